@@ -17,6 +17,7 @@ import (
 	duckdbadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/duckdb"
 	postgresadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/postgres"
 	sqliteadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/sqlite"
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/storage/history"
 	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
 	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
@@ -25,6 +26,10 @@ const (
 	DefaultResourcesPath   = "./resources.json"
 	DefaultPort            = 8080
 	maxMaterializedRowsEnv = "ORI_MAX_MATERIALIZED_ROWS"
+	resultSpillDirEnv      = "ORI_RESULT_SPILL_DIR"
+	resultSpillRowsEnv     = "ORI_RESULT_SPILL_ROWS"
+	resultDiskQuotaEnv     = "ORI_RESULT_DISK_QUOTA_MB"
+	historyPathEnv         = "ORI_HISTORY_PATH"
 )
 
 var (
@@ -72,6 +77,11 @@ func run() int {
 		slog.ErrorContext(ctx, "invalid query materialization limit", slog.Any("err", limitErr))
 		return 1
 	}
+	resultSpill, spillErr := resultSpillFromEnv(ctx)
+	if spillErr != nil {
+		slog.ErrorContext(ctx, "invalid result spill configuration", slog.Any("err", spillErr))
+		return 1
+	}
 
 	var parentDone <-chan struct{}
 	if !*standalone {
@@ -99,14 +109,23 @@ func run() int {
 	connectionService.RegisterAdapter("postgres", postgresadapter.NewAdapter)
 
 	nodeService := service.NewNodeService(configService, connectionService)
-	queryService := service.NewQueryService(connectionService, eventHub, ctx, maxMaterializedRows)
+	queryService := service.NewQueryService(connectionService, eventHub, ctx, maxMaterializedRows, resultSpill)
+	queryService.RegisterExporter(service.ExportParquet, duckdbadapter.ParquetExporter{})
+
+	// History is best effort: the server still runs queries when the database cannot be opened.
+	historyStore, err := history.Open(ctx, historyPath())
+	if err != nil {
+		slog.WarnContext(ctx, "query history disabled", slog.Any("err", err))
+	} else {
+		defer func() {
+			_ = historyStore.Close()
+		}()
+		queryService.SetHistoryStore(historyStore)
+	}
 
 	handler := httpapi.NewHandler(configService, connectionService, nodeService, queryService)
 
-	var (
-		server *httpapi.Server
-		err    error
-	)
+	var server *httpapi.Server
 	if *socketPath != "" {
 		server, err = httpapi.NewUnixServer(ctx, handler, eventHub, *socketPath)
 		if err != nil {
@@ -150,16 +169,51 @@ func run() int {
 }
 
 func maxMaterializedRowsFromEnv() (int, error) {
-	value, ok := os.LookupEnv(maxMaterializedRowsEnv)
+	return positiveIntFromEnv(maxMaterializedRowsEnv, service.DefaultMaxMaterializedRows)
+}
+
+// resultSpillFromEnv configures where large query results overflow to disk. The
+// default directory is best effort: when it cannot be used, results stay in memory
+// up to the materialization limit. Only an explicit directory that fails is an error.
+func resultSpillFromEnv(ctx context.Context) (*service.ResultSpill, error) {
+	memoryRows, err := positiveIntFromEnv(resultSpillRowsEnv, service.DefaultSpillMemoryRows)
+	if err != nil {
+		return nil, err
+	}
+	quotaMB, err := positiveIntFromEnv(resultDiskQuotaEnv, int(service.DefaultSpillDiskQuota>>20))
+	if err != nil {
+		return nil, err
+	}
+	if dir := os.Getenv(resultSpillDirEnv); dir != "" {
+		return service.NewResultSpill(dir, memoryRows, int64(quotaMB)<<20)
+	}
+	spill, err := service.NewResultSpill(filepath.Join(defaultStateDir(), "results"), memoryRows, int64(quotaMB)<<20)
+	if err != nil {
+		slog.WarnContext(ctx, "result spill disabled", slog.Any("err", err))
+		return nil, nil
+	}
+	return spill, nil
+}
+
+// historyPath returns the location of the query history database.
+func historyPath() string {
+	if path := os.Getenv(historyPathEnv); path != "" {
+		return path
+	}
+	return filepath.Join(defaultStateDir(), "history.db")
+}
+
+func positiveIntFromEnv(name string, def int) (int, error) {
+	value, ok := os.LookupEnv(name)
 	if !ok {
-		return service.DefaultMaxMaterializedRows, nil
+		return def, nil
 	}
 
-	maxRows, err := strconv.Atoi(value)
-	if err != nil || maxRows <= 0 {
-		return 0, fmt.Errorf("%s must be a positive integer, got %q", maxMaterializedRowsEnv, value)
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer, got %q", name, value)
 	}
-	return maxRows, nil
+	return parsed, nil
 }
 
 // monitorParentAlive monitors if the parent process is still alive
@@ -239,3 +293,13 @@ func defaultLogDir() string {
 	}
 	return filepath.Join(os.TempDir(), "ori")
 }
+
+func defaultStateDir() string {
+	if x := os.Getenv("XDG_STATE_HOME"); x != "" {
+		return filepath.Join(x, "ori")
+	}
+	if home, _ := os.UserHomeDir(); home != "" {
+		return filepath.Join(home, ".local", "state", "ori")
+	}
+	return filepath.Join(os.TempDir(), "ori")
+}
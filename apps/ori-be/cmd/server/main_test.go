@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/service"
@@ -80,3 +82,47 @@ func TestMaxMaterializedRowsFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestResultSpillFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(resultSpillDirEnv, dir)
+	t.Setenv(resultSpillRowsEnv, "250")
+	t.Setenv(resultDiskQuotaEnv, "16")
+
+	spill, err := resultSpillFromEnv(context.Background())
+	if err != nil {
+		t.Fatalf("resultSpillFromEnv: %v", err)
+	}
+	if spill.Dir != dir {
+		t.Fatalf("Dir = %q, want %q", spill.Dir, dir)
+	}
+	if spill.MemoryRows != 250 {
+		t.Fatalf("MemoryRows = %d, want 250", spill.MemoryRows)
+	}
+	if spill.DiskQuota != 16<<20 {
+		t.Fatalf("DiskQuota = %d, want %d", spill.DiskQuota, 16<<20)
+	}
+
+	t.Setenv(resultDiskQuotaEnv, "none")
+	if _, err := resultSpillFromEnv(context.Background()); err == nil {
+		t.Fatal("expected error for invalid disk quota")
+	}
+	t.Setenv(resultDiskQuotaEnv, "16")
+
+	// A file where the state directory should be makes every spill directory unusable.
+	blocked := filepath.Join(t.TempDir(), "blocked")
+	if err := os.WriteFile(blocked, nil, 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	t.Setenv("XDG_STATE_HOME", blocked)
+	t.Setenv(resultSpillDirEnv, "")
+	spill, err = resultSpillFromEnv(context.Background())
+	if err != nil || spill != nil {
+		t.Fatalf("unusable default directory: spill=%v err=%v, want spill disabled", spill, err)
+	}
+
+	t.Setenv(resultSpillDirEnv, filepath.Join(blocked, "results"))
+	if _, err := resultSpillFromEnv(context.Background()); err == nil {
+		t.Fatal("expected error for an unusable configured directory")
+	}
+}
@@ -5,6 +5,10 @@ const (
 	ConnectionStateEvent = "connection.state"
 	// QueryJobCompletedEvent is emitted when a query job completes.
 	QueryJobCompletedEvent = "query.job.completed"
+	// QueryJobProgressEvent is emitted periodically while a query job runs.
+	QueryJobProgressEvent = "query.job.progress"
+	// QueryJobNoticeEvent is emitted with the notices the database sent since the previous one while a query job runs.
+	QueryJobNoticeEvent = "query.job.notice"
 
 	ConnectionStateConnecting = "connecting"
 	ConnectionStateConnected  = "connected"
@@ -27,4 +31,29 @@ type QueryJobCompletedPayload struct {
 	Error        string `json:"error,omitempty"`
 	Message      string `json:"message,omitempty"`
 	Stored       bool   `json:"stored"`
+	SessionID    string `json:"sessionId,omitempty"`
+	TxState      string `json:"txState,omitempty"`
+}
+
+type QueryJobProgressPayload struct {
+	JobID             string `json:"jobId"`
+	ResourceName      string `json:"resourceName"`
+	Statement         *int   `json:"statement,omitempty"`
+	RowsFetched       int    `json:"rowsFetched"`
+	BytesMaterialized int64  `json:"bytesMaterialized"`
+	ElapsedMs         int64  `json:"elapsedMs"`
+}
+
+type QueryJobNoticePayload struct {
+	JobID          string           `json:"jobId"`
+	ResourceName   string           `json:"resourceName"`
+	Notices        []QueryJobNotice `json:"notices"`
+	DroppedNotices int              `json:"droppedNotices,omitempty"`
+}
+
+type QueryJobNotice struct {
+	Statement *int   `json:"statement,omitempty"`
+	Severity  string `json:"severity"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
 }
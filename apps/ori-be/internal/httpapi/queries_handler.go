@@ -39,7 +39,7 @@ func (h *Handler) execQuery(w http.ResponseWriter, r *http.Request) {
 
 	if payload.Params != nil {
 		if obj, err := payload.Params.AsQueryExecRequestParams0(); err == nil {
-			params = obj
+			params = map[string]any(obj)
 		} else if arr, err := payload.Params.AsQueryExecRequestParams1(); err == nil {
 			params = arr
 		} else {
@@ -49,33 +49,80 @@ func (h *Handler) execQuery(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var options *service.QueryExecOptions
-	if payload.Options != nil {
+	if payload.Options != nil || payload.SessionId != nil {
 		options = &service.QueryExecOptions{}
+	}
+	if payload.SessionId != nil {
+		options.SessionID = uuid.UUID(*payload.SessionId).String()
+	}
+	if payload.Options != nil {
 		if payload.Options.MaxRows != nil {
 			options.MaxRows = *payload.Options.MaxRows
 		}
+		if payload.Options.Script != nil {
+			options.Script = *payload.Options.Script
+		}
+		if payload.Options.OnError != nil {
+			options.OnError = service.ScriptErrorMode(*payload.Options.OnError)
+		}
+		if payload.Options.Lazy != nil {
+			options.Lazy = *payload.Options.Lazy
+		}
+		if payload.Options.FetchRows != nil {
+			options.FetchRows = *payload.Options.FetchRows
+		}
+		if payload.Options.TimeoutMs != nil {
+			options.TimeoutMs = *payload.Options.TimeoutMs
+		}
+		if payload.Options.ConfirmToken != nil {
+			options.ConfirmToken = *payload.Options.ConfirmToken
+		}
 	}
 
 	ctx := logctx.WithField(r.Context(), "resource", payload.ResourceName)
 	job, err := h.queries.Exec(ctx, payload.ResourceName, jobID, payload.Query, params, options)
+	var confirmErr *service.ConfirmationRequiredError
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrConnectionUnavailable):
 			respondError(w, http.StatusConflict, "connection_not_ready", err.Error(), nil)
+		case errors.As(err, &confirmErr):
+			respondError(w, http.StatusPreconditionRequired, "confirmation_required", err.Error(), map[string]any{
+				"risks":        confirmErr.Risks,
+				"confirmToken": confirmErr.Token,
+			})
 		case errors.Is(err, service.ErrJobAlreadyExists):
 			respondError(w, http.StatusConflict, "job_already_exists", err.Error(), nil)
 		case errors.Is(err, service.ErrMaxRowsExceeded):
 			respondError(w, http.StatusBadRequest, "max_rows_exceeded", err.Error(), nil)
+		case errors.Is(err, service.ErrInvalidParams):
+			respondError(w, http.StatusBadRequest, "invalid_params", err.Error(), nil)
+		case errors.Is(err, service.ErrInvalidOptions):
+			respondError(w, http.StatusBadRequest, "invalid_options", err.Error(), nil)
+		case errors.Is(err, service.ErrReadOnlyResource):
+			respondError(w, http.StatusForbidden, "read_only_resource", err.Error(), nil)
+		case errors.Is(err, service.ErrSessionNotFound):
+			respondError(w, http.StatusNotFound, "session_not_found", err.Error(), nil)
+		case errors.Is(err, service.ErrSessionBusy):
+			respondError(w, http.StatusConflict, "session_busy", err.Error(), nil)
 		default:
 			respondError(w, http.StatusInternalServerError, "query_exec_failed", err.Error(), nil)
 		}
 		return
 	}
 
-	respondJSON(w, http.StatusAccepted, dto.QueryExecResponse{
+	response := dto.QueryExecResponse{
 		JobId:  job.ID,
 		Status: dto.QueryExecResponseStatusRunning,
-	})
+	}
+	if len(job.Warnings) > 0 {
+		response.Warnings = &job.Warnings
+	}
+	if status, err := h.queries.GetStatus(job.ID); err == nil && status.Status == service.JobStatusQueued {
+		response.Status = dto.QueryExecResponseStatusQueued
+		response.QueuePosition = &status.QueuePosition
+	}
+	respondJSON(w, http.StatusAccepted, response)
 }
 
 func (h *Handler) cancelQuery(w http.ResponseWriter, r *http.Request) {
@@ -127,5 +174,63 @@ func (h *Handler) getQueryStatus(w http.ResponseWriter, r *http.Request) {
 	if status.Error != "" {
 		response.Error = &status.Error
 	}
+	response.DatabaseError = databaseErrorToDTO(status.DatabaseError)
+	if status.QueuePosition > 0 {
+		response.QueuePosition = &status.QueuePosition
+	}
+	if status.SessionID != "" {
+		txState := dto.QueryTxState(status.TxState)
+		response.SessionId = &status.SessionID
+		response.TxState = &txState
+	}
+	if len(status.Statements) > 0 {
+		statements := make([]dto.QueryStatementStatus, len(status.Statements))
+		for i, statement := range status.Statements {
+			statements[i] = statementStatusToDTO(statement)
+		}
+		response.Statements = &statements
+	}
+	if len(status.Notices) > 0 {
+		notices := noticesToDTO(status.Notices)
+		response.Notices = &notices
+	}
+	if status.DroppedNotices > 0 {
+		response.DroppedNotices = &status.DroppedNotices
+	}
 	respondJSON(w, http.StatusOK, response)
 }
+
+func noticesToDTO(notices []service.QueryNotice) []dto.QueryNotice {
+	out := make([]dto.QueryNotice, len(notices))
+	for i, notice := range notices {
+		out[i] = dto.QueryNotice{
+			Severity:  notice.Severity,
+			Code:      notice.Code,
+			Message:   notice.Message,
+			Statement: notice.Statement,
+		}
+	}
+	return out
+}
+
+func statementStatusToDTO(statement service.StatementSummary) dto.QueryStatementStatus {
+	out := dto.QueryStatementStatus{
+		Index:      statement.Index,
+		Line:       statement.Line,
+		Status:     dto.QueryStatementStatusStatus(statement.Status),
+		DurationMs: statement.DurationMs,
+		RowCount:   statement.RowCount,
+	}
+	if statement.RowsAffected != nil {
+		v := int(*statement.RowsAffected)
+		out.RowsAffected = &v
+	}
+	if statement.CommandTag != "" {
+		out.CommandTag = &statement.CommandTag
+	}
+	if statement.Error != "" {
+		out.Error = &statement.Error
+	}
+	out.DatabaseError = databaseErrorToDTO(statement.DatabaseError)
+	return out
+}
@@ -0,0 +1,156 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// exportMediaTypes maps export formats to their content type and file extension.
+var exportMediaTypes = map[service.ExportFormat]struct {
+	contentType string
+	extension   string
+}{
+	service.ExportCSV:       {"text/csv; charset=utf-8", "csv"},
+	service.ExportTSV:       {"text/tab-separated-values; charset=utf-8", "tsv"},
+	service.ExportJSONLines: {"application/x-ndjson", "jsonl"},
+	service.ExportMarkdown:  {"text/markdown; charset=utf-8", "md"},
+	service.ExportParquet:   {"application/vnd.apache.parquet", "parquet"},
+}
+
+func (h *Handler) exportQueryResult(w http.ResponseWriter, r *http.Request) {
+	jobID, err := decodePathParam(r, "jobId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_job", err.Error(), nil)
+		return
+	}
+
+	query := r.URL.Query()
+	statement, err := optionalInt(query.Get("statement"), 0)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_statement", err.Error(), nil)
+		return
+	}
+	header, err := optionalBool(query.Get("header"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_header", err.Error(), nil)
+		return
+	}
+	var nullText *string
+	if query.Has("null") {
+		value := query.Get("null")
+		nullText = &value
+	}
+
+	options := exportOptions(query.Get("format"), statement, header, nullText)
+	media, ok := exportMediaTypes[options.Format]
+	if !ok {
+		respondError(w, http.StatusBadRequest, "unsupported_format", fmt.Sprintf("unsupported export format %q", options.Format), nil)
+		return
+	}
+
+	export, err := h.queries.ExportResult(r.Context(), jobID, options)
+	if err != nil {
+		respondExportError(w, err)
+		return
+	}
+
+	// Large exports outlast the server's write timeout.
+	disableWriteDeadline(w, r)
+	w.Header().Set("Content-Type", media.contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, jobID, media.extension))
+	w.WriteHeader(http.StatusOK)
+	// The status is already sent, so a failure midway can only truncate the stream.
+	if err := export.WriteTo(r.Context(), w); err != nil {
+		slog.WarnContext(r.Context(), "query result export interrupted",
+			slog.String("jobId", jobID),
+			slog.Any("err", err))
+	}
+}
+
+func (h *Handler) exportQueryResultToFile(w http.ResponseWriter, r *http.Request) {
+	jobID, err := decodePathParam(r, "jobId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_job", err.Error(), nil)
+		return
+	}
+
+	var payload dto.QueryExportFileRequest
+	if err := decodeJSON(r.Body, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_body", err.Error(), nil)
+		return
+	}
+	if strings.TrimSpace(payload.Path) == "" {
+		respondError(w, http.StatusBadRequest, "missing_path", "path is required", nil)
+		return
+	}
+	if payload.Statement != nil && *payload.Statement < 0 {
+		respondError(w, http.StatusBadRequest, "invalid_statement", "statement must be >= 0", nil)
+		return
+	}
+
+	options := exportOptions(string(payload.Format), payload.Statement, payload.Header, payload.Null)
+	overwrite := payload.Overwrite != nil && *payload.Overwrite
+
+	// Writing the file may outlast the server's write timeout, which would drop the response.
+	disableWriteDeadline(w, r)
+	ctx := logctx.WithField(r.Context(), "jobId", jobID)
+	info, err := h.queries.ExportResultToFile(ctx, jobID, payload.Path, overwrite, options)
+	if err != nil {
+		respondExportError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.QueryExportFileResponse{
+		Path:     info.Path,
+		Bytes:    info.Bytes,
+		RowCount: info.RowCount,
+	})
+}
+
+func disableWriteDeadline(w http.ResponseWriter, r *http.Request) {
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		slog.WarnContext(r.Context(), "failed to disable write deadline for export", slog.Any("err", err))
+	}
+}
+
+func exportOptions(format string, statement *int, header *bool, nullText *string) *service.ExportOptions {
+	options := &service.ExportOptions{
+		Format:    service.ExportFormat(strings.ToLower(strings.TrimSpace(format))),
+		Statement: statement,
+		Header:    true,
+		NullText:  nullText,
+	}
+	if header != nil {
+		options.Header = *header
+	}
+	return options
+}
+
+func respondExportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedExportFormat):
+		respondError(w, http.StatusBadRequest, "unsupported_format", err.Error(), nil)
+	case errors.Is(err, service.ErrNotFound):
+		respondError(w, http.StatusNotFound, "job_not_found", err.Error(), nil)
+	case errors.Is(err, service.ErrStatementNotFound):
+		respondError(w, http.StatusNotFound, "statement_not_found", err.Error(), nil)
+	case errors.Is(err, fs.ErrExist):
+		respondError(w, http.StatusConflict, "export_file_exists", err.Error(), nil)
+	case errors.Is(err, service.ErrInvalidExportPath):
+		respondError(w, http.StatusBadRequest, "invalid_path", err.Error(), nil)
+	case errors.Is(err, service.ErrResultUnavailable):
+		respondError(w, http.StatusBadRequest, "result_unavailable", err.Error(), nil)
+	default:
+		respondError(w, http.StatusInternalServerError, "query_export_failed", err.Error(), nil)
+	}
+}
@@ -0,0 +1,111 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/events"
+	sqliteadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/sqlite"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// slowExporter writes its output in two parts with a pause in between.
+type slowExporter struct {
+	pause time.Duration
+}
+
+func (e slowExporter) Export(ctx context.Context, _ *service.QueryResult, _ *service.ExportOptions, w io.Writer) error {
+	if _, err := io.WriteString(w, "n\n"); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(e.pause):
+	}
+	_, err := io.WriteString(w, "1\n")
+	return err
+}
+
+func TestExportOutlastsWriteTimeout(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	configPath := filepath.Join(root, "resources.json")
+	config := `{"resources":[{"name":"local-sqlite","type":"sqlite","database":"./export.db"}]}`
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	configService := service.NewResourceCatalogService(configPath)
+	if err := configService.LoadResources(); err != nil {
+		t.Fatalf("LoadResources: %v", err)
+	}
+	hub := events.NewHub()
+	connections := service.NewResourceSessionService(configService, hub)
+	connections.RegisterAdapter("sqlite", sqliteadapter.NewAdapter)
+	queries := service.NewQueryService(connections, hub, ctx, service.DefaultMaxMaterializedRows, nil)
+	t.Cleanup(queries.Stop)
+	queries.RegisterExporter(service.ExportCSV, slowExporter{pause: 300 * time.Millisecond})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for connections.Connect(ctx, "local-sqlite").Result != service.ResourceConnectResultSuccess {
+		if time.Now().After(deadline) {
+			t.Fatal("resource did not connect")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	job, err := queries.Exec(ctx, "local-sqlite", uuid.NewString(), "SELECT 1 AS n", nil, nil)
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	for {
+		status, err := queries.GetStatus(job.ID)
+		if err != nil {
+			t.Fatalf("GetStatus: %v", err)
+		}
+		if status.Status == service.JobStatusSuccess {
+			break
+		}
+		if status.FinishedAt != nil || time.Now().After(deadline) {
+			t.Fatalf("job did not succeed: %+v", status)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	handler := NewHandler(configService, connections, service.NewNodeService(configService, connections), queries)
+	srv := httptest.NewUnstartedServer((&Server{handler: handler, events: hub}).buildMux())
+	srv.Config.WriteTimeout = 100 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	resp, err := http.Get(srv.URL + "/queries/" + job.ID + "/export?format=csv")
+	if err != nil {
+		t.Fatalf("GET export: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK || string(body) != "n\n1\n" {
+		t.Fatalf("GET export: status=%d body=%q err=%v", resp.StatusCode, body, err)
+	}
+
+	target := filepath.Join(root, "out.csv")
+	payload := fmt.Sprintf(`{"path":%q,"format":"csv"}`, target)
+	resp, err = http.Post(srv.URL+"/queries/"+job.ID+"/export", "application/json", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("POST export: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST export: status=%d", resp.StatusCode)
+	}
+}
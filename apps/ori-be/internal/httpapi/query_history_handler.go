@@ -0,0 +1,108 @@
+package httpapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func (h *Handler) getQueryHistory(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := &service.QueryHistoryFilter{
+		Search:       query.Get("q"),
+		ResourceName: query.Get("resource"),
+	}
+
+	limit, err := optionalInt(query.Get("limit"), 1)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_limit", err.Error(), nil)
+		return
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	offset, err := optionalInt(query.Get("offset"), 0)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_offset", err.Error(), nil)
+		return
+	}
+	if offset != nil {
+		filter.Offset = *offset
+	}
+
+	for _, value := range query["status"] {
+		switch status := service.JobStatus(value); status {
+		case service.JobStatusSuccess, service.JobStatusFailed, service.JobStatusCanceled, service.JobStatusTimedOut:
+			filter.Statuses = append(filter.Statuses, status)
+		default:
+			respondError(w, http.StatusBadRequest, "invalid_status", fmt.Sprintf("unknown status %q", value), nil)
+			return
+		}
+	}
+	if filter.Since, err = optionalTime(query.Get("since")); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_since", err.Error(), nil)
+		return
+	}
+	if filter.Until, err = optionalTime(query.Get("until")); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_until", err.Error(), nil)
+		return
+	}
+
+	page, err := h.queries.SearchHistory(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, service.ErrHistoryUnavailable) {
+			respondError(w, http.StatusServiceUnavailable, "history_unavailable", err.Error(), nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "query_history_failed", err.Error(), nil)
+		return
+	}
+
+	entries := make([]dto.QueryHistoryEntry, len(page.Entries))
+	for i, entry := range page.Entries {
+		entries[i] = historyEntryToDTO(entry)
+	}
+	respondJSON(w, http.StatusOK, dto.QueryHistoryResponse{
+		Entries: entries,
+		Total:   page.Total,
+	})
+}
+
+func historyEntryToDTO(entry *service.QueryHistoryEntry) dto.QueryHistoryEntry {
+	out := dto.QueryHistoryEntry{
+		JobId:        entry.JobID,
+		ResourceName: entry.ResourceName,
+		Query:        entry.Query,
+		Status:       dto.QueryHistoryStatus(entry.Status),
+		RowCount:     entry.RowCount,
+		RowsAffected: entry.RowsAffected,
+		DurationMs:   entry.DurationMs,
+		Script:       entry.Script,
+		CreatedAt:    entry.CreatedAt,
+		StartedAt:    entry.StartedAt,
+		FinishedAt:   entry.FinishedAt,
+	}
+	if entry.Error != "" {
+		out.Error = &entry.Error
+	}
+	if entry.SessionID != "" {
+		out.SessionId = &entry.SessionID
+	}
+
+	var params dto.QueryHistoryEntry_Params
+	switch value := entry.Params.(type) {
+	case map[string]any:
+		if err := params.FromQueryHistoryEntryParams0(value); err == nil {
+			out.Params = &params
+		}
+	case []any:
+		if err := params.FromQueryHistoryEntryParams1(value); err == nil {
+			out.Params = &params
+		}
+	}
+	return out
+}
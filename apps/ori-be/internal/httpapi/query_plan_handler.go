@@ -0,0 +1,100 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func (h *Handler) explainQuery(w http.ResponseWriter, r *http.Request) {
+	var payload dto.QueryExplainRequest
+	if err := decodeJSON(r.Body, &payload); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_body", err.Error(), nil)
+		return
+	}
+
+	if strings.TrimSpace(payload.ResourceName) == "" {
+		respondError(w, http.StatusBadRequest, "missing_resource", "resourceName is required", nil)
+		return
+	}
+	if strings.TrimSpace(payload.Query) == "" {
+		respondError(w, http.StatusBadRequest, "missing_query", "query is required", nil)
+		return
+	}
+
+	var params any
+	if payload.Params != nil {
+		if obj, err := payload.Params.AsQueryExplainRequestParams0(); err == nil {
+			params = map[string]any(obj)
+		} else if arr, err := payload.Params.AsQueryExplainRequestParams1(); err == nil {
+			params = arr
+		} else {
+			respondError(w, http.StatusBadRequest, "invalid_params", "params must be an object or array", nil)
+			return
+		}
+	}
+
+	options := &service.QueryExplainOptions{}
+	if payload.Analyze != nil {
+		options.Analyze = *payload.Analyze
+	}
+
+	ctx := logctx.WithField(r.Context(), "resource", payload.ResourceName)
+	plan, err := h.queries.Explain(ctx, payload.ResourceName, payload.Query, params, options)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConnectionUnavailable):
+			respondError(w, http.StatusConflict, "connection_not_ready", err.Error(), nil)
+		case errors.Is(err, service.ErrInvalidParams):
+			respondError(w, http.StatusBadRequest, "invalid_params", err.Error(), nil)
+		case errors.Is(err, service.ErrInvalidOptions):
+			respondError(w, http.StatusBadRequest, "invalid_options", err.Error(), nil)
+		case errors.Is(err, service.ErrReadOnlyResource):
+			respondError(w, http.StatusForbidden, "read_only_resource", err.Error(), nil)
+		case errors.Is(err, service.ErrExplainUnsupported):
+			respondError(w, http.StatusBadRequest, "explain_unsupported", err.Error(), nil)
+		case errors.Is(err, service.ErrExplainFailed):
+			respondDatabaseError(w, http.StatusBadRequest, "explain_failed", err)
+		default:
+			respondDatabaseError(w, http.StatusInternalServerError, "query_explain_failed", err)
+		}
+		return
+	}
+
+	response := dto.QueryPlanResponse{
+		Analyzed:        plan.Analyzed,
+		PlanningTimeMs:  plan.PlanningTimeMs,
+		ExecutionTimeMs: plan.ExecutionTimeMs,
+		Nodes:           queryPlanNodesToDTO(plan.Nodes),
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+func queryPlanNodesToDTO(nodes []*service.QueryPlanNode) []dto.QueryPlanNode {
+	out := make([]dto.QueryPlanNode, 0, len(nodes))
+	for _, node := range nodes {
+		item := dto.QueryPlanNode{
+			NodeType:      node.NodeType,
+			EstimatedRows: node.EstimatedRows,
+			ActualRows:    node.ActualRows,
+			StartupCost:   node.StartupCost,
+			TotalCost:     node.TotalCost,
+			ActualTimeMs:  node.ActualTimeMs,
+			Loops:         node.Loops,
+			Children:      queryPlanNodesToDTO(node.Children),
+		}
+		if node.Relation != "" {
+			item.Relation = &node.Relation
+		}
+		if node.Detail != "" {
+			item.Detail = &node.Detail
+		}
+		out = append(out, item)
+	}
+	return out
+}
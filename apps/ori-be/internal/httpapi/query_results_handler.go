@@ -28,11 +28,39 @@ func (h *Handler) getQueryResult(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	view, err := h.queries.BuildResultView(r.Context(), jobID, limit, offset)
+	statement, err := optionalInt(r.URL.Query().Get("statement"), 0)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_statement", err.Error(), nil)
+		return
+	}
+
+	viewQuery := &service.ResultViewQuery{}
+	for _, expr := range r.URL.Query()["sort"] {
+		sort, err := service.ParseResultSort(expr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid_sort", err.Error(), nil)
+			return
+		}
+		viewQuery.Sort = append(viewQuery.Sort, sort)
+	}
+	for _, expr := range r.URL.Query()["filter"] {
+		filter, err := service.ParseResultFilter(expr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "invalid_filter", err.Error(), nil)
+			return
+		}
+		viewQuery.Filters = append(viewQuery.Filters, filter)
+	}
+
+	view, err := h.queries.BuildResultView(r.Context(), jobID, statement, limit, offset, viewQuery)
 	if err != nil {
 		switch {
 		case errors.Is(err, service.ErrNotFound):
 			respondError(w, http.StatusNotFound, "job_not_found", err.Error(), nil)
+		case errors.Is(err, service.ErrStatementNotFound):
+			respondError(w, http.StatusNotFound, "statement_not_found", err.Error(), nil)
+		case errors.Is(err, service.ErrInvalidViewQuery):
+			respondError(w, http.StatusBadRequest, "invalid_view_query", err.Error(), nil)
 		default:
 			respondError(w, http.StatusBadRequest, "result_unavailable", err.Error(), nil)
 		}
@@ -50,11 +78,25 @@ func (h *Handler) getQueryResult(w http.ResponseWriter, r *http.Request) {
 		rowsAffected = &v
 	}
 
-	respondJSON(w, http.StatusOK, dto.QueryResultResponse{
+	response := dto.QueryResultResponse{
 		Columns:      columns,
 		Rows:         view.Rows,
 		RowCount:     view.RowCount,
 		Truncated:    view.Truncated,
 		RowsAffected: rowsAffected,
-	})
+	}
+	if view.CommandTag != "" {
+		response.CommandTag = &view.CommandTag
+	}
+	if view.Partial {
+		response.Partial = &view.Partial
+	}
+	if view.HasMore {
+		response.HasMore = &view.HasMore
+	}
+	if len(view.Notices) > 0 {
+		notices := noticesToDTO(view.Notices)
+		response.Notices = &notices
+	}
+	respondJSON(w, http.StatusOK, response)
 }
@@ -0,0 +1,105 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func (h *Handler) openQuerySession(w http.ResponseWriter, r *http.Request) {
+	resourceName, err := decodePathParam(r, "resourceName")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_resource", err.Error(), nil)
+		return
+	}
+
+	ctx := logctx.WithField(r.Context(), "resource", resourceName)
+	session, err := h.queries.OpenSession(ctx, resourceName)
+	if err != nil {
+		if errors.Is(err, service.ErrConnectionUnavailable) {
+			respondError(w, http.StatusConflict, "connection_not_ready", err.Error(), nil)
+			return
+		}
+		if errors.Is(err, service.ErrSessionsUnsupported) {
+			respondError(w, http.StatusBadRequest, "sessions_unsupported", err.Error(), nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "session_open_failed", err.Error(), nil)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, querySessionToDTO(session))
+}
+
+func (h *Handler) getQuerySession(w http.ResponseWriter, r *http.Request) {
+	resourceName, sessionID, ok := decodeSessionPath(w, r)
+	if !ok {
+		return
+	}
+
+	session, err := h.queries.GetSession(resourceName, sessionID)
+	if err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			respondError(w, http.StatusNotFound, "session_not_found", err.Error(), nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "session_status_failed", err.Error(), nil)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, querySessionToDTO(session))
+}
+
+func (h *Handler) closeQuerySession(w http.ResponseWriter, r *http.Request) {
+	resourceName, sessionID, ok := decodeSessionPath(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := logctx.WithField(r.Context(), "resource", resourceName)
+	if err := h.queries.CloseSession(ctx, resourceName, sessionID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrSessionNotFound):
+			respondError(w, http.StatusNotFound, "session_not_found", err.Error(), nil)
+		case errors.Is(err, service.ErrSessionBusy):
+			respondError(w, http.StatusConflict, "session_busy", err.Error(), nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "session_close_failed", err.Error(), nil)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func decodeSessionPath(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	resourceName, err := decodePathParam(r, "resourceName")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_resource", err.Error(), nil)
+		return "", "", false
+	}
+	sessionID, err := decodePathParam(r, "sessionId")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_session", err.Error(), nil)
+		return "", "", false
+	}
+	return resourceName, sessionID, true
+}
+
+func querySessionToDTO(session *service.QuerySessionInfo) dto.QuerySession {
+	out := dto.QuerySession{
+		SessionId:    session.ID,
+		ResourceName: session.ResourceName,
+		TxState:      dto.QueryTxState(session.TxState),
+		CreatedAt:    session.CreatedAt,
+		LastUsedAt:   session.LastUsedAt,
+	}
+	if session.ActiveJobID != "" {
+		out.ActiveJobId = &session.ActiveJobID
+	}
+	return out
+}
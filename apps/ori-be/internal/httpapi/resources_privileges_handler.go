@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func (h *Handler) getResourcePrivileges(w http.ResponseWriter, r *http.Request) {
+	resourceName, err := decodePathParam(r, "resourceName")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid_resource", err.Error(), nil)
+		return
+	}
+	resourceName = strings.TrimSpace(resourceName)
+	if resourceName == "" {
+		respondError(w, http.StatusBadRequest, "missing_resource", "resourceName is required", nil)
+		return
+	}
+	role := strings.TrimSpace(r.URL.Query().Get("role"))
+	schema := strings.TrimSpace(r.URL.Query().Get("schema"))
+
+	ctx := logctx.WithField(r.Context(), "resource", resourceName)
+	privileges, err := h.nodes.GetRolePrivileges(ctx, resourceName, role, schema)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrConnectionUnavailable):
+			respondError(w, http.StatusConflict, "connection_not_ready", err.Error(), nil)
+		case errors.Is(err, service.ErrPrivilegesUnsupported):
+			respondError(w, http.StatusBadRequest, "privileges_unsupported", err.Error(), nil)
+		case errors.Is(err, service.ErrUnknownRole):
+			respondError(w, http.StatusNotFound, "role_not_found", err.Error(), nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "privileges_fetch_failed", err.Error(), nil)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, rolePrivilegesToDTO(privileges))
+}
+
+func rolePrivilegesToDTO(privileges *model.RolePrivileges) dto.RolePrivilegesResponse {
+	objects := make([]dto.ObjectPrivileges, 0, len(privileges.Objects))
+	for _, object := range privileges.Objects {
+		grants := make([]dto.RolePrivilege, 0, len(object.Privileges))
+		for _, grant := range object.Privileges {
+			grants = append(grants, dto.RolePrivilege{
+				Privilege:   grant.Privilege,
+				GrantOption: grant.GrantOption,
+			})
+		}
+		objects = append(objects, dto.ObjectPrivileges{
+			ObjectType: dto.ObjectPrivilegesObjectType(object.ObjectType),
+			Schema:     object.Schema,
+			Name:       object.Name,
+			Privileges: grants,
+		})
+	}
+	return dto.RolePrivilegesResponse{
+		Role:      privileges.Role,
+		Superuser: privileges.Superuser,
+		Objects:   objects,
+	}
+}
@@ -2,8 +2,10 @@ package httpapi
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 	dto "github.com/crueladdict/ori/libs/contract/go"
 )
 
@@ -20,3 +22,47 @@ func respondError(w http.ResponseWriter, status int, code, message string, detai
 	}
 	respondJSON(w, status, resp)
 }
+
+// respondDatabaseError reports err like respondError, adding the structured fields of
+// the database error it carries, if any.
+func respondDatabaseError(w http.ResponseWriter, status int, code string, err error) {
+	resp := dto.ErrorPayload{Code: code, Message: err.Error()}
+	var dbErr *service.DatabaseError
+	if errors.As(err, &dbErr) {
+		resp.DatabaseError = databaseErrorToDTO(dbErr)
+	}
+	respondJSON(w, status, resp)
+}
+
+func databaseErrorToDTO(err *service.DatabaseError) *dto.DatabaseError {
+	if err == nil {
+		return nil
+	}
+	optionalString := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	optionalNumber := func(value int) *int {
+		if value <= 0 {
+			return nil
+		}
+		return &value
+	}
+	return &dto.DatabaseError{
+		Engine:     err.Engine,
+		Code:       optionalString(err.Code),
+		Severity:   optionalString(err.Severity),
+		Message:    err.Message,
+		Detail:     optionalString(err.Detail),
+		Hint:       optionalString(err.Hint),
+		Position:   optionalNumber(err.Position),
+		Line:       optionalNumber(err.Line),
+		Column:     optionalNumber(err.Column),
+		Schema:     optionalString(err.Schema),
+		Table:      optionalString(err.Table),
+		ColumnName: optionalString(err.ColumnName),
+		Constraint: optionalString(err.Constraint),
+	}
+}
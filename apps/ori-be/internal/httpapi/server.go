@@ -65,11 +65,19 @@ func (s *Server) buildMux() *http.ServeMux {
 	mux.HandleFunc("GET /events", s.handleEvents)
 	mux.HandleFunc("GET /resources", s.handler.listResources)
 	mux.HandleFunc("GET /resources/{resourceName}/nodes", s.handler.getResourceNodes)
+	mux.HandleFunc("GET /resources/{resourceName}/privileges", s.handler.getResourcePrivileges)
 	mux.HandleFunc("POST /resources/connect", s.handler.connectResource)
+	mux.HandleFunc("POST /resources/{resourceName}/sessions", s.handler.openQuerySession)
+	mux.HandleFunc("GET /resources/{resourceName}/sessions/{sessionId}", s.handler.getQuerySession)
+	mux.HandleFunc("DELETE /resources/{resourceName}/sessions/{sessionId}", s.handler.closeQuerySession)
 	mux.HandleFunc("POST /queries", s.handler.execQuery)
+	mux.HandleFunc("POST /queries/explain", s.handler.explainQuery)
 	mux.HandleFunc("GET /queries/{jobId}", s.handler.getQueryStatus)
 	mux.HandleFunc("POST /queries/{jobId}/cancel", s.handler.cancelQuery)
 	mux.HandleFunc("GET /queries/{jobId}/result", s.handler.getQueryResult)
+	mux.HandleFunc("GET /queries/{jobId}/export", s.handler.exportQueryResult)
+	mux.HandleFunc("POST /queries/{jobId}/export", s.handler.exportQueryResultToFile)
+	mux.HandleFunc("GET /history", s.handler.getQueryHistory)
 	return mux
 }
 
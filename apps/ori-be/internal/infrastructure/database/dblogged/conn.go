@@ -0,0 +1,60 @@
+package dblogged
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
+)
+
+// Conn wraps a single pooled connection with the same call logging as DB.
+type Conn struct {
+	conn *sqlx.Conn
+}
+
+func (c *Conn) QueryxContext(ctx context.Context, query string, args ...any) (rows *sqlx.Rows, err error) {
+	start := time.Now()
+	ctx = logctx.WithFields(ctx, map[string]any{
+		keyParamCount: len(args),
+		keyOperation:  operationQuery,
+	})
+	defer func() {
+		logFinish(ctx, start, err)
+	}()
+	rows, err = c.conn.QueryxContext(ctx, query, args...)
+	return rows, err
+}
+
+func (c *Conn) ExecContext(ctx context.Context, query string, args ...any) (result sql.Result, err error) {
+	start := time.Now()
+	ctx = logctx.WithFields(ctx, map[string]any{
+		keyParamCount: len(args),
+		keyOperation:  operationExec,
+	})
+	defer func() {
+		logFinish(ctx, start, err)
+	}()
+	result, err = c.conn.ExecContext(ctx, query, args...)
+	return result, err
+}
+
+func (c *Conn) PreparexContext(ctx context.Context, query string) (stmt *sqlx.Stmt, err error) {
+	start := time.Now()
+	ctx = logctx.WithField(ctx, keyOperation, operationPrepare)
+	defer func() {
+		logFinish(ctx, start, err)
+	}()
+	stmt, err = c.conn.PreparexContext(ctx, query)
+	return stmt, err
+}
+
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Conn) Raw(f func(driverConn any) error) error {
+	return c.conn.Raw(f)
+}
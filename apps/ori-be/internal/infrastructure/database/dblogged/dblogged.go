@@ -23,11 +23,12 @@ const (
 	operationExec     = "exec"
 	operationPrepare  = "prepare"
 	operationPing     = "ping"
+	operationConn     = "conn"
 	operationClose    = "close"
 )
 
 type DB struct {
-	db database.DB
+	db *sqlx.DB
 }
 
 func Open(ctx context.Context, driver, dsn string) (*DB, error) {
@@ -132,6 +133,19 @@ func (d *DB) NamedQueryContext(ctx context.Context, query string, arg any) (rows
 	return rows, err
 }
 
+func (d *DB) Conn(ctx context.Context) (conn database.Conn, err error) {
+	start := time.Now()
+	ctx = logctx.WithField(ctx, keyOperation, operationConn)
+	defer func() {
+		logFinish(ctx, start, err)
+	}()
+	raw, err := d.db.Connx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{conn: raw}, nil
+}
+
 func (d *DB) PingContext(ctx context.Context) (err error) {
 	start := time.Now()
 	ctx = logctx.WithField(ctx, keyOperation, operationPing)
@@ -2,7 +2,9 @@ package duckdb
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
+	"strings"
 
 	_ "github.com/duckdb/duckdb-go/v2"
 
@@ -40,6 +42,18 @@ func NewAdapter(params service.AdapterFactoryParams) (service.ConnectionAdapter,
 	} else {
 		dsn = ""
 	}
+	if params.Resource.ReadOnly {
+		if dsn == "" {
+			return nil, fmt.Errorf("duckdb resource '%s' cannot be read-only in memory", params.ConnectionName)
+		}
+		path, query, _ := strings.Cut(dsn, "?")
+		options, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("duckdb resource '%s' has invalid options: %w", params.ConnectionName, err)
+		}
+		options.Set("access_mode", "READ_ONLY")
+		dsn = path + "?" + options.Encode()
+	}
 
 	return &Adapter{
 		connectionName: params.ConnectionName,
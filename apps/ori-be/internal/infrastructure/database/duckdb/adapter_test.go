@@ -0,0 +1,31 @@
+package duckdb
+
+import (
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestNewAdapterAddsReadOnlyAccessMode(t *testing.T) {
+	tests := []struct {
+		database string
+		want     string
+	}{
+		{database: "/data/app.duckdb", want: "/data/app.duckdb?access_mode=READ_ONLY"},
+		{database: "/data/app.duckdb?threads=4", want: "/data/app.duckdb?access_mode=READ_ONLY&threads=4"},
+		{database: "/data/app.duckdb?access_mode=READ_WRITE", want: "/data/app.duckdb?access_mode=READ_ONLY"},
+	}
+	for _, tt := range tests {
+		adapter, err := NewAdapter(service.AdapterFactoryParams{
+			ConnectionName: "local",
+			Resource:       &model.Resource{Database: tt.database, ReadOnly: true},
+		})
+		if err != nil {
+			t.Fatalf("NewAdapter(%q): %v", tt.database, err)
+		}
+		if got := adapter.(*Adapter).dsn; got != tt.want {
+			t.Fatalf("dsn for %q = %q, want %q", tt.database, got, tt.want)
+		}
+	}
+}
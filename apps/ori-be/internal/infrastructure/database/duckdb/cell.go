@@ -0,0 +1,98 @@
+package duckdb
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	duckdbgo "github.com/duckdb/duckdb-go/v2"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+// encodeCell maps a value scanned from the duckdb driver into the typed cell encoding.
+// typeName is the type name reported by the driver, e.g. DECIMAL(18,3) or INTEGER[].
+func encodeCell(value any, typeName string) any {
+	if value == nil {
+		return nil
+	}
+	if typeName == "JSON" {
+		return querycell.JSON(value)
+	}
+
+	switch v := value.(type) {
+	case duckdbgo.Decimal:
+		return querycell.Decimal(v.String())
+	case duckdbgo.Interval:
+		return querycell.Interval(formatInterval(v))
+	case []byte:
+		if typeName == "UUID" {
+			return querycell.UUID(v)
+		}
+	case time.Time:
+		switch typeName {
+		case "DATE":
+			return querycell.Date(v)
+		case "TIME":
+			return querycell.Time(v.Format("15:04:05.999999"))
+		case "TIMETZ":
+			return querycell.Time(v.Format("15:04:05.999999-07:00"))
+		case "TIMESTAMPTZ":
+			return querycell.Timestamp(v, true)
+		default:
+			return querycell.Timestamp(v, false)
+		}
+	case []any:
+		elementType := listElementType(typeName)
+		elements := make([]any, len(v))
+		for i, element := range v {
+			elements[i] = encodeCell(element, elementType)
+		}
+		return querycell.Array(elements, strings.ToLower(elementType))
+	}
+	return querycell.Encode(value)
+}
+
+// listElementType strips the list or array suffix from a type name: INTEGER[] and INTEGER[3] yield INTEGER.
+func listElementType(typeName string) string {
+	if !strings.HasSuffix(typeName, "]") {
+		return ""
+	}
+	if i := strings.LastIndex(typeName, "["); i > 0 {
+		return typeName[:i]
+	}
+	return ""
+}
+
+// formatInterval renders an interval the way duckdb prints it, e.g. "1 year 2 months 3 days 04:05:06.5".
+func formatInterval(interval duckdbgo.Interval) string {
+	var parts []string
+	plural := func(n int64, unit string) {
+		if n == 0 {
+			return
+		}
+		if n == 1 || n == -1 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, unit))
+			return
+		}
+		parts = append(parts, fmt.Sprintf("%d %ss", n, unit))
+	}
+	plural(int64(interval.Months/12), "year")
+	plural(int64(interval.Months%12), "month")
+	plural(int64(interval.Days), "day")
+
+	if interval.Micros != 0 || len(parts) == 0 {
+		micros := interval.Micros
+		sign := ""
+		if micros < 0 {
+			sign = "-"
+			micros = -micros
+		}
+		clock := fmt.Sprintf("%s%02d:%02d:%02d", sign, micros/3600e6, micros/60e6%60, micros/1e6%60)
+		if fraction := micros % 1e6; fraction != 0 {
+			clock += strings.TrimRight(fmt.Sprintf(".%06d", fraction), "0")
+		}
+		parts = append(parts, clock)
+	}
+	return strings.Join(parts, " ")
+}
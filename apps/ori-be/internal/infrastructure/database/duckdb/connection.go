@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
 	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/dblogged"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
 
 // Connect establishes the database connection.
@@ -41,3 +43,34 @@ func (a *Adapter) Ping(ctx context.Context) error {
 	}
 	return a.db.PingContext(ctx)
 }
+
+// PinConnection checks out a dedicated connection from the pool.
+func (a *Adapter) PinConnection(ctx context.Context) (service.PinnedConnection, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	return &pinnedConnection{conn: conn}, nil
+}
+
+// SingleConnection reports that the pool holds one connection, so it cannot back a query session.
+func (a *Adapter) SingleConnection() bool {
+	return true
+}
+
+// pinnedConnection runs queries on a single connection checked out by PinConnection.
+type pinnedConnection struct {
+	conn database.Conn
+}
+
+func (p *pinnedConnection) ExecuteQuery(ctx context.Context, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	result, err := executeQuery(ctx, p.conn, query, params, options)
+	return result, databaseError(err, query, 0)
+}
+
+func (p *pinnedConnection) Close() error {
+	return p.conn.Close()
+}
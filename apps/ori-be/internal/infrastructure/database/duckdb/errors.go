@@ -0,0 +1,82 @@
+package duckdb
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	duckdbgo "github.com/duckdb/duckdb-go/v2"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// databaseError wraps a DuckDB error with the parts of its message. DuckDB points at
+// the error with a "LINE n:" excerpt and a caret, which becomes a position within
+// executed less the prefix characters the adapter put before the statement.
+func databaseError(err error, executed string, prefix int) error {
+	var duckErr *duckdbgo.Error
+	if !errors.As(err, &duckErr) {
+		return err
+	}
+	dbErr := &service.DatabaseError{Engine: "duckdb", Severity: "ERROR", Err: err}
+
+	// "Catalog Error: Table with name t does not exist!\nDid you mean "u"?\n\nLINE 1: ..."
+	lines := strings.Split(duckErr.Msg, "\n")
+	if errorType, message, ok := strings.Cut(lines[0], " Error: "); ok {
+		dbErr.Code = errorType
+		dbErr.Message = message
+	} else {
+		dbErr.Message = lines[0]
+	}
+	var details, hints []string
+	for i := 1; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.HasPrefix(line, "LINE ") && i+1 < len(lines):
+			dbErr.Position = max(errorPosition(executed, line, lines[i+1])-prefix, 0)
+			i = len(lines)
+		case strings.HasPrefix(line, "Did you mean") || strings.HasPrefix(line, "Candidate bindings"):
+			hints = append(hints, line)
+		case line != "":
+			details = append(details, line)
+		}
+	}
+	dbErr.Detail = strings.Join(details, "\n")
+	dbErr.Hint = strings.Join(hints, "\n")
+
+	if _, target, ok := strings.Cut(dbErr.Message, " constraint failed: "); ok {
+		// NOT NULL constraint failed: t.n
+		if table, column, ok := strings.Cut(target, "."); ok {
+			dbErr.Table, dbErr.ColumnName = table, column
+		}
+	} else if _, rest, ok := strings.Cut(dbErr.Message, " constraint failed on table "); ok {
+		// CHECK constraint failed on table t with expression CHECK((id > 0))
+		dbErr.Table, _, _ = strings.Cut(rest, " ")
+	}
+	return dbErr
+}
+
+// errorPosition turns an excerpt such as "LINE 2:   2 frm t" and the caret line below
+// it into a 1-based character position within query. Excerpts DuckDB shortened with
+// "..." cannot be mapped and give 0.
+func errorPosition(query, excerpt, caret string) int {
+	label, text, ok := strings.Cut(excerpt, ": ")
+	if !ok || strings.HasPrefix(text, "...") {
+		return 0
+	}
+	line, err := strconv.Atoi(strings.TrimPrefix(label, "LINE "))
+	column := strings.IndexByte(caret, '^') - len(label) - len(": ")
+	if err != nil || column < 0 {
+		return 0
+	}
+	lines := strings.Split(query, "\n")
+	if line < 1 || line > len(lines) {
+		return 0
+	}
+	position := 0
+	for _, previous := range lines[:line-1] {
+		position += utf8.RuneCountInString(previous) + 1
+	}
+	return position + column + 1
+}
@@ -0,0 +1,168 @@
+package duckdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// ExplainQuery runs DuckDB's JSON EXPLAIN and normalizes the plan. With Analyze the
+// statement runs inside a transaction that is rolled back, so writes are not kept.
+func (a *Adapter) ExplainQuery(ctx context.Context, query string, params any, options *service.QueryExplainOptions) (*service.QueryPlan, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+	args, _ := params.([]any)
+
+	if !options.Analyze {
+		output, err := explain(ctx, a.db, "EXPLAIN (FORMAT JSON) ", query, args)
+		if err != nil {
+			return nil, err
+		}
+		return parsePlan(output)
+	}
+
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if _, err := conn.ExecContext(ctx, "BEGIN TRANSACTION"); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+	}()
+	output, err := explain(ctx, conn, "EXPLAIN (ANALYZE, FORMAT JSON) ", query, args)
+	if err != nil {
+		return nil, err
+	}
+	return parseAnalyzedPlan(output)
+}
+
+// explain returns the JSON document of an EXPLAIN statement, which DuckDB reports
+// as a single (explain_key, explain_value) row.
+func explain(ctx context.Context, db database.Querier, prefix, query string, args []any) ([]byte, error) {
+	rows, err := db.QueryxContext(ctx, prefix+query, args...)
+	if err != nil {
+		return nil, databaseError(fmt.Errorf("explain failed: %w", err), prefix+query, len(prefix))
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var key, value string
+	if rows.Next() {
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan plan: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return []byte(value), nil
+}
+
+// planOperator is a node of either the physical or the analyzed plan JSON.
+type planOperator struct {
+	Name         string         `json:"name"`
+	OperatorName string         `json:"operator_name"`
+	Cardinality  *float64       `json:"operator_cardinality"`
+	Timing       *float64       `json:"operator_timing"`
+	ExtraInfo    map[string]any `json:"extra_info"`
+	Children     []planOperator `json:"children"`
+}
+
+// analyzedPlan is the query-level profile returned by EXPLAIN (ANALYZE, FORMAT JSON).
+type analyzedPlan struct {
+	Latency  *float64       `json:"latency"`
+	Children []planOperator `json:"children"`
+}
+
+// parsePlan normalizes the output of EXPLAIN (FORMAT JSON).
+func parsePlan(output []byte) (*service.QueryPlan, error) {
+	var operators []planOperator
+	if err := json.Unmarshal(output, &operators); err != nil {
+		return nil, fmt.Errorf("failed to decode plan: %w", err)
+	}
+	plan := &service.QueryPlan{}
+	for _, operator := range operators {
+		plan.Nodes = append(plan.Nodes, planNode(operator, false))
+	}
+	return plan, nil
+}
+
+// parseAnalyzedPlan normalizes the output of EXPLAIN (ANALYZE, FORMAT JSON),
+// skipping the EXPLAIN_ANALYZE operator DuckDB wraps around the plan.
+func parseAnalyzedPlan(output []byte) (*service.QueryPlan, error) {
+	var profile analyzedPlan
+	if err := json.Unmarshal(output, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode plan: %w", err)
+	}
+	plan := &service.QueryPlan{Analyzed: true}
+	if profile.Latency != nil {
+		latencyMs := *profile.Latency * 1000
+		plan.ExecutionTimeMs = &latencyMs
+	}
+	operators := profile.Children
+	if len(operators) == 1 && strings.TrimSpace(operators[0].OperatorName) == "EXPLAIN_ANALYZE" {
+		operators = operators[0].Children
+	}
+	for _, operator := range operators {
+		plan.Nodes = append(plan.Nodes, planNode(operator, true))
+	}
+	return plan, nil
+}
+
+// planDetailKeys are the extra_info entries summarized in Detail, in display order.
+var planDetailKeys = []string{"Join Type", "Conditions", "Filters", "Groups", "Order By"}
+
+func planNode(operator planOperator, analyzed bool) *service.QueryPlanNode {
+	name := operator.Name
+	if analyzed {
+		name = operator.OperatorName
+	}
+	node := &service.QueryPlanNode{NodeType: strings.TrimSpace(name)}
+	if table, ok := operator.ExtraInfo["Table"].(string); ok {
+		node.Relation = table
+	}
+	if estimate, ok := operator.ExtraInfo["Estimated Cardinality"].(string); ok {
+		if rows, err := strconv.ParseFloat(estimate, 64); err == nil {
+			node.EstimatedRows = &rows
+		}
+	}
+	if analyzed {
+		node.ActualRows = operator.Cardinality
+		if operator.Timing != nil {
+			timingMs := *operator.Timing * 1000
+			node.ActualTimeMs = &timingMs
+		}
+	}
+
+	var details []string
+	for _, key := range planDetailKeys {
+		switch value := operator.ExtraInfo[key].(type) {
+		case string:
+			details = append(details, key+": "+value)
+		case []any:
+			parts := make([]string, 0, len(value))
+			for _, part := range value {
+				parts = append(parts, fmt.Sprint(part))
+			}
+			details = append(details, key+": "+strings.Join(parts, ", "))
+		}
+	}
+	node.Detail = strings.Join(details, "; ")
+
+	for _, child := range operator.Children {
+		node.Children = append(node.Children, planNode(child, analyzed))
+	}
+	return node
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
 
 func (a *Adapter) GetScopes(ctx context.Context) ([]model.Scope, error) {
@@ -191,6 +194,32 @@ func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation st
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating duckdb columns: %w", err)
 	}
+
+	// The catalog reports a generated column's expression as its default; only the
+	// table's CREATE statement tells the two apart. DuckDB generated columns are virtual.
+	var definition sql.NullString
+	err = a.db.GetContext(ctx, &definition, `
+		SELECT sql FROM duckdb_tables()
+		WHERE database_name = ? AND schema_name = ? AND table_name = ?
+	`, databaseName, schemaName, relation)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to fetch duckdb table definition: %w", err)
+	}
+	generated := sqlutil.GeneratedColumns(definition.String, sqlutil.DialectDuckDB)
+	for i := range columns {
+		if column, ok := generated[columns[i].Name]; ok {
+			kind := "VIRTUAL"
+			columns[i].Generated = &kind
+			columns[i].GenerationExpression = &column.Expression
+			columns[i].DefaultValue = nil
+		}
+	}
+
+	types, err := a.getUserTypes(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	linkUserTypes(columns, types)
 	return columns, nil
 }
 
@@ -404,6 +433,334 @@ func (a *Adapter) GetTriggers(context.Context, model.Scope, string) ([]model.Tri
 	return []model.Trigger{}, nil
 }
 
+// GetSequences returns the sequences of a schema. DuckDB does not tie a sequence to a
+// column, so the first column whose default draws from it is reported as its owner.
+func (a *Adapter) GetSequences(ctx context.Context, scope model.Scope) ([]model.Sequence, error) {
+	databaseName, schemaName, err := relationScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.db.QueryxContext(ctx, `
+		WITH owners AS (
+			SELECT column_default, min(table_name) AS table_name, arg_min(column_name, table_name) AS column_name
+			FROM duckdb_columns()
+			WHERE database_name = ? AND schema_name = ? AND column_default LIKE 'nextval(%'
+			GROUP BY column_default
+		)
+		SELECT
+			s.sequence_name,
+			s.start_value,
+			s.increment_by,
+			s.min_value,
+			s.max_value,
+			s.cycle,
+			s.last_value,
+			COALESCE(o.table_name, '') AS owned_by_table,
+			COALESCE(o.column_name, '') AS owned_by_column
+		FROM duckdb_sequences() s
+		LEFT JOIN owners o ON o.column_default IN (
+			'nextval(''' || s.sequence_name || ''')',
+			'nextval(''' || s.schema_name || '.' || s.sequence_name || ''')'
+		)
+		WHERE s.database_name = ? AND s.schema_name = ? AND NOT s.temporary
+		ORDER BY s.sequence_name
+	`, databaseName, schemaName, databaseName, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch duckdb sequences: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var sequences []model.Sequence
+	for rows.Next() {
+		seq := model.Sequence{DataType: "BIGINT"}
+		var lastValue sql.NullInt64
+		if err := rows.Scan(
+			&seq.Name,
+			&seq.Start,
+			&seq.Increment,
+			&seq.MinValue,
+			&seq.MaxValue,
+			&seq.Cycle,
+			&lastValue,
+			&seq.OwnedByTable,
+			&seq.OwnedByColumn,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan duckdb sequence: %w", err)
+		}
+		if lastValue.Valid {
+			seq.CurrentValue = &lastValue.Int64
+		}
+		sequences = append(sequences, seq)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duckdb sequences: %w", err)
+	}
+	return sequences, nil
+}
+
+// GetRoutines returns the macros of a schema, which are DuckDB's user-defined functions.
+func (a *Adapter) GetRoutines(ctx context.Context, scope model.Scope) ([]model.Routine, error) {
+	databaseName, schemaName, err := relationScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.db.QueryxContext(ctx, `
+		SELECT
+			function_name,
+			function_type,
+			array_to_string(parameters, ', ') AS arguments,
+			COALESCE(macro_definition, '') AS definition
+		FROM duckdb_functions()
+		WHERE database_name = ? AND schema_name = ? AND NOT internal
+			AND function_type IN ('macro', 'table_macro')
+		ORDER BY function_name
+	`, databaseName, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch duckdb macros: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var routines []model.Routine
+	for rows.Next() {
+		var name, functionType, arguments, body string
+		if err := rows.Scan(&name, &functionType, &arguments, &body); err != nil {
+			return nil, fmt.Errorf("failed to scan duckdb macro: %w", err)
+		}
+		returnType, as := "", "AS "
+		if functionType == "table_macro" {
+			returnType, as = "TABLE", "AS TABLE "
+		}
+		routines = append(routines, model.Routine{
+			Name:       name,
+			Kind:       "function",
+			Signature:  name + "(" + arguments + ")",
+			Arguments:  arguments,
+			ReturnType: returnType,
+			Language:   "sql",
+			Definition: fmt.Sprintf("CREATE MACRO %s.%s(%s) %s%s", schemaName, name, arguments, as, body),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duckdb macros: %w", err)
+	}
+	return routines, nil
+}
+
+// GetTypes returns the user-defined types of a schema. DuckDB keeps no domains, so
+// type aliases are reported as domains over their base type.
+func (a *Adapter) GetTypes(ctx context.Context, scope model.Scope) ([]model.UserType, error) {
+	databaseName, schemaName, err := relationScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	types, err := a.getUserTypes(ctx, databaseName)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]model.UserType, 0, len(types))
+	for _, typ := range types {
+		if typ.schema == schemaName {
+			result = append(result, typ.UserType)
+		}
+	}
+	return result, nil
+}
+
+// GetExtensions returns the installed and loaded extensions. They belong to the
+// process rather than a schema, so only the current default schema lists them.
+func (a *Adapter) GetExtensions(ctx context.Context, scope model.Scope) ([]model.Extension, error) {
+	databaseName, schemaName, err := relationScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := a.db.QueryxContext(ctx, `
+		SELECT
+			extension_name,
+			COALESCE(extension_version, '') AS extension_version,
+			COALESCE(description, '') AS description,
+			loaded
+		FROM duckdb_extensions()
+		WHERE (installed OR loaded)
+			AND current_catalog() = ? AND current_schema() = ?
+		ORDER BY extension_name
+	`, databaseName, schemaName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch duckdb extensions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var extensions []model.Extension
+	for rows.Next() {
+		var ext model.Extension
+		var loaded bool
+		if err := rows.Scan(&ext.Name, &ext.Version, &ext.Description, &loaded); err != nil {
+			return nil, fmt.Errorf("failed to scan duckdb extension: %w", err)
+		}
+		ext.Loaded = &loaded
+		extensions = append(extensions, ext)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duckdb extensions: %w", err)
+	}
+	return extensions, nil
+}
+
+// GetRolePrivileges is unsupported; DuckDB has no roles.
+func (a *Adapter) GetRolePrivileges(context.Context, string, string) (*model.RolePrivileges, error) {
+	return nil, fmt.Errorf("%w: duckdb has no roles", service.ErrPrivilegesUnsupported)
+}
+
+// userType is a user-defined type along with its schema and the type name DuckDB
+// resolves it to, which is all a column of the type reports.
+type userType struct {
+	model.UserType
+	schema   string
+	resolved string
+}
+
+func (a *Adapter) getUserTypes(ctx context.Context, databaseName string) ([]userType, error) {
+	rows, err := a.db.QueryxContext(ctx, `
+		SELECT
+			schema_name,
+			type_name,
+			logical_type,
+			COALESCE(CAST(to_json(labels) AS VARCHAR), '[]') AS labels_json
+		FROM duckdb_types()
+		WHERE database_name = ? AND NOT internal
+		ORDER BY schema_name, type_name
+	`, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch duckdb types: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var types []userType
+	for rows.Next() {
+		var typ userType
+		var logicalType, labelsJSON string
+		if err := rows.Scan(&typ.schema, &typ.Name, &logicalType, &labelsJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan duckdb type: %w", err)
+		}
+		labels, err := decodeStringArray(labelsJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode labels of %s: %w", typ.Name, err)
+		}
+		typ.Labels = labels
+		switch logicalType {
+		case "ENUM":
+			typ.Kind = "enum"
+		case "STRUCT":
+			typ.Kind = "composite"
+		default:
+			typ.Kind = "domain"
+		}
+		types = append(types, typ)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating duckdb types: %w", err)
+	}
+	_ = rows.Close()
+
+	for i := range types {
+		name := strings.Join([]string{
+			quoteIdentifier(databaseName),
+			quoteIdentifier(types[i].schema),
+			quoteIdentifier(types[i].Name),
+		}, ".")
+		if err := a.db.GetContext(ctx, &types[i].resolved, "SELECT typeof(CAST(NULL AS "+name+"))"); err != nil {
+			return nil, fmt.Errorf("failed to resolve duckdb type %s: %w", types[i].Name, err)
+		}
+		switch types[i].Kind {
+		case "composite":
+			types[i].Fields = structFields(types[i].resolved)
+		case "domain":
+			types[i].BaseType = types[i].resolved
+		}
+	}
+	return types, nil
+}
+
+// linkUserTypes points columns at the enum or struct type they were declared with.
+// The catalog only keeps the resolved type, so a column links when exactly one
+// user type resolves to it; aliases of built-in types are left alone.
+func linkUserTypes(columns []model.Column, types []userType) {
+	byResolved := make(map[string]*userType, len(types))
+	ambiguous := make(map[string]bool)
+	for i := range types {
+		if types[i].Kind == "domain" {
+			continue
+		}
+		if _, ok := byResolved[types[i].resolved]; ok {
+			ambiguous[types[i].resolved] = true
+		}
+		byResolved[types[i].resolved] = &types[i]
+	}
+	for i := range columns {
+		resolved := columns[i].DataType
+		for strings.HasSuffix(resolved, "[]") {
+			resolved = strings.TrimSuffix(resolved, "[]")
+		}
+		typ, ok := byResolved[resolved]
+		if !ok || ambiguous[resolved] {
+			continue
+		}
+		columns[i].TypeSchema = &typ.schema
+		columns[i].TypeName = &typ.Name
+	}
+}
+
+// structFields reads the fields of a resolved struct type such as "STRUCT(x INTEGER, y INTEGER)".
+func structFields(resolved string) []model.TypeField {
+	tokens := sqlutil.Tokenize(resolved, sqlutil.DialectDuckDB)
+	i := 0
+	for i < len(tokens) && tokens[i].Depth == 0 {
+		i++
+	}
+
+	var fields []model.TypeField
+	for i < len(tokens) && tokens[i].Depth > 0 {
+		end := i
+		for end < len(tokens) && tokens[end].Depth > 0 && !(tokens[end].Depth == 1 && tokens[end].Text == ",") {
+			end++
+		}
+		if field, ok := structField(resolved, tokens[i:end]); ok {
+			fields = append(fields, field)
+		}
+		i = end + 1
+	}
+	return fields
+}
+
+func structField(source string, tokens []sqlutil.Token) (model.TypeField, bool) {
+	if len(tokens) < 2 {
+		return model.TypeField{}, false
+	}
+	name := tokens[0].Text
+	if tokens[0].Kind == sqlutil.TokenQuotedIdentifier {
+		name = tokens[0].Value
+	}
+	last := tokens[len(tokens)-1]
+	return model.TypeField{
+		Name:     name,
+		DataType: source[tokens[1].Offset : last.Offset+len(last.Text)],
+	}, true
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
 func relationScope(scope model.Scope) (string, string, error) {
 	if scope == nil {
 		return "", "", fmt.Errorf("scope is nil")
@@ -0,0 +1,49 @@
+package duckdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+)
+
+func TestStructFields(t *testing.T) {
+	got := structFields(`STRUCT(x INTEGER, "the label" VARCHAR, dims STRUCT(w DOUBLE, h DOUBLE), tags VARCHAR[])`)
+	want := []model.TypeField{
+		{Name: "x", DataType: "INTEGER"},
+		{Name: "the label", DataType: "VARCHAR"},
+		{Name: "dims", DataType: "STRUCT(w DOUBLE, h DOUBLE)"},
+		{Name: "tags", DataType: "VARCHAR[]"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("structFields() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLinkUserTypesSkipsAliasesAndAmbiguousTypes(t *testing.T) {
+	enum := "ENUM('sad', 'ok', 'happy')"
+	types := []userType{
+		{UserType: model.UserType{Name: "mood", Kind: "enum"}, schema: "main", resolved: enum},
+		{UserType: model.UserType{Name: "cents", Kind: "domain"}, schema: "main", resolved: "BIGINT"},
+		{UserType: model.UserType{Name: "point", Kind: "composite"}, schema: "geo", resolved: "STRUCT(x INTEGER)"},
+		{UserType: model.UserType{Name: "coord", Kind: "composite"}, schema: "geo", resolved: "STRUCT(x INTEGER)"},
+	}
+	columns := []model.Column{
+		{Name: "status", DataType: enum},
+		{Name: "history", DataType: enum + "[]"},
+		{Name: "amount", DataType: "BIGINT"},
+		{Name: "position", DataType: "STRUCT(x INTEGER)"},
+	}
+	linkUserTypes(columns, types)
+
+	for _, column := range columns[:2] {
+		if column.TypeName == nil || *column.TypeName != "mood" || *column.TypeSchema != "main" {
+			t.Fatalf("expected %s to link to main.mood, got %v", column.Name, column.TypeName)
+		}
+	}
+	for _, column := range columns[2:] {
+		if column.TypeName != nil {
+			t.Fatalf("expected %s to stay unlinked, got %s", column.Name, *column.TypeName)
+		}
+	}
+}
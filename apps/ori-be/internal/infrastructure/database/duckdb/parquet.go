@@ -0,0 +1,319 @@
+package duckdb
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+const (
+	// parquetChunkRows is how many rows are read at a time while staging.
+	parquetChunkRows = 1024
+	// maxDecimalWidth is the widest DECIMAL DuckDB and Parquet hold.
+	maxDecimalWidth = 38
+)
+
+var (
+	decimalTypePattern = regexp.MustCompile(`^(?:DECIMAL|NUMERIC)\((\d+),\s*(\d+)\)$`)
+	decimalTextPattern = regexp.MustCompile(`^[+-]?(\d*)(?:\.(\d*))?$`)
+)
+
+// ParquetExporter converts stored results to Parquet with an in-memory DuckDB
+// database. Cells are staged as JSON Lines of text, so no value passes through
+// JSON number parsing, and cast to types derived from the result's columns.
+// A column whose values do not all fit its type is written as VARCHAR.
+type ParquetExporter struct{}
+
+var _ service.ResultExporter = ParquetExporter{}
+
+func (ParquetExporter) Export(ctx context.Context, result *service.QueryResult, _ *service.ExportOptions, w io.Writer) error {
+	dir, err := os.MkdirTemp("", "ori-parquet-*")
+	if err != nil {
+		return fmt.Errorf("failed to create parquet staging directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	columns := parquetColumns(result)
+	staged := filepath.Join(dir, "rows.jsonl")
+	if err := stageRows(ctx, result, columns, staged); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		return fmt.Errorf("failed to open duckdb: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE staged AS "+stagedSelect(columns, staged, result.RowCount)); err != nil {
+		return fmt.Errorf("failed to stage parquet rows: %w", err)
+	}
+	if err := checkCasts(ctx, db, columns); err != nil {
+		return err
+	}
+
+	target := filepath.Join(dir, "result.parquet")
+	copyQuery := fmt.Sprintf("COPY (SELECT %s FROM staged) TO %s (FORMAT PARQUET)", selectList(columns), stringutil.QuoteLiteral(target))
+	if _, err := db.ExecContext(ctx, copyQuery); err != nil {
+		return fmt.Errorf("failed to write parquet: %w", err)
+	}
+
+	file, err := os.Open(target)
+	if err != nil {
+		return fmt.Errorf("failed to open parquet output: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// parquetColumn is an output column and the DuckDB type its staged text is cast to.
+type parquetColumn struct {
+	name     string
+	staged   string
+	duckType string
+
+	// Decimal columns track the digits of their values, since NUMERIC without a
+	// declared precision and scale has no fixed Parquet type.
+	decimal                      bool
+	declaredWidth, declaredScale int
+	intDigits, scale             int
+}
+
+func parquetColumns(result *service.QueryResult) []*parquetColumn {
+	columns := make([]*parquetColumn, len(result.Columns))
+	seen := make(map[string]int, len(result.Columns))
+	for i, column := range result.Columns {
+		name := column.Name
+		seen[name]++
+		if count := seen[name]; count > 1 {
+			name += "_" + strconv.Itoa(count)
+		}
+		columns[i] = &parquetColumn{name: name, staged: "c" + strconv.Itoa(i)}
+		columns[i].setType(column.Type)
+	}
+	return columns
+}
+
+// setType maps a result column type, as reported by any adapter, to a DuckDB type.
+// Plain INTEGER becomes BIGINT because SQLite integers are 64-bit.
+func (c *parquetColumn) setType(columnType string) {
+	name := strings.ToUpper(strings.TrimSpace(columnType))
+	if match := decimalTypePattern.FindStringSubmatch(name); match != nil {
+		c.decimal = true
+		c.declaredWidth, _ = strconv.Atoi(match[1])
+		c.declaredScale, _ = strconv.Atoi(match[2])
+		return
+	}
+	switch name {
+	case "NUMERIC", "DECIMAL":
+		c.decimal = true
+	case "BOOL", "BOOLEAN":
+		c.duckType = "BOOLEAN"
+	case "INT2", "SMALLINT":
+		c.duckType = "SMALLINT"
+	case "INT4":
+		c.duckType = "INTEGER"
+	case "TINYINT", "MEDIUMINT", "INT", "INTEGER", "INT8", "BIGINT":
+		c.duckType = "BIGINT"
+	case "HUGEINT", "UTINYINT", "USMALLINT", "UINTEGER", "UBIGINT", "UHUGEINT":
+		c.duckType = name
+	case "FLOAT4":
+		c.duckType = "FLOAT"
+	case "FLOAT", "FLOAT8", "REAL", "DOUBLE", "DOUBLE PRECISION":
+		c.duckType = "DOUBLE"
+	case "BYTEA", "BLOB":
+		c.duckType = "BLOB"
+	case "DATE":
+		c.duckType = "DATE"
+	case "TIMESTAMP", "DATETIME":
+		c.duckType = "TIMESTAMP"
+	case "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE":
+		c.duckType = "TIMESTAMPTZ"
+	case "TIME":
+		c.duckType = "TIME"
+	case "UUID":
+		c.duckType = "UUID"
+	case "JSON", "JSONB":
+		c.duckType = "JSON"
+	default:
+		c.duckType = "VARCHAR"
+	}
+}
+
+// observe checks a cell against the column type, falling back to VARCHAR on a mismatch.
+func (c *parquetColumn) observe(text string, tag querycell.Tag) {
+	switch {
+	case c.duckType == "VARCHAR":
+	case c.duckType == "BLOB" && tag != querycell.TagBytes:
+		c.duckType = "VARCHAR"
+	case c.decimal:
+		match := decimalTextPattern.FindStringSubmatch(text)
+		if match == nil || match[1]+match[2] == "" {
+			c.decimal = false
+			c.duckType = "VARCHAR"
+			return
+		}
+		c.intDigits = max(c.intDigits, len(strings.TrimLeft(match[1], "0")))
+		c.scale = max(c.scale, len(match[2]))
+	}
+}
+
+// resolveDecimal picks the declared precision and scale when every value fits
+// them, and otherwise the smallest scale that holds every value.
+func (c *parquetColumn) resolveDecimal() {
+	if !c.decimal {
+		return
+	}
+	c.decimal = false
+	switch {
+	case c.declaredWidth > 0 && c.declaredWidth <= maxDecimalWidth && c.scale <= c.declaredScale && c.intDigits <= c.declaredWidth-c.declaredScale:
+		c.duckType = fmt.Sprintf("DECIMAL(%d,%d)", c.declaredWidth, c.declaredScale)
+	case c.intDigits+c.scale <= maxDecimalWidth:
+		c.duckType = fmt.Sprintf("DECIMAL(%d,%d)", maxDecimalWidth, c.scale)
+	default:
+		c.duckType = "VARCHAR"
+	}
+}
+
+// stageRows writes each row as a JSON object of cell texts keyed by staged column name.
+func stageRows(ctx context.Context, result *service.QueryResult, columns []*parquetColumn, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create parquet staging file: %w", err)
+	}
+	buffered := bufio.NewWriter(file)
+	err = func() error {
+		for start := 0; start < result.RowCount; start += parquetChunkRows {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			rows, err := result.ReadRows(start, min(start+parquetChunkRows, result.RowCount))
+			if err != nil {
+				return fmt.Errorf("%w: %w", service.ErrResultUnavailable, err)
+			}
+			for _, row := range rows {
+				if err := stageRow(buffered, columns, row); err != nil {
+					return err
+				}
+			}
+		}
+		return buffered.Flush()
+	}()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	for _, column := range columns {
+		column.resolveDecimal()
+	}
+	return file.Close()
+}
+
+func stageRow(w *bufio.Writer, columns []*parquetColumn, row []any) error {
+	_ = w.WriteByte('{')
+	for i, cell := range row {
+		if i > 0 {
+			_ = w.WriteByte(',')
+		}
+		_, _ = w.WriteString(`"` + columns[i].staged + `":`)
+		text, tag, ok := service.ExportCell(cell)
+		if !ok {
+			_, _ = w.WriteString("null")
+			continue
+		}
+		columns[i].observe(text, tag)
+		value, err := json.Marshal(text)
+		if err != nil {
+			return fmt.Errorf("failed to encode cell: %w", err)
+		}
+		_, _ = w.Write(value)
+	}
+	_, err := w.WriteString("}\n")
+	return err
+}
+
+// stagedSelect reads the staged cells as text. read_json cannot read an empty
+// file, so a result without rows selects typed NULLs instead.
+func stagedSelect(columns []*parquetColumn, path string, rowCount int) string {
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		if rowCount == 0 {
+			fields[i] = "NULL::VARCHAR AS " + column.staged
+		} else {
+			fields[i] = column.staged + ": 'VARCHAR'"
+		}
+	}
+	if rowCount == 0 {
+		return "SELECT " + strings.Join(fields, ", ") + " LIMIT 0"
+	}
+	return fmt.Sprintf("SELECT * FROM read_json(%s, format = 'newline_delimited', columns = {%s})",
+		stringutil.QuoteLiteral(path), strings.Join(fields, ", "))
+}
+
+// checkCasts falls back to VARCHAR for columns holding text their type cannot parse,
+// such as strings in a SQLite INTEGER column.
+func checkCasts(ctx context.Context, db *sql.DB, columns []*parquetColumn) error {
+	var checked []*parquetColumn
+	var counts []string
+	for _, column := range columns {
+		if column.duckType == "VARCHAR" || column.duckType == "BLOB" {
+			continue
+		}
+		checked = append(checked, column)
+		counts = append(counts, fmt.Sprintf("count(*) FILTER (WHERE %s IS NOT NULL AND TRY_CAST(%s AS %s) IS NULL)",
+			column.staged, column.staged, column.duckType))
+	}
+	if len(checked) == 0 {
+		return nil
+	}
+
+	failures := make([]int64, len(checked))
+	targets := make([]any, len(checked))
+	for i := range failures {
+		targets[i] = &failures[i]
+	}
+	if err := db.QueryRowContext(ctx, "SELECT "+strings.Join(counts, ", ")+" FROM staged").Scan(targets...); err != nil {
+		return fmt.Errorf("failed to check parquet column types: %w", err)
+	}
+	for i, column := range checked {
+		if failures[i] > 0 {
+			column.duckType = "VARCHAR"
+		}
+	}
+	return nil
+}
+
+func selectList(columns []*parquetColumn) string {
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		alias := `"` + stringutil.EscapeIdentifier(column.name) + `"`
+		switch column.duckType {
+		case "VARCHAR":
+			fields[i] = column.staged + " AS " + alias
+		case "BLOB":
+			fields[i] = "from_base64(" + column.staged + ") AS " + alias
+		default:
+			fields[i] = fmt.Sprintf("CAST(%s AS %s) AS %s", column.staged, column.duckType, alias)
+		}
+	}
+	return strings.Join(fields, ", ")
+}
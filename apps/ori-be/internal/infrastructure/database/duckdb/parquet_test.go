@@ -0,0 +1,72 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestParquetExporterKeepsColumnTypes(t *testing.T) {
+	result := &service.QueryResult{
+		Columns: []service.QueryColumn{
+			{Name: "amount", Type: "NUMERIC"},
+			{Name: "price", Type: "DECIMAL(10,2)"},
+			{Name: "payload", Type: "BYTEA"},
+			{Name: "id", Type: "INTEGER"},
+		},
+		Rows: [][]any{
+			{querycell.Decimal("12345678901234567890.123456789"), querycell.Decimal("9.50"), querycell.Bytes([]byte{0, 1, 2, 255}), int64(1)},
+			{querycell.Decimal("-0.5"), nil, nil, "not a number"},
+		},
+		RowCount: 2,
+	}
+
+	var out bytes.Buffer
+	if err := (ParquetExporter{}).Export(context.Background(), result, &service.ExportOptions{Format: service.ExportParquet}, &out); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "result.parquet")
+	if err := os.WriteFile(path, out.Bytes(), 0o600); err != nil {
+		t.Fatalf("write parquet: %v", err)
+	}
+
+	db, err := sql.Open("duckdb", "")
+	if err != nil {
+		t.Fatalf("open duckdb: %v", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	var types [4]string
+	err = db.QueryRow(`SELECT typeof(amount), typeof(price), typeof(payload), typeof(id) FROM read_parquet(`+
+		stringutil.QuoteLiteral(path)+`) LIMIT 1`).Scan(&types[0], &types[1], &types[2], &types[3])
+	if err != nil {
+		t.Fatalf("read parquet types: %v", err)
+	}
+	if want := [4]string{"DECIMAL(38,9)", "DECIMAL(10,2)", "BLOB", "VARCHAR"}; types != want {
+		t.Fatalf("column types = %v, want %v", types, want)
+	}
+
+	var (
+		amount  string
+		price   sql.NullString
+		payload []byte
+		id      string
+	)
+	err = db.QueryRow(`SELECT amount::VARCHAR, price::VARCHAR, payload, id FROM read_parquet(`+
+		stringutil.QuoteLiteral(path)+`) ORDER BY amount DESC LIMIT 1`).Scan(&amount, &price, &payload, &id)
+	if err != nil {
+		t.Fatalf("read parquet row: %v", err)
+	}
+	if amount != "12345678901234567890.123456789" || price.String != "9.50" || !bytes.Equal(payload, []byte{0, 1, 2, 255}) || id != "1" {
+		t.Fatalf("row = %q, %q, %v, %q", amount, price.String, payload, id)
+	}
+}
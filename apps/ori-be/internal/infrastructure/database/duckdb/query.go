@@ -5,7 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
-	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
 	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
 	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 	"github.com/jmoiron/sqlx"
@@ -16,12 +16,19 @@ func (a *Adapter) ExecuteQuery(ctx context.Context, query string, params any, op
 	if a.db == nil {
 		return nil, fmt.Errorf("database not connected")
 	}
+	result, err := executeQuery(ctx, a.db, query, params, options)
+	return result, databaseError(err, query, 0)
+}
 
+// executeQuery reads results eagerly even when options ask for a lazy cursor: the
+// resource has a single connection, and an open cursor would block every other
+// query and introspection until it drained. Large results spill to disk instead.
+func executeQuery(ctx context.Context, db database.Querier, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
 	var stmt *sqlx.Stmt
 	var err error
 
 	if params != nil {
-		stmt, err = a.db.PreparexContext(ctx, query)
+		stmt, err = db.PreparexContext(ctx, query)
 		if err != nil {
 			return nil, fmt.Errorf("failed to prepare query: %w", err)
 		}
@@ -30,20 +37,20 @@ func (a *Adapter) ExecuteQuery(ctx context.Context, query string, params any, op
 		}()
 	}
 
-	if sqlutil.IsRowReturningQuery(query) {
-		return a.executeSelect(ctx, stmt, query, params, options)
+	if sqlutil.IsRowReturningQuery(query, sqlutil.DialectDuckDB) {
+		return executeSelect(ctx, db, stmt, query, params, options)
 	}
-	return a.executeStatement(ctx, stmt, query, params)
+	return executeStatement(ctx, db, stmt, query, params)
 }
 
-func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+func executeSelect(ctx context.Context, db database.Querier, stmt *sqlx.Stmt, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
 	var rows *sqlx.Rows
 	var err error
 
 	if stmt != nil {
 		rows, err = queryWithParams(ctx, stmt, params)
 	} else {
-		rows, err = a.db.QueryxContext(ctx, query)
+		rows, err = db.QueryxContext(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
@@ -52,37 +59,25 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 		_ = rows.Close()
 	}()
 
-	columns, err := rows.Columns()
+	queryColumns, err := resultColumns(rows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %w", err)
+		return nil, err
 	}
 
-	columnTypes, err := rows.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get column types: %w", err)
-	}
-
-	queryColumns := make([]service.QueryColumn, len(columns))
-	for i, name := range columns {
-		colType := "unknown"
-		if i < len(columnTypes) {
-			dbType := columnTypes[i].DatabaseTypeName()
-			if dbType != "" {
-				colType = dbType
-			}
-		}
-		queryColumns[i] = service.QueryColumn{Name: name, Type: colType}
-	}
-
-	rowData := make([]any, len(columns))
-	rowPtrs := make([]any, len(columns))
+	rowData := make([]any, len(queryColumns))
+	rowPtrs := make([]any, len(queryColumns))
 	for i := range rowData {
 		rowPtrs[i] = &rowData[i]
 	}
 
-	var allRows [][]any
-	rowCount := 0
+	collector := service.NewRowCollector(options)
+	defer collector.Discard()
+	collector.SetColumns(queryColumns)
 	truncated := false
+	// A data-modifying statement with RETURNING affected exactly the rows it returned.
+	kind := sqlutil.Classify(query, sqlutil.DialectDuckDB).Kind
+	modifies := sqlutil.CommandTag(kind, 0) != ""
+	skipped := 0
 
 	for rows.Next() {
 		if err := rows.Scan(rowPtrs...); err != nil {
@@ -91,15 +86,21 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 
 		rowCopy := make([]any, len(rowData))
 		for i, value := range rowData {
-			rowCopy[i] = querycell.Stringify(value)
+			rowCopy[i] = encodeCell(value, queryColumns[i].Type)
 		}
 
-		allRows = append(allRows, rowCopy)
-		rowCount++
+		if err := collector.Append(rowCopy); err != nil {
+			return nil, err
+		}
 
-		if options.MaxRows > 0 && rowCount >= options.MaxRows {
-			if rows.Next() {
+		if collector.Full() {
+			// A RETURNING statement counts every row it returned as affected.
+			for rows.Next() {
 				truncated = true
+				skipped++
+				if !modifies {
+					break
+				}
 			}
 			break
 		}
@@ -109,23 +110,56 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return &service.QueryResult{
+	result := &service.QueryResult{
 		Status:    service.JobStatusSuccess,
 		Columns:   queryColumns,
-		Rows:      allRows,
-		RowCount:  rowCount,
 		Truncated: truncated,
-	}, nil
+	}
+	if err := collector.Finish(result); err != nil {
+		return nil, err
+	}
+	if modifies {
+		affected := int64(result.RowCount + skipped)
+		result.RowsAffected = &affected
+		result.CommandTag = sqlutil.CommandTag(kind, affected)
+	}
+	return result, nil
+}
+
+// resultColumns describes the columns of rows.
+func resultColumns(rows *sqlx.Rows) ([]service.QueryColumn, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	queryColumns := make([]service.QueryColumn, len(columns))
+	for i, name := range columns {
+		colType := "unknown"
+		if i < len(columnTypes) {
+			dbType := columnTypes[i].DatabaseTypeName()
+			if dbType != "" {
+				colType = dbType
+			}
+		}
+		queryColumns[i] = service.QueryColumn{Name: name, Type: colType}
+	}
+	return queryColumns, nil
 }
 
-func (a *Adapter) executeStatement(ctx context.Context, stmt *sqlx.Stmt, query string, params any) (*service.QueryResult, error) {
+func executeStatement(ctx context.Context, db database.Querier, stmt *sqlx.Stmt, query string, params any) (*service.QueryResult, error) {
 	var result sql.Result
 	var err error
 
 	if stmt != nil {
 		result, err = execWithParams(ctx, stmt, params)
 	} else {
-		result, err = a.db.ExecContext(ctx, query)
+		result, err = db.ExecContext(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("statement execution failed: %w", err)
@@ -136,13 +170,14 @@ func (a *Adapter) executeStatement(ctx context.Context, stmt *sqlx.Stmt, query s
 	return &service.QueryResult{
 		Status:       service.JobStatusSuccess,
 		RowsAffected: &ra,
+		CommandTag:   sqlutil.CommandTag(sqlutil.Classify(query, sqlutil.DialectDuckDB).Kind, ra),
 	}, nil
 }
 
 func queryWithParams(ctx context.Context, stmt *sqlx.Stmt, params any) (*sqlx.Rows, error) {
 	switch p := params.(type) {
 	case map[string]any:
-		return nil, fmt.Errorf("named parameters not yet supported in prepared statements")
+		return nil, fmt.Errorf("named parameters must be bound to positional arguments before execution")
 	case []any:
 		return stmt.QueryxContext(ctx, p...)
 	default:
@@ -153,7 +188,7 @@ func queryWithParams(ctx context.Context, stmt *sqlx.Stmt, params any) (*sqlx.Ro
 func execWithParams(ctx context.Context, stmt *sqlx.Stmt, params any) (sql.Result, error) {
 	switch p := params.(type) {
 	case map[string]any:
-		return nil, fmt.Errorf("named parameters not yet supported in prepared statements")
+		return nil, fmt.Errorf("named parameters must be bound to positional arguments before execution")
 	case []any:
 		return stmt.ExecContext(ctx, p...)
 	default:
@@ -7,15 +7,31 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// Querier runs statements. It is implemented by both DB and Conn.
+type Querier interface {
+	QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error)
+}
+
 // DB defines the database operations used by adapters.
 type DB interface {
+	Querier
 	GetContext(ctx context.Context, dest any, query string, args ...any) error
 	SelectContext(ctx context.Context, dest any, query string, args ...any) error
-	QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error)
-	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
-	PreparexContext(ctx context.Context, query string) (*sqlx.Stmt, error)
 	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
 	NamedQueryContext(ctx context.Context, query string, arg any) (*sqlx.Rows, error)
+	// Conn checks out a single connection from the pool until it is closed.
+	Conn(ctx context.Context) (Conn, error)
 	PingContext(ctx context.Context) error
 	Close() error
 }
+
+// Conn is a single pooled connection. Session state such as open
+// transactions persists across calls until the connection is closed.
+type Conn interface {
+	Querier
+	// Raw exposes the underlying driver connection for driver-specific state.
+	Raw(f func(driverConn any) error) error
+	Close() error
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
@@ -15,6 +16,9 @@ type Adapter struct {
 	config         *model.Resource
 	connString     string
 	db             database.DB
+	// registeredConfig names the pgx config the pool was opened with.
+	registeredConfig string
+	notices          noticeRouter
 }
 
 // NewAdapter creates a factory that builds PostgreSQL connection adapters
@@ -47,7 +51,7 @@ func NewAdapter(params service.AdapterFactoryParams) (service.ConnectionAdapter,
 
 	// Build connection string
 	resolvedTLS := model.ResolveTLSPaths(cfg.TLS, params.BaseDir)
-	connString := buildConnectionString(*cfg.Host, *cfg.Port, cfg.Database, *cfg.Username, password, resolvedTLS)
+	connString := buildConnectionString(*cfg.Host, *cfg.Port, cfg.Database, *cfg.Username, password, resolvedTLS, cfg.QueryTimeout, cfg.ReadOnly)
 
 	return &Adapter{
 		connectionName: params.ConnectionName,
@@ -56,8 +60,10 @@ func NewAdapter(params service.AdapterFactoryParams) (service.ConnectionAdapter,
 	}, nil
 }
 
-// buildConnectionString creates a PostgreSQL connection URL
-func buildConnectionString(host string, port int, database, username, password string, tls *model.TLSConfig) string {
+// buildConnectionString creates a PostgreSQL connection URL. A query timeout becomes
+// the statement_timeout of every connection, and read-only resources start every
+// transaction read-only.
+func buildConnectionString(host string, port int, database, username, password string, tls *model.TLSConfig, queryTimeout *int, readOnly bool) string {
 	u := &url.URL{
 		Scheme: "postgres",
 		Host:   fmt.Sprintf("%s:%d", host, port),
@@ -70,8 +76,8 @@ func buildConnectionString(host string, port int, database, username, password s
 		u.User = url.User(username)
 	}
 
+	q := u.Query()
 	if tls != nil {
-		q := u.Query()
 		if tls.Mode != nil && *tls.Mode != "" {
 			q.Set("sslmode", *tls.Mode)
 		}
@@ -84,9 +90,15 @@ func buildConnectionString(host string, port int, database, username, password s
 		if tls.KeyPath != nil && *tls.KeyPath != "" {
 			q.Set("sslkey", *tls.KeyPath)
 		}
-		if len(q) > 0 {
-			u.RawQuery = q.Encode()
-		}
+	}
+	if queryTimeout != nil {
+		q.Set("statement_timeout", strconv.Itoa(*queryTimeout))
+	}
+	if readOnly {
+		q.Set("default_transaction_read_only", "on")
+	}
+	if len(q) > 0 {
+		u.RawQuery = q.Encode()
 	}
 
 	return u.String()
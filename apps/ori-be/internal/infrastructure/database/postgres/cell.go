@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+// encodeCell maps a value scanned through pgx stdlib into the typed cell encoding.
+// typeName is the upper-case type name reported by the driver; array types start with '_'.
+func encodeCell(value any, typeName string) any {
+	if value == nil {
+		return nil
+	}
+	if elementType, ok := strings.CutPrefix(typeName, "_"); ok {
+		if text, ok := value.(string); ok {
+			return encodeArray(text, elementType)
+		}
+	}
+
+	switch typeName {
+	case "NUMERIC", "MONEY":
+		if text, ok := value.(string); ok {
+			return querycell.Decimal(text)
+		}
+	case "JSON", "JSONB":
+		return querycell.JSON(value)
+	case "UUID":
+		return querycell.UUID(value)
+	case "INTERVAL":
+		if text, ok := value.(string); ok {
+			return querycell.Interval(text)
+		}
+	case "TIME", "TIMETZ":
+		if text, ok := value.(string); ok {
+			return querycell.Time(text)
+		}
+	case "DATE":
+		if t, ok := value.(time.Time); ok {
+			return querycell.Date(t)
+		}
+	case "TIMESTAMP":
+		if t, ok := value.(time.Time); ok {
+			return querycell.Timestamp(t, false)
+		}
+	case "TIMESTAMPTZ":
+		if t, ok := value.(time.Time); ok {
+			return querycell.Timestamp(t, true)
+		}
+	case "XML":
+		if raw, ok := value.([]byte); ok {
+			return string(raw)
+		}
+	}
+	return querycell.Encode(value)
+}
+
+// encodeArray parses the text form of an array and encodes its elements.
+// Arrays that cannot be parsed are returned as text.
+func encodeArray(text, elementType string) any {
+	elements, err := parseArray(text)
+	if err != nil {
+		return text
+	}
+	return querycell.Array(encodeArrayElements(elements, elementType), strings.ToLower(elementType))
+}
+
+func encodeArrayElements(elements []any, elementType string) []any {
+	encoded := make([]any, len(elements))
+	for i, element := range elements {
+		switch v := element.(type) {
+		case []any:
+			encoded[i] = encodeArrayElements(v, elementType)
+		case string:
+			encoded[i] = encodeArrayElement(v, elementType)
+		}
+	}
+	return encoded
+}
+
+// encodeArrayElement encodes the text of one array element. Elements of types
+// without a lossless mapping keep their text form.
+func encodeArrayElement(text, elementType string) any {
+	switch elementType {
+	case "INT2", "INT4", "INT8", "OID":
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return querycell.Int(n)
+		}
+	case "FLOAT4", "FLOAT8":
+		if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return querycell.Float(f)
+		}
+	case "BOOL":
+		return text == "t"
+	case "NUMERIC":
+		return querycell.Decimal(text)
+	case "JSON", "JSONB":
+		return querycell.JSON(text)
+	case "UUID":
+		return querycell.UUID(text)
+	case "BYTEA":
+		if raw, err := hex.DecodeString(strings.TrimPrefix(text, `\x`)); err == nil {
+			return querycell.Bytes(raw)
+		}
+	}
+	return text
+}
+
+// parseArray parses the text form of a postgres array into nested slices of
+// element texts. NULL elements are nil.
+func parseArray(text string) ([]any, error) {
+	// Arrays with non-default bounds are prefixed with their dimensions, e.g. [0:1]={a,b}.
+	if strings.HasPrefix(text, "[") {
+		if i := strings.Index(text, "="); i >= 0 {
+			text = text[i+1:]
+		}
+	}
+	parser := arrayParser{text: text}
+	elements, err := parser.parseArray()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos != len(text) {
+		return nil, fmt.Errorf("unexpected trailing text in array at %d", parser.pos)
+	}
+	return elements, nil
+}
+
+type arrayParser struct {
+	text string
+	pos  int
+}
+
+func (p *arrayParser) parseArray() ([]any, error) {
+	if p.peek() != '{' {
+		return nil, fmt.Errorf("expected '{' in array at %d", p.pos)
+	}
+	p.pos++
+	elements := []any{}
+	p.skipSpaces()
+	if p.peek() == '}' {
+		p.pos++
+		return elements, nil
+	}
+
+	for {
+		p.skipSpaces()
+		switch p.peek() {
+		case '{':
+			nested, err := p.parseArray()
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, nested)
+		case '"':
+			element, err := p.parseQuoted()
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, element)
+		default:
+			element := p.parseUnquoted()
+			if strings.EqualFold(element, "NULL") {
+				elements = append(elements, nil)
+			} else {
+				elements = append(elements, element)
+			}
+		}
+
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return elements, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' in array at %d", p.pos)
+		}
+	}
+}
+
+func (p *arrayParser) parseQuoted() (string, error) {
+	p.pos++
+	var builder strings.Builder
+	for p.pos < len(p.text) {
+		char := p.text[p.pos]
+		switch char {
+		case '\\':
+			if p.pos+1 < len(p.text) {
+				builder.WriteByte(p.text[p.pos+1])
+			}
+			p.pos += 2
+		case '"':
+			p.pos++
+			return builder.String(), nil
+		default:
+			builder.WriteByte(char)
+			p.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted array element")
+}
+
+func (p *arrayParser) parseUnquoted() string {
+	start := p.pos
+	for p.pos < len(p.text) && p.text[p.pos] != ',' && p.text[p.pos] != '}' {
+		p.pos++
+	}
+	return strings.TrimSpace(p.text[start:p.pos])
+}
+
+func (p *arrayParser) skipSpaces() {
+	for p.pos < len(p.text) && (p.text[p.pos] == ' ' || p.text[p.pos] == '\t' || p.text[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+func (p *arrayParser) peek() byte {
+	if p.pos >= len(p.text) {
+		return 0
+	}
+	return p.text[p.pos]
+}
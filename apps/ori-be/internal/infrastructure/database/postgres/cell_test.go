@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEncodeCellArrays(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		typeName string
+		want     string
+	}{
+		{name: "integers", value: "{1,NULL,3}", typeName: "_INT4", want: `{"$type":"array","value":[1,null,3],"elementType":"int4"}`},
+		{name: "nested", value: "{{1,2},{3,4}}", typeName: "_INT8", want: `{"$type":"array","value":[[1,2],[3,4]],"elementType":"int8"}`},
+		{name: "quoted text", value: `{"a,b","say \"hi\"",NULL,"NULL"}`, typeName: "_TEXT", want: `{"$type":"array","value":["a,b","say \"hi\"",null,"NULL"],"elementType":"text"}`},
+		{name: "custom bounds", value: "[0:1]={t,f}", typeName: "_BOOL", want: `{"$type":"array","value":[true,false],"elementType":"bool"}`},
+		{name: "numeric", value: "{1.50}", typeName: "_NUMERIC", want: `{"$type":"array","value":[{"$type":"decimal","value":"1.50"}],"elementType":"numeric"}`},
+		{name: "malformed stays text", value: "{1,2", typeName: "_INT4", want: `"{1,2"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.Marshal(encodeCell(tt.value, tt.typeName))
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(payload) != tt.want {
+				t.Fatalf("encodeCell(%q, %q) = %s, want %s", tt.value, tt.typeName, payload, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// commandTagKey carries the recorder a query's command tag is written to.
+type commandTagKey struct{}
+
+// commandTagTracer records the command tag of queries whose context carries a
+// recorder. database/sql hides the tag, but pgx hands it to the tracer once the
+// rows are closed or the statement completes.
+type commandTagTracer struct{}
+
+func (commandTagTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (commandTagTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if tag, ok := ctx.Value(commandTagKey{}).(*pgconn.CommandTag); ok && data.Err == nil {
+		*tag = data.CommandTag
+	}
+}
+
+// withCommandTag returns a context whose queries record their command tag in the returned recorder.
+func withCommandTag(ctx context.Context) (context.Context, *pgconn.CommandTag) {
+	tag := &pgconn.CommandTag{}
+	return context.WithValue(ctx, commandTagKey{}, tag), tag
+}
+
+// applyCommandTag records the command tag of a finished statement, and the rows it
+// affected when it modified rows, which a RETURNING result would otherwise lose.
+func applyCommandTag(result *service.QueryResult, tag pgconn.CommandTag) {
+	result.CommandTag = tag.String()
+	if tag.Insert() || tag.Update() || tag.Delete() || strings.HasPrefix(result.CommandTag, "MERGE ") {
+		affected := tag.RowsAffected()
+		result.RowsAffected = &affected
+	}
+}
@@ -4,24 +4,40 @@ import (
 	"context"
 	"fmt"
 
-	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
 	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/dblogged"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
 
 // Connect establishes the database connection
 func (a *Adapter) Connect(ctx context.Context) error {
+	config, err := pgx.ParseConfig(a.connString)
+	if err != nil {
+		return fmt.Errorf("failed to parse postgresql connection string: %w", err)
+	}
+	config.OnNotice = a.notices.dispatch
+	config.Tracer = commandTagTracer{}
+	registered := stdlib.RegisterConnConfig(config)
+
 	// TODO: replace with DI
-	db, err := dblogged.Open(ctx, "pgx", a.connString)
+	db, err := dblogged.Open(ctx, "pgx", registered)
 	if err != nil {
+		stdlib.UnregisterConnConfig(registered)
 		return fmt.Errorf("failed to open postgresql database: %w", err)
 	}
 	a.db = db
+	a.registeredConfig = registered
 	return nil
 }
 
 // Close releases database resources
 func (a *Adapter) Close() error {
+	if a.registeredConfig != "" {
+		defer stdlib.UnregisterConnConfig(a.registeredConfig)
+	}
 	if a.db != nil {
 		return a.db.Close()
 	}
@@ -35,3 +51,53 @@ func (a *Adapter) Ping(ctx context.Context) error {
 	}
 	return a.db.PingContext(ctx)
 }
+
+// PinConnection checks out a dedicated connection from the pool
+func (a *Adapter) PinConnection(ctx context.Context) (service.PinnedConnection, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	return &pinnedConnection{conn: conn, notices: &a.notices}, nil
+}
+
+// pinnedConnection runs queries on a single connection checked out by PinConnection
+type pinnedConnection struct {
+	conn    database.Conn
+	notices *noticeRouter
+}
+
+func (p *pinnedConnection) ExecuteQuery(ctx context.Context, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	stop, err := p.notices.route(p.conn, jobNotices(options))
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+	return executeQuery(ctx, p.conn, query, params, options)
+}
+
+func (p *pinnedConnection) Close() error {
+	return p.conn.Close()
+}
+
+// TransactionState reports the server-side transaction status of the pinned connection.
+func (p *pinnedConnection) TransactionState() (service.TxState, error) {
+	state := service.TxStateIdle
+	err := p.conn.Raw(func(driverConn any) error {
+		conn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		switch conn.Conn().PgConn().TxStatus() {
+		case 'T':
+			state = service.TxStateInTransaction
+		case 'E':
+			state = service.TxStateFailed
+		}
+		return nil
+	})
+	return state, err
+}
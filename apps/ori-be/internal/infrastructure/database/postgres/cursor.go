@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+const (
+	// cursorName is unique per connection, and every lazy result checks out its own.
+	cursorName         = "ori_result"
+	cursorCloseTimeout = 5 * time.Second
+	// featureNotSupported is raised when a query cannot back a cursor, such as a WITH holding an INSERT.
+	featureNotSupported = "0A000"
+)
+
+// openCursor declares a cursor for query inside a transaction on a dedicated
+// connection. Queries a cursor cannot be declared for run eagerly instead.
+func (a *Adapter) openCursor(ctx context.Context, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	if !sqlutil.IsCursorQuery(query, sqlutil.DialectPostgres) {
+		return a.execute(ctx, query, params, options)
+	}
+	args, err := toArgs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "BEGIN"); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to begin cursor transaction: %w", err)
+	}
+	cursor := &declaredCursor{conn: conn}
+	// Later pages are fetched after the job finished, so only the first reports notices.
+	stop, err := a.notices.route(conn, options.Notices)
+	if err != nil {
+		_ = cursor.Close()
+		return nil, err
+	}
+	defer stop()
+	if options.TimeoutMs > 0 {
+		// The timeout covers the DECLARE and each later FETCH.
+		if _, err := conn.ExecContext(ctx, "SELECT set_config('statement_timeout', $1, true)", strconv.Itoa(options.TimeoutMs)); err != nil {
+			_ = cursor.Close()
+			return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+		}
+	}
+
+	prefix := fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR ", cursorName)
+	body := strings.TrimRight(strings.TrimSpace(query), ";")
+	if _, err := conn.ExecContext(ctx, prefix+body, args...); err != nil {
+		_ = cursor.Close()
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == featureNotSupported {
+			return a.execute(ctx, query, params, options)
+		}
+		// The error position counts the prefix but not the whitespace trimmed from the query.
+		trimmed := len(query) - len(strings.TrimLeftFunc(query, unicode.IsSpace))
+		return nil, databaseError(timeoutError(fmt.Errorf("query execution failed: %w", err)), len(prefix)-trimmed)
+	}
+
+	// Only FETCH describes the columns, so the first page is read here and handed out by Next.
+	fetchRows := options.FetchRows
+	if fetchRows <= 0 {
+		fetchRows = model.DefaultAutoLimitRows
+	}
+	rows, columns, err := cursor.fetch(ctx, fetchRows)
+	if err != nil {
+		_ = cursor.Close()
+		return nil, databaseError(timeoutError(err), 0)
+	}
+	cursor.pending = rows
+	cursor.exhausted = len(rows) < fetchRows
+	return service.NewCursorResult(columns, cursor), nil
+}
+
+// declaredCursor reads a server-side cursor with FETCH. Closing commits the
+// transaction the cursor lives in and returns the connection to the pool.
+type declaredCursor struct {
+	conn      database.Conn
+	pending   [][]any
+	exhausted bool
+}
+
+func (c *declaredCursor) Next(ctx context.Context, n int) ([][]any, error) {
+	take := min(n, len(c.pending))
+	rows := c.pending[:take:take]
+	c.pending = c.pending[take:]
+	if len(rows) == n || c.exhausted {
+		return rows, nil
+	}
+
+	fetched, _, err := c.fetch(ctx, n-len(rows))
+	if err != nil {
+		return nil, err
+	}
+	c.exhausted = len(fetched) < n-len(rows)
+	return append(rows, fetched...), nil
+}
+
+func (c *declaredCursor) fetch(ctx context.Context, n int) ([][]any, []service.QueryColumn, error) {
+	rows, err := c.conn.QueryxContext(ctx, fmt.Sprintf("FETCH FORWARD %d FROM %s", n, cursorName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rows: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := resultColumns(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+	rowData := make([]any, len(columns))
+	rowPtrs := make([]any, len(columns))
+	for i := range rowData {
+		rowPtrs[i] = &rowData[i]
+	}
+
+	var out [][]any
+	for rows.Next() {
+		if err := rows.Scan(rowPtrs...); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		row := make([]any, len(rowData))
+		for i, value := range rowData {
+			row[i] = encodeCell(value, columns[i].Type)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return out, columns, nil
+}
+
+func (c *declaredCursor) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), cursorCloseTimeout)
+	defer cancel()
+	// COMMIT ends a failed transaction too, closing the cursor either way.
+	_, err := c.conn.ExecContext(ctx, "COMMIT")
+	if closeErr := c.conn.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// ExplainQuery runs EXPLAIN (FORMAT JSON) and normalizes the plan. With Analyze the
+// statement runs inside a transaction that is rolled back, so writes are not kept.
+func (a *Adapter) ExplainQuery(ctx context.Context, query string, params any, options *service.QueryExplainOptions) (*service.QueryPlan, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+	args, err := toArgs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.Analyze {
+		return explain(ctx, a.db, "EXPLAIN (FORMAT JSON) ", query, args)
+	}
+
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if _, err := conn.ExecContext(ctx, "BEGIN"); err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK")
+	}()
+	return explain(ctx, conn, "EXPLAIN (FORMAT JSON, ANALYZE) ", query, args)
+}
+
+func explain(ctx context.Context, db database.Querier, prefix, query string, args []any) (*service.QueryPlan, error) {
+	rows, err := db.QueryxContext(ctx, prefix+query, args...)
+	if err != nil {
+		return nil, databaseError(fmt.Errorf("explain failed: %w", err), len(prefix))
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var output []byte
+	if rows.Next() {
+		if err := rows.Scan(&output); err != nil {
+			return nil, fmt.Errorf("failed to scan plan: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return parsePlan(output)
+}
+
+// explainOutput is one entry of the EXPLAIN (FORMAT JSON) array.
+type explainOutput struct {
+	Plan          map[string]any `json:"Plan"`
+	PlanningTime  *float64       `json:"Planning Time"`
+	ExecutionTime *float64       `json:"Execution Time"`
+}
+
+// parsePlan normalizes the output of EXPLAIN (FORMAT JSON).
+func parsePlan(output []byte) (*service.QueryPlan, error) {
+	var outputs []explainOutput
+	if err := json.Unmarshal(output, &outputs); err != nil {
+		return nil, fmt.Errorf("failed to decode plan: %w", err)
+	}
+
+	plan := &service.QueryPlan{}
+	for _, entry := range outputs {
+		if entry.Plan == nil {
+			continue
+		}
+		plan.PlanningTimeMs = entry.PlanningTime
+		plan.ExecutionTimeMs = entry.ExecutionTime
+		plan.Analyzed = entry.ExecutionTime != nil
+		plan.Nodes = append(plan.Nodes, planNode(entry.Plan))
+	}
+	return plan, nil
+}
+
+// planDetailKeys are the node properties summarized in Detail, in display order.
+var planDetailKeys = []string{"Index Name", "Join Type", "Index Cond", "Hash Cond", "Merge Cond", "Join Filter", "Filter", "Sort Key"}
+
+func planNode(raw map[string]any) *service.QueryPlanNode {
+	node := &service.QueryPlanNode{
+		EstimatedRows: planNumber(raw, "Plan Rows"),
+		ActualRows:    planNumber(raw, "Actual Rows"),
+		StartupCost:   planNumber(raw, "Startup Cost"),
+		TotalCost:     planNumber(raw, "Total Cost"),
+		ActualTimeMs:  planNumber(raw, "Actual Total Time"),
+	}
+	node.NodeType, _ = raw["Node Type"].(string)
+	if relation, ok := raw["Relation Name"].(string); ok {
+		if schema, ok := raw["Schema"].(string); ok && schema != "" {
+			relation = schema + "." + relation
+		}
+		node.Relation = relation
+	}
+	if loops := planNumber(raw, "Actual Loops"); loops != nil {
+		count := int64(*loops)
+		node.Loops = &count
+	}
+
+	var details []string
+	for _, key := range planDetailKeys {
+		switch value := raw[key].(type) {
+		case string:
+			details = append(details, key+": "+value)
+		case []any:
+			parts := make([]string, 0, len(value))
+			for _, part := range value {
+				parts = append(parts, fmt.Sprint(part))
+			}
+			details = append(details, key+": "+strings.Join(parts, ", "))
+		}
+	}
+	node.Detail = strings.Join(details, "; ")
+
+	children, _ := raw["Plans"].([]any)
+	for _, child := range children {
+		if childRaw, ok := child.(map[string]any); ok {
+			node.Children = append(node.Children, planNode(childRaw))
+		}
+	}
+	return node
+}
+
+func planNumber(raw map[string]any, key string) *float64 {
+	value, ok := raw[key].(float64)
+	if !ok {
+		return nil
+	}
+	return &value
+}
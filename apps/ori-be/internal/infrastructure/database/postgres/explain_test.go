@@ -0,0 +1,49 @@
+package postgres
+
+import "testing"
+
+func TestParsePlan(t *testing.T) {
+	output := []byte(`[{
+		"Plan": {
+			"Node Type": "Hash Join", "Join Type": "Inner", "Hash Cond": "(o.user_id = u.id)",
+			"Startup Cost": 1.5, "Total Cost": 40.25, "Plan Rows": 12,
+			"Actual Total Time": 0.42, "Actual Rows": 10, "Actual Loops": 1,
+			"Plans": [
+				{"Node Type": "Seq Scan", "Relation Name": "orders", "Alias": "o", "Plan Rows": 100, "Filter": "(total > 10)"},
+				{"Node Type": "Hash", "Plans": [{"Node Type": "Seq Scan", "Relation Name": "users", "Plan Rows": 5}]}
+			]
+		},
+		"Planning Time": 0.1,
+		"Execution Time": 0.6
+	}]`)
+
+	plan, err := parsePlan(output)
+	if err != nil {
+		t.Fatalf("parsePlan: %v", err)
+	}
+	if !plan.Analyzed || plan.ExecutionTimeMs == nil || *plan.ExecutionTimeMs != 0.6 || *plan.PlanningTimeMs != 0.1 {
+		t.Fatalf("plan timings = %+v", plan)
+	}
+	if len(plan.Nodes) != 1 {
+		t.Fatalf("expected one root node, got %d", len(plan.Nodes))
+	}
+
+	root := plan.Nodes[0]
+	if root.NodeType != "Hash Join" || *root.EstimatedRows != 12 || *root.ActualRows != 10 || *root.TotalCost != 40.25 || *root.Loops != 1 {
+		t.Fatalf("root = %+v", root)
+	}
+	if root.Detail != "Join Type: Inner; Hash Cond: (o.user_id = u.id)" {
+		t.Fatalf("root detail = %q", root.Detail)
+	}
+	if len(root.Children) != 2 {
+		t.Fatalf("expected two children, got %d", len(root.Children))
+	}
+
+	scan := root.Children[0]
+	if scan.Relation != "orders" || scan.Detail != "Filter: (total > 10)" || scan.ActualRows != nil {
+		t.Fatalf("scan = %+v", scan)
+	}
+	if users := root.Children[1].Children[0]; users.Relation != "users" || *users.EstimatedRows != 5 {
+		t.Fatalf("nested scan = %+v", users)
+	}
+}
@@ -3,18 +3,24 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
 
 func (a *Adapter) GetScopes(ctx context.Context) ([]model.Scope, error) {
 	query := `
 		SELECT
 			s.schema_name,
-			COALESCE(s.schema_name = current_schema(), false) AS is_default
+			COALESCE(s.schema_name = current_schema(), false) AS is_default,
+			pg_get_userbyid(n.nspowner) as owner,
+			` + aclGrantsColumn("n.nspacl", "n", "n.nspowner") + ` as grants
 		FROM information_schema.schemata s
+		JOIN pg_catalog.pg_namespace n ON n.nspname = s.schema_name
 		WHERE s.schema_name != 'information_schema'
 		  AND s.schema_name NOT LIKE 'pg_%'
 		ORDER BY
@@ -31,18 +37,23 @@ func (a *Adapter) GetScopes(ctx context.Context) ([]model.Scope, error) {
 
 	var scopes []model.Scope
 	for rows.Next() {
-		var schemaName string
+		var schemaName, owner, grants string
 		var isDefault bool
-		if err := rows.Scan(&schemaName, &isDefault); err != nil {
+		if err := rows.Scan(&schemaName, &isDefault, &owner, &grants); err != nil {
 			return nil, fmt.Errorf("failed to scan schema: %w", err)
 		}
-		scopes = append(scopes, model.Schema{
+		scope := model.Schema{
 			Engine:         "postgres",
 			ConnectionName: a.connectionName,
 			Database:       a.config.Database,
 			Name:           schemaName,
 			IsDefault:      isDefault,
-		})
+			Owner:          owner,
+		}
+		if err := json.Unmarshal([]byte(grants), &scope.Grants); err != nil {
+			return nil, fmt.Errorf("failed to decode grants of %s: %w", schemaName, err)
+		}
+		scopes = append(scopes, scope)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating schemas: %w", err)
@@ -61,18 +72,29 @@ func (a *Adapter) GetRelations(ctx context.Context, scope model.Scope) ([]model.
 			n.nspname as schema_name,
 			c.relname as table_name,
 			c.relkind,
-			CASE WHEN c.relkind = 'v' THEN pg_get_viewdef(c.oid, true) ELSE '' END as definition,
+			CASE WHEN c.relkind IN ('v', 'm') THEN pg_get_viewdef(c.oid, true) ELSE '' END as definition,
 			pn.nspname as parent_schema,
-			pc.relname as parent_name
+			pc.relname as parent_name,
+			c.relispopulated as populated,
+			COALESCE(pt.partstrat::text, '') as partition_strategy,
+			CASE WHEN pt.partrelid IS NOT NULL THEN pg_get_partkeydef(c.oid) ELSE '' END as partition_key,
+			COALESCE(pg_get_expr(c.relpartbound, c.oid), '') as partition_bound,
+			COALESCE(fs.srvname, '') as server,
+			COALESCE(array_to_json(ft.ftoptions)::text, '[]') as options,
+			pg_get_userbyid(c.relowner) as owner,
+			` + aclGrantsColumn("c.relacl", "r", "c.relowner") + ` as grants
 		FROM pg_catalog.pg_class c
 		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
 		LEFT JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid
 		LEFT JOIN pg_catalog.pg_class pc ON pc.oid = i.inhparent
 		LEFT JOIN pg_catalog.pg_namespace pn ON pn.oid = pc.relnamespace
+		LEFT JOIN pg_catalog.pg_partitioned_table pt ON pt.partrelid = c.oid
+		LEFT JOIN pg_catalog.pg_foreign_table ft ON ft.ftrelid = c.oid
+		LEFT JOIN pg_catalog.pg_foreign_server fs ON fs.oid = ft.ftserver
 		WHERE (
-				n.nspname = $1 AND c.relkind IN ('r', 'p', 'v')
+				n.nspname = $1 AND c.relkind IN ('r', 'p', 'v', 'm', 'f')
 			) OR (
-				pn.nspname = $1 AND c.relkind = 'r'
+				pn.nspname = $1 AND c.relkind IN ('r', 'p', 'f')
 			)
 		ORDER BY n.nspname, c.relname
 	`
@@ -89,13 +111,35 @@ func (a *Adapter) GetRelations(ctx context.Context, scope model.Scope) ([]model.
 		var schemaName, name, relkind, definition string
 		var parentSchema sql.NullString
 		var parentTable sql.NullString
-		if err := rows.Scan(&schemaName, &name, &relkind, &definition, &parentSchema, &parentTable); err != nil {
+		var populated bool
+		var strategy, partitionKey, partitionBound, server, options, owner, grants string
+		if err := rows.Scan(
+			&schemaName,
+			&name,
+			&relkind,
+			&definition,
+			&parentSchema,
+			&parentTable,
+			&populated,
+			&strategy,
+			&partitionKey,
+			&partitionBound,
+			&server,
+			&options,
+			&owner,
+			&grants,
+		); err != nil {
 			return nil, fmt.Errorf("failed to scan relation: %w", err)
 		}
 
-		relType := "table"
-		if relkind == "v" {
-			relType = "view"
+		relType := relationTypeFromKind(relkind)
+		var optionList []string
+		if err := json.Unmarshal([]byte(options), &optionList); err != nil {
+			return nil, fmt.Errorf("failed to decode options of %s: %w", name, err)
+		}
+		var grantList []model.Grant
+		if err := json.Unmarshal([]byte(grants), &grantList); err != nil {
+			return nil, fmt.Errorf("failed to decode grants of %s: %w", name, err)
 		}
 
 		schemaValue := schemaName
@@ -113,12 +157,20 @@ func (a *Adapter) GetRelations(ctx context.Context, scope model.Scope) ([]model.
 		}
 
 		relations = append(relations, model.Relation{
-			Name:         name,
-			Type:         relType,
-			Definition:   definition,
-			Schema:       schemaPtr,
-			ParentSchema: parentSchemaPtr,
-			ParentTable:  parentTablePtr,
+			Name:              name,
+			Type:              relType,
+			Definition:        definition,
+			Schema:            schemaPtr,
+			ParentSchema:      parentSchemaPtr,
+			ParentTable:       parentTablePtr,
+			Populated:         populated,
+			PartitionStrategy: partitionStrategyFromCode(strategy),
+			PartitionKey:      partitionKeyColumns(partitionKey),
+			PartitionBound:    partitionBound,
+			Server:            server,
+			Options:           optionList,
+			Owner:             owner,
+			Grants:            grantList,
 		})
 	}
 	if err := rows.Err(); err != nil {
@@ -147,19 +199,41 @@ func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation st
 				AND tc.constraint_type = 'PRIMARY KEY'
 		)
 		SELECT
-			c.column_name,
-			c.ordinal_position,
-			c.data_type,
-			CASE WHEN c.is_nullable = 'YES' THEN false ELSE true END as not_null,
-			c.column_default,
+			a.attname as column_name,
+			a.attnum as ordinal_position,
+			COALESCE(c.data_type, pg_catalog.format_type(a.atttypid, a.atttypmod)) as data_type,
+			COALESCE(c.is_nullable = 'NO', a.attnotnull) as not_null,
+			CASE WHEN a.attgenerated = '' THEN pg_get_expr(ad.adbin, ad.adrelid) END as column_default,
 			c.character_maximum_length,
 			c.numeric_precision,
 			c.numeric_scale,
-			COALESCE(pk.ordinal_position, 0) as pk_position
-		FROM information_schema.columns c
-		LEFT JOIN pk_columns pk ON pk.column_name = c.column_name
-		WHERE c.table_schema = $1 AND c.table_name = $2
-		ORDER BY c.ordinal_position
+			COALESCE(pk.ordinal_position, 0) as pk_position,
+			a.attidentity::text as identity,
+			a.attgenerated::text as generated,
+			CASE WHEN a.attgenerated <> '' THEN pg_get_expr(ad.adbin, ad.adrelid, true) END as generation_expression,
+			CASE WHEN ut.typtype IN ('e', 'd') OR utr.relkind = 'c' THEN utn.nspname END as type_schema,
+			CASE WHEN utn.oid IS NOT NULL AND (ut.typtype IN ('e', 'd') OR utr.relkind = 'c') THEN ut.typname END as type_name
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_class rel ON rel.oid = a.attrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = rel.relnamespace
+		-- Array columns link to the type of their elements.
+		JOIN pg_catalog.pg_type at ON at.oid = a.atttypid
+		JOIN pg_catalog.pg_type ut ON ut.oid = CASE WHEN at.typcategory = 'A' THEN at.typelem ELSE at.oid END
+		LEFT JOIN pg_catalog.pg_namespace utn ON utn.oid = ut.typnamespace
+			AND utn.nspname NOT IN ('pg_catalog', 'information_schema')
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_catalog.pg_depend dep
+				WHERE dep.classid = 'pg_type'::regclass AND dep.objid = ut.oid AND dep.deptype = 'e'
+			)
+		LEFT JOIN pg_catalog.pg_class utr ON utr.oid = ut.typrelid
+		-- information_schema leaves out materialized views, so it only fills in type details.
+		LEFT JOIN information_schema.columns c
+			ON c.table_schema = n.nspname AND c.table_name = rel.relname AND c.column_name = a.attname
+		LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+		LEFT JOIN pk_columns pk ON pk.column_name = a.attname
+		WHERE n.nspname = $1 AND rel.relname = $2
+			AND a.attnum > 0 AND NOT a.attisdropped
+		ORDER BY a.attnum
 	`
 	rows, err := a.db.QueryxContext(ctx, query, *schema, relation)
 	if err != nil {
@@ -177,6 +251,8 @@ func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation st
 		var numPrecision sql.NullInt64
 		var numScale sql.NullInt64
 		var pkPos sql.NullInt64
+		var identity, generated string
+		var generationExpression, typeSchema, typeName sql.NullString
 		if err := rows.Scan(
 			&col.Name,
 			&col.Ordinal,
@@ -187,9 +263,23 @@ func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation st
 			&numPrecision,
 			&numScale,
 			&pkPos,
+			&identity,
+			&generated,
+			&generationExpression,
+			&typeSchema,
+			&typeName,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan column: %w", err)
 		}
+		if typeName.Valid {
+			col.TypeSchema = &typeSchema.String
+			col.TypeName = &typeName.String
+		}
+		col.Identity = identityFromCode(identity)
+		col.Generated = generatedFromCode(generated)
+		if generationExpression.Valid {
+			col.GenerationExpression = &generationExpression.String
+		}
 		if defaultValue.Valid {
 			col.DefaultValue = &defaultValue.String
 		}
@@ -390,6 +480,418 @@ func (a *Adapter) GetTriggers(ctx context.Context, scope model.Scope, relation s
 	return triggers, nil
 }
 
+func (a *Adapter) GetRoutines(ctx context.Context, scope model.Scope) ([]model.Routine, error) {
+	schema := scope.SchemaName()
+	if schema == nil {
+		return nil, fmt.Errorf("postgres requires schema in scope")
+	}
+
+	// Aggregates have no definition to show; extension members belong to the extension.
+	query := `
+		SELECT
+			p.proname,
+			p.prokind,
+			p.proname || '(' || pg_get_function_identity_arguments(p.oid) || ')' as signature,
+			pg_get_function_arguments(p.oid) as arguments,
+			COALESCE(pg_get_function_result(p.oid), '') as return_type,
+			l.lanname,
+			p.provolatile,
+			p.prosecdef,
+			pg_get_functiondef(p.oid) as definition,
+			pg_get_userbyid(p.proowner) as owner,
+			` + aclGrantsColumn("p.proacl", "f", "p.proowner") + ` as grants
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_language l ON l.oid = p.prolang
+		WHERE n.nspname = $1
+			AND p.prokind IN ('f', 'p', 'w')
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_depend d
+				WHERE d.classid = 'pg_proc'::regclass
+					AND d.objid = p.oid
+					AND d.deptype = 'e'
+			)
+		ORDER BY signature
+	`
+
+	rows, err := a.db.QueryxContext(ctx, query, *schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read routines: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var routines []model.Routine
+	for rows.Next() {
+		var name, kind, signature, arguments, returnType, language, volatility, definition, owner, grants string
+		var securityDefiner bool
+		if err := rows.Scan(
+			&name,
+			&kind,
+			&signature,
+			&arguments,
+			&returnType,
+			&language,
+			&volatility,
+			&securityDefiner,
+			&definition,
+			&owner,
+			&grants,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan routine: %w", err)
+		}
+		routine := model.Routine{
+			Name:            name,
+			Kind:            "function",
+			Signature:       signature,
+			Arguments:       arguments,
+			ReturnType:      returnType,
+			Language:        language,
+			Volatility:      volatilityFromCode(volatility),
+			SecurityDefiner: securityDefiner,
+			Definition:      definition,
+			Owner:           owner,
+		}
+		if err := json.Unmarshal([]byte(grants), &routine.Grants); err != nil {
+			return nil, fmt.Errorf("failed to decode grants of %s: %w", signature, err)
+		}
+		if kind == "p" {
+			routine.Kind = "procedure"
+			routine.ReturnType = ""
+			routine.Volatility = ""
+		}
+		routines = append(routines, routine)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating routines: %w", err)
+	}
+	return routines, nil
+}
+
+func (a *Adapter) GetSequences(ctx context.Context, scope model.Scope) ([]model.Sequence, error) {
+	schema := scope.SchemaName()
+	if schema == nil {
+		return nil, fmt.Errorf("postgres requires schema in scope")
+	}
+
+	// Serial columns own their sequence through an auto dependency, identity columns through an internal one.
+	query := `
+		SELECT
+			s.sequencename,
+			s.data_type::text,
+			s.start_value,
+			s.increment_by,
+			s.min_value,
+			s.max_value,
+			s.cycle,
+			s.last_value,
+			COALESCE(owner.relname, '') as owned_by_table,
+			COALESCE(att.attname, '') as owned_by_column
+		FROM pg_sequences s
+		JOIN pg_namespace n ON n.nspname = s.schemaname
+		JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+		LEFT JOIN pg_depend d ON d.classid = 'pg_class'::regclass
+			AND d.objid = c.oid
+			AND d.refclassid = 'pg_class'::regclass
+			AND d.deptype IN ('a', 'i')
+		LEFT JOIN pg_class owner ON owner.oid = d.refobjid
+		LEFT JOIN pg_attribute att ON att.attrelid = d.refobjid AND att.attnum = d.refobjsubid
+		WHERE s.schemaname = $1
+		ORDER BY s.sequencename
+	`
+
+	rows, err := a.db.QueryxContext(ctx, query, *schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sequences: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var sequences []model.Sequence
+	for rows.Next() {
+		var seq model.Sequence
+		var lastValue sql.NullInt64
+		if err := rows.Scan(
+			&seq.Name,
+			&seq.DataType,
+			&seq.Start,
+			&seq.Increment,
+			&seq.MinValue,
+			&seq.MaxValue,
+			&seq.Cycle,
+			&lastValue,
+			&seq.OwnedByTable,
+			&seq.OwnedByColumn,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan sequence: %w", err)
+		}
+		if lastValue.Valid {
+			seq.CurrentValue = &lastValue.Int64
+		}
+		sequences = append(sequences, seq)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sequences: %w", err)
+	}
+	return sequences, nil
+}
+
+func (a *Adapter) GetTypes(ctx context.Context, scope model.Scope) ([]model.UserType, error) {
+	schema := scope.SchemaName()
+	if schema == nil {
+		return nil, fmt.Errorf("postgres requires schema in scope")
+	}
+
+	// Composite types back every table too; only standalone ones (relkind 'c') are user types.
+	query := `
+		SELECT
+			t.typname,
+			t.typtype::text,
+			COALESCE((
+				SELECT json_agg(e.enumlabel ORDER BY e.enumsortorder)
+				FROM pg_enum e
+				WHERE e.enumtypid = t.oid
+			)::text, '[]') as labels,
+			CASE WHEN t.typtype = 'd' THEN format_type(t.typbasetype, t.typtypmod) ELSE '' END as base_type,
+			t.typnotnull,
+			t.typdefault,
+			COALESCE((
+				SELECT json_agg(pg_get_constraintdef(con.oid) ORDER BY con.conname)
+				FROM pg_constraint con
+				WHERE con.contypid = t.oid AND con.contype = 'c'
+			)::text, '[]') as constraints,
+			COALESCE((
+				SELECT json_agg(json_build_object(
+					'name', att.attname,
+					'dataType', format_type(att.atttypid, att.atttypmod)
+				) ORDER BY att.attnum)
+				FROM pg_attribute att
+				WHERE att.attrelid = t.typrelid AND att.attnum > 0 AND NOT att.attisdropped
+			)::text, '[]') as fields
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_class rel ON rel.oid = t.typrelid
+		WHERE n.nspname = $1
+			AND (t.typtype IN ('e', 'd') OR (t.typtype = 'c' AND rel.relkind = 'c'))
+			AND NOT EXISTS (
+				SELECT 1 FROM pg_depend dep
+				WHERE dep.classid = 'pg_type'::regclass AND dep.objid = t.oid AND dep.deptype = 'e'
+			)
+		ORDER BY t.typname
+	`
+
+	rows, err := a.db.QueryxContext(ctx, query, *schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read types: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var types []model.UserType
+	for rows.Next() {
+		var typ model.UserType
+		var kind, labels, constraints, fields string
+		var defaultValue sql.NullString
+		if err := rows.Scan(
+			&typ.Name,
+			&kind,
+			&labels,
+			&typ.BaseType,
+			&typ.NotNull,
+			&defaultValue,
+			&constraints,
+			&fields,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan type: %w", err)
+		}
+		typ.Kind = typeKindFromCode(kind)
+		if defaultValue.Valid {
+			typ.Default = &defaultValue.String
+		}
+		if err := json.Unmarshal([]byte(labels), &typ.Labels); err != nil {
+			return nil, fmt.Errorf("failed to decode labels of %s: %w", typ.Name, err)
+		}
+		if err := json.Unmarshal([]byte(constraints), &typ.Constraints); err != nil {
+			return nil, fmt.Errorf("failed to decode constraints of %s: %w", typ.Name, err)
+		}
+		if err := json.Unmarshal([]byte(fields), &typ.Fields); err != nil {
+			return nil, fmt.Errorf("failed to decode fields of %s: %w", typ.Name, err)
+		}
+		types = append(types, typ)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating types: %w", err)
+	}
+	return types, nil
+}
+
+// GetExtensions returns every installed extension. They belong to the database rather
+// than the schema holding their objects, which may be pg_catalog, so only the current
+// default schema lists them; each keeps its own schema as an attribute. When the
+// search_path names no existing schema, public lists them instead, or the first listed
+// schema when there is no public.
+func (a *Adapter) GetExtensions(ctx context.Context, scope model.Scope) ([]model.Extension, error) {
+	schema := scope.SchemaName()
+	if schema == nil {
+		return nil, fmt.Errorf("postgres requires schema in scope")
+	}
+
+	query := `
+		SELECT
+			e.extname,
+			e.extversion,
+			n.nspname,
+			COALESCE(av.comment, '') as description
+		FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		LEFT JOIN pg_available_extensions av ON av.name = e.extname
+		WHERE COALESCE(current_schema(), (
+			SELECT s.schema_name
+			FROM information_schema.schemata s
+			WHERE s.schema_name != 'information_schema'
+			  AND s.schema_name NOT LIKE 'pg_%'
+			ORDER BY s.schema_name != 'public', s.schema_name
+			LIMIT 1
+		)) = $1
+		ORDER BY e.extname
+	`
+
+	rows, err := a.db.QueryxContext(ctx, query, *schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read extensions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var extensions []model.Extension
+	for rows.Next() {
+		var ext model.Extension
+		if err := rows.Scan(&ext.Name, &ext.Version, &ext.Schema, &ext.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan extension: %w", err)
+		}
+		extensions = append(extensions, ext)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating extensions: %w", err)
+	}
+	return extensions, nil
+}
+
+// GetRolePrivileges lists the objects a role holds privileges on, counting the
+// ones it inherits through role membership or PUBLIC. Superusers pass every
+// check, so they get every object. A non-empty schema limits the list to it.
+func (a *Adapter) GetRolePrivileges(ctx context.Context, role, schema string) (*model.RolePrivileges, error) {
+	var roleRow struct {
+		Name      string `db:"rolname"`
+		Superuser bool   `db:"rolsuper"`
+	}
+	err := a.db.GetContext(ctx, &roleRow, `
+		SELECT rolname, rolsuper
+		FROM pg_roles
+		WHERE rolname = COALESCE(NULLIF($1::text, ''), current_user)
+	`, role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", service.ErrUnknownRole, role)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read role: %w", err)
+	}
+	result := &model.RolePrivileges{Role: roleRow.Name, Superuser: roleRow.Superuser}
+
+	query := `
+		SELECT object_type, schema_name, object_name, privilege, grant_option
+		FROM (
+			SELECT
+				'schema' as object_type,
+				n.nspname as schema_name,
+				n.nspname as object_name,
+				priv.pos,
+				priv.name as privilege,
+				has_schema_privilege($1::name, n.oid, priv.name) as granted,
+				has_schema_privilege($1::name, n.oid, priv.name || ' WITH GRANT OPTION') as grant_option
+			FROM pg_namespace n
+			CROSS JOIN (VALUES (1, 'USAGE'), (2, 'CREATE')) priv(pos, name)
+			WHERE n.nspname != 'information_schema'
+				AND n.nspname NOT LIKE 'pg_%'
+			UNION ALL
+			SELECT
+				CASE c.relkind WHEN 'v' THEN 'view' WHEN 'm' THEN 'matview' WHEN 'f' THEN 'foreign_table' ELSE 'table' END,
+				n.nspname,
+				c.relname,
+				priv.pos,
+				priv.name,
+				has_table_privilege($1::name, c.oid, priv.name),
+				has_table_privilege($1::name, c.oid, priv.name || ' WITH GRANT OPTION')
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			CROSS JOIN (VALUES
+				(1, 'SELECT'), (2, 'INSERT'), (3, 'UPDATE'), (4, 'DELETE'),
+				(5, 'TRUNCATE'), (6, 'REFERENCES'), (7, 'TRIGGER')
+			) priv(pos, name)
+			WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f')
+				AND n.nspname != 'information_schema'
+				AND n.nspname NOT LIKE 'pg_%'
+			UNION ALL
+			SELECT
+				CASE p.prokind WHEN 'p' THEN 'procedure' ELSE 'function' END,
+				n.nspname,
+				p.proname || '(' || pg_get_function_identity_arguments(p.oid) || ')',
+				1,
+				'EXECUTE',
+				has_function_privilege($1::name, p.oid, 'EXECUTE'),
+				has_function_privilege($1::name, p.oid, 'EXECUTE WITH GRANT OPTION')
+			FROM pg_proc p
+			JOIN pg_namespace n ON n.oid = p.pronamespace
+			WHERE p.prokind IN ('f', 'p', 'w')
+				AND n.nspname != 'information_schema'
+				AND n.nspname NOT LIKE 'pg_%'
+				AND NOT EXISTS (
+					SELECT 1 FROM pg_depend d
+					WHERE d.classid = 'pg_proc'::regclass
+						AND d.objid = p.oid
+						AND d.deptype = 'e'
+				)
+		) privileges
+		WHERE granted AND ($2::text = '' OR schema_name = $2)
+		ORDER BY schema_name, object_type != 'schema', object_type, object_name, pos
+	`
+
+	rows, err := a.db.QueryxContext(ctx, query, result.Role, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read privileges: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		var objectType, schemaName, objectName string
+		var grant model.Grant
+		if err := rows.Scan(&objectType, &schemaName, &objectName, &grant.Privilege, &grant.GrantOption); err != nil {
+			return nil, fmt.Errorf("failed to scan privilege: %w", err)
+		}
+		last := len(result.Objects) - 1
+		if last < 0 || result.Objects[last].ObjectType != objectType ||
+			result.Objects[last].Schema != schemaName || result.Objects[last].Name != objectName {
+			result.Objects = append(result.Objects, model.ObjectPrivileges{
+				ObjectType: objectType,
+				Schema:     schemaName,
+				Name:       objectName,
+			})
+			last++
+		}
+		result.Objects[last].Privileges = append(result.Objects[last].Privileges, grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating privileges: %w", err)
+	}
+	return result, nil
+}
+
 // TODO: remove defensive slop?
 func splitCSV(input string) []string {
 	if input == "" {
@@ -445,6 +947,106 @@ func triggerEventsFromBits(bits int) []string {
 	return events
 }
 
+func relationTypeFromKind(kind string) string {
+	switch kind {
+	case "v":
+		return "view"
+	case "m":
+		return "matview"
+	case "f":
+		return "foreign_table"
+	default:
+		return "table"
+	}
+}
+
+func partitionStrategyFromCode(code string) string {
+	switch code {
+	case "r":
+		return "RANGE"
+	case "l":
+		return "LIST"
+	case "h":
+		return "HASH"
+	default:
+		return ""
+	}
+}
+
+// partitionKeyColumns strips the strategy from pg_get_partkeydef output, so
+// "RANGE (created_at)" becomes "created_at".
+func partitionKeyColumns(def string) string {
+	start := strings.Index(def, "(")
+	if start < 0 || !strings.HasSuffix(def, ")") {
+		return def
+	}
+	return def[start+1 : len(def)-1]
+}
+
+// aclGrantsColumn expands an ACL column into a JSON array of grants. A NULL
+// ACL means the object still has its default privileges, which acldefault
+// spells out for the owner (and PUBLIC, for functions).
+func aclGrantsColumn(acl, objectType, owner string) string {
+	return fmt.Sprintf(`COALESCE((
+				SELECT json_agg(json_build_object(
+					'grantee', CASE WHEN g.grantee = 0 THEN 'PUBLIC' ELSE pg_get_userbyid(g.grantee) END,
+					'privilege', g.privilege_type,
+					'grantOption', g.is_grantable
+				) ORDER BY g.grantee <> 0, pg_get_userbyid(g.grantee), g.privilege_type)
+				FROM aclexplode(COALESCE(%s, acldefault('%s', %s))) g
+			)::text, '[]')`, acl, objectType, owner)
+}
+
+func typeKindFromCode(code string) string {
+	switch code {
+	case "e":
+		return "enum"
+	case "d":
+		return "domain"
+	default:
+		return "composite"
+	}
+}
+
+func identityFromCode(code string) *string {
+	var identity string
+	switch code {
+	case "a":
+		identity = "ALWAYS"
+	case "d":
+		identity = "BY DEFAULT"
+	default:
+		return nil
+	}
+	return &identity
+}
+
+func generatedFromCode(code string) *string {
+	var generated string
+	switch code {
+	case "s":
+		generated = "STORED"
+	case "v":
+		generated = "VIRTUAL"
+	default:
+		return nil
+	}
+	return &generated
+}
+
+func volatilityFromCode(code string) string {
+	switch code {
+	case "i":
+		return "IMMUTABLE"
+	case "s":
+		return "STABLE"
+	case "v":
+		return "VOLATILE"
+	default:
+		return ""
+	}
+}
+
 func constraintTypeFromCode(code string) string {
 	switch code {
 	case "p":
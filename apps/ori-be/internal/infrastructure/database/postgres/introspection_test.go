@@ -0,0 +1,20 @@
+package postgres
+
+import "testing"
+
+func TestPartitionKeyColumns(t *testing.T) {
+	tests := []struct {
+		def  string
+		want string
+	}{
+		{def: "RANGE (happened_on)", want: "happened_on"},
+		{def: "LIST (region, lower(code))", want: "region, lower(code)"},
+		{def: "HASH (id)", want: "id"},
+		{def: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := partitionKeyColumns(tt.def); got != tt.want {
+			t.Errorf("partitionKeyColumns(%q) = %q, want %q", tt.def, got, tt.want)
+		}
+	}
+}
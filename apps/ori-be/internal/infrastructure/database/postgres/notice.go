@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// noticeRouter hands the notices a connection receives to the job running on it.
+// pgx reports notices per connection, so jobs register the connection they hold.
+type noticeRouter struct {
+	sinks sync.Map // *pgconn.PgConn -> *service.QueryNotices
+}
+
+// dispatch is the OnNotice handler of every connection the adapter opens.
+func (r *noticeRouter) dispatch(conn *pgconn.PgConn, notice *pgconn.Notice) {
+	sink, ok := r.sinks.Load(conn)
+	if !ok {
+		return
+	}
+	sink.(*service.QueryNotices).Add(service.QueryNotice{
+		Severity: notice.Severity,
+		Code:     notice.Code,
+		Message:  notice.Message,
+	})
+}
+
+// route sends the notices conn receives to sink until the returned function is called.
+func (r *noticeRouter) route(conn database.Conn, sink *service.QueryNotices) (func(), error) {
+	if sink == nil {
+		return func() {}, nil
+	}
+	var pgConn *pgconn.PgConn
+	err := conn.Raw(func(driverConn any) error {
+		stdConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		pgConn = stdConn.Conn().PgConn()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.sinks.Store(pgConn, sink)
+	// The connection may already serve another job by the time the caller stops.
+	return func() { r.sinks.CompareAndDelete(pgConn, sink) }, nil
+}
@@ -3,38 +3,157 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
-	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
 	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
 	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 )
 
+// queryCanceled is raised for canceled statements, including those past statement_timeout.
+const queryCanceled = "57014"
+
 // ExecuteQuery runs a query and returns the result
 func (a *Adapter) ExecuteQuery(ctx context.Context, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
 	if a.db == nil {
 		return nil, fmt.Errorf("database not connected")
 	}
+	if options != nil && options.Lazy {
+		return a.openCursor(ctx, query, params, options)
+	}
+	return a.execute(ctx, query, params, options)
+}
+
+// execute runs the query eagerly. Jobs that collect notices or need their own timeout
+// run on a dedicated connection; everything else uses the pool directly.
+func (a *Adapter) execute(ctx context.Context, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	setTimeout := options != nil && options.TimeoutMs > 0 && !a.hasQueryTimeout(options.TimeoutMs)
+	if !setTimeout && jobNotices(options) == nil {
+		return executeQuery(ctx, a.db, query, params, options)
+	}
+
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	stop, err := a.notices.route(conn, jobNotices(options))
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+	if !setTimeout {
+		return executeQuery(ctx, conn, query, params, options)
+	}
+	return executeWithTimeout(ctx, conn, query, params, options)
+}
+
+// hasQueryTimeout reports whether pooled connections already run with timeoutMs as their statement_timeout.
+func (a *Adapter) hasQueryTimeout(timeoutMs int) bool {
+	return a.config != nil && a.config.QueryTimeout != nil && *a.config.QueryTimeout == timeoutMs
+}
+
+// executeWithTimeout runs the query with the job's timeout as the connection's
+// statement_timeout, and restores the default before the connection returns to the pool.
+func executeWithTimeout(ctx context.Context, conn database.Conn, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	if _, err := conn.ExecContext(ctx, "SELECT set_config('statement_timeout', $1, false)", strconv.Itoa(options.TimeoutMs)); err != nil {
+		return nil, fmt.Errorf("failed to set statement timeout: %w", err)
+	}
+
+	result, err := executeQuery(ctx, conn, query, params, options)
+
+	resetCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cursorCloseTimeout)
+	defer cancel()
+	if _, resetErr := conn.ExecContext(resetCtx, "RESET statement_timeout"); resetErr != nil {
+		// Drop the connection rather than pool it with the job's timeout.
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	}
+	return result, err
+}
+
+// jobNotices returns the collector for the job's notices, if it has one.
+func jobNotices(options *service.QueryExecOptions) *service.QueryNotices {
+	if options == nil {
+		return nil
+	}
+	return options.Notices
+}
 
+func executeQuery(ctx context.Context, db database.Querier, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	var (
+		result *service.QueryResult
+		err    error
+	)
+	ctx, tag := withCommandTag(ctx)
 	// Check if the query returns rows or is a statement
-	if sqlutil.IsRowReturningQuery(query) {
-		return a.executeSelect(ctx, query, params, options)
+	if sqlutil.IsRowReturningQuery(query, sqlutil.DialectPostgres) {
+		result, err = executeSelect(ctx, db, query, params, options)
+	} else {
+		result, err = executeStatement(ctx, db, query, params)
+	}
+	if err != nil {
+		return nil, databaseError(timeoutError(err), 0)
+	}
+	applyCommandTag(result, *tag)
+	return result, nil
+}
+
+// timeoutError marks err as a query timeout when the server canceled the statement
+// for exceeding statement_timeout.
+func timeoutError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == queryCanceled && strings.Contains(pgErr.Message, "statement timeout") {
+		return fmt.Errorf("%w: %w", service.ErrQueryTimeout, err)
+	}
+	return err
+}
+
+// databaseError wraps a server error with the fields PostgreSQL reported. prefix is the
+// number of characters the adapter put before the submitted statement, which the
+// error position counts.
+func databaseError(err error, prefix int) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	return &service.DatabaseError{
+		Engine:     "postgres",
+		Code:       pgErr.Code,
+		Severity:   pgErr.Severity,
+		Message:    pgErr.Message,
+		Detail:     pgErr.Detail,
+		Hint:       pgErr.Hint,
+		Position:   max(int(pgErr.Position)-prefix, 0),
+		Schema:     pgErr.SchemaName,
+		Table:      pgErr.TableName,
+		ColumnName: pgErr.ColumnName,
+		Constraint: pgErr.ConstraintName,
+		Err:        err,
 	}
-	return a.executeStatement(ctx, query, params)
 }
 
 // executeSelect executes a SELECT query
-func (a *Adapter) executeSelect(ctx context.Context, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+func executeSelect(ctx context.Context, db database.Querier, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
 	var rows *sqlx.Rows
 	var err error
 
 	// Execute the query with parameters
-	args := toArgs(params)
+	args, err := toArgs(params)
+	if err != nil {
+		return nil, err
+	}
 	if len(args) > 0 {
-		rows, err = a.db.QueryxContext(ctx, query, args...)
+		rows, err = db.QueryxContext(ctx, query, args...)
 	} else {
-		rows, err = a.db.QueryxContext(ctx, query)
+		rows, err = db.QueryxContext(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
@@ -44,42 +163,21 @@ func (a *Adapter) executeSelect(ctx context.Context, query string, params any, o
 	}()
 
 	// Get column information
-	columns, err := rows.Columns()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %w", err)
-	}
-
-	// Get column types
-	columnTypes, err := rows.ColumnTypes()
+	queryColumns, err := resultColumns(rows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get column types: %w", err)
-	}
-
-	// Build column metadata
-	queryColumns := make([]service.QueryColumn, len(columns))
-	for i, name := range columns {
-		colType := "unknown"
-		if i < len(columnTypes) {
-			dbType := columnTypes[i].DatabaseTypeName()
-			if dbType != "" {
-				colType = dbType
-			}
-		}
-		queryColumns[i] = service.QueryColumn{
-			Name: name,
-			Type: colType,
-		}
+		return nil, err
 	}
 
 	// Prepare slice to hold row data
-	rowData := make([]any, len(columns))
-	rowPtrs := make([]any, len(columns))
+	rowData := make([]any, len(queryColumns))
+	rowPtrs := make([]any, len(queryColumns))
 	for i := range rowData {
 		rowPtrs[i] = &rowData[i]
 	}
 
-	var allRows [][]any
-	rowCount := 0
+	collector := service.NewRowCollector(options)
+	defer collector.Discard()
+	collector.SetColumns(queryColumns)
 	truncated := false
 
 	// Collect rows up to the limit
@@ -88,17 +186,18 @@ func (a *Adapter) executeSelect(ctx context.Context, query string, params any, o
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		// Create a copy of the row data
+		// Encode the row into typed cells
 		rowCopy := make([]any, len(rowData))
 		for i, value := range rowData {
-			rowCopy[i] = querycell.Stringify(value)
+			rowCopy[i] = encodeCell(value, queryColumns[i].Type)
 		}
 
-		allRows = append(allRows, rowCopy)
-		rowCount++
+		if err := collector.Append(rowCopy); err != nil {
+			return nil, err
+		}
 
-		// Check if we've hit the row limit
-		if options.MaxRows > 0 && rowCount >= options.MaxRows {
+		// Check if we've hit the row limit or the spill quota
+		if collector.Full() {
 			// Check if there are more rows
 			if rows.Next() {
 				truncated = true
@@ -111,26 +210,60 @@ func (a *Adapter) executeSelect(ctx context.Context, query string, params any, o
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return &service.QueryResult{
+	result := &service.QueryResult{
 		Status:    service.JobStatusSuccess,
 		Columns:   queryColumns,
-		Rows:      allRows,
-		RowCount:  rowCount,
 		Truncated: truncated,
-	}, nil
+	}
+	if err := collector.Finish(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// resultColumns describes the columns of rows
+func resultColumns(rows *sqlx.Rows) ([]service.QueryColumn, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	queryColumns := make([]service.QueryColumn, len(columns))
+	for i, name := range columns {
+		colType := "unknown"
+		if i < len(columnTypes) {
+			dbType := columnTypes[i].DatabaseTypeName()
+			if dbType != "" {
+				colType = dbType
+			}
+		}
+		queryColumns[i] = service.QueryColumn{
+			Name: name,
+			Type: colType,
+		}
+	}
+	return queryColumns, nil
 }
 
 // executeStatement executes a non-SELECT statement (INSERT, UPDATE, DELETE, etc.)
-func (a *Adapter) executeStatement(ctx context.Context, query string, params any) (*service.QueryResult, error) {
+func executeStatement(ctx context.Context, db database.Querier, query string, params any) (*service.QueryResult, error) {
 	var result sql.Result
 	var err error
 
 	// Execute the statement with parameters
-	args := toArgs(params)
+	args, err := toArgs(params)
+	if err != nil {
+		return nil, err
+	}
 	if len(args) > 0 {
-		result, err = a.db.ExecContext(ctx, query, args...)
+		result, err = db.ExecContext(ctx, query, args...)
 	} else {
-		result, err = a.db.ExecContext(ctx, query)
+		result, err = db.ExecContext(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("statement execution failed: %w", err)
@@ -146,18 +279,17 @@ func (a *Adapter) executeStatement(ctx context.Context, query string, params any
 
 // toArgs converts params to a slice of arguments for the query
 // PostgreSQL uses $1, $2, etc. for parameters, and pgx handles this natively
-func toArgs(params any) []any {
+func toArgs(params any) ([]any, error) {
 	if params == nil {
-		return nil
+		return nil, nil
 	}
 	switch p := params.(type) {
 	case []any:
-		return p
+		return p, nil
 	case map[string]any:
-		// For named parameters, we'd need to rewrite the query
-		// For now, return empty - queries should use positional params
-		return nil
+		// Named parameters are rewritten to positional ones by the query service
+		return nil, fmt.Errorf("named parameters must be bound to positional arguments before execution")
 	default:
-		return nil
+		return nil, nil
 	}
 }
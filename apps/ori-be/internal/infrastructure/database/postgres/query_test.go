@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestResourceSettingsBecomeRuntimeParams(t *testing.T) {
+	timeout := 1500
+	connString := buildConnectionString("db.local", 5432, "app", "ori", "", nil, &timeout, true)
+	config, err := pgx.ParseConfig(connString)
+	if err != nil {
+		t.Fatalf("ParseConfig(%q): %v", connString, err)
+	}
+	if got := config.RuntimeParams["statement_timeout"]; got != "1500" {
+		t.Fatalf("statement_timeout = %q, want 1500", got)
+	}
+	if got := config.RuntimeParams["default_transaction_read_only"]; got != "on" {
+		t.Fatalf("default_transaction_read_only = %q, want on", got)
+	}
+
+	timedOut := fmt.Errorf("query execution failed: %w", &pgconn.PgError{Code: queryCanceled, Message: "canceling statement due to statement timeout"})
+	if err := timeoutError(timedOut); !errors.Is(err, service.ErrQueryTimeout) {
+		t.Fatalf("timeoutError(statement timeout) = %v", err)
+	}
+	canceled := &pgconn.PgError{Code: queryCanceled, Message: "canceling statement due to user request"}
+	if err := timeoutError(canceled); errors.Is(err, service.ErrQueryTimeout) {
+		t.Fatalf("timeoutError(user cancel) = %v", err)
+	}
+}
+
+func TestDatabaseErrorCarriesServerFields(t *testing.T) {
+	pgErr := &pgconn.PgError{
+		Severity:       "ERROR",
+		Code:           "23505",
+		Message:        "duplicate key value violates unique constraint \"orders_pkey\"",
+		Detail:         "Key (id)=(1) already exists.",
+		SchemaName:     "public",
+		TableName:      "orders",
+		ConstraintName: "orders_pkey",
+	}
+	var dbErr *service.DatabaseError
+	if err := databaseError(fmt.Errorf("statement execution failed: %w", pgErr), 0); !errors.As(err, &dbErr) {
+		t.Fatalf("databaseError = %v, want DatabaseError", err)
+	}
+	if dbErr.Engine != "postgres" || dbErr.Code != "23505" || dbErr.Table != "orders" || dbErr.Constraint != "orders_pkey" || dbErr.Detail != pgErr.Detail {
+		t.Fatalf("database error = %+v", dbErr)
+	}
+	if !errors.Is(dbErr, pgErr) {
+		t.Fatal("database error does not wrap the server error")
+	}
+
+	// EXPLAIN (FORMAT JSON) SELECT nope
+	syntaxErr := &pgconn.PgError{Code: "42703", Message: "column \"nope\" does not exist", Position: 30}
+	if err := databaseError(syntaxErr, len("EXPLAIN (FORMAT JSON) ")); !errors.As(err, &dbErr) || dbErr.Position != 8 {
+		t.Fatalf("position after prefix = %+v, want 8", dbErr)
+	}
+	if err := databaseError(errors.New("connection refused"), 0); errors.As(err, &dbErr) {
+		t.Fatalf("databaseError(non-server error) = %+v", dbErr)
+	}
+}
+
+func TestApplyCommandTagReportsAffectedRows(t *testing.T) {
+	tests := []struct {
+		tag      string
+		affected *int64
+	}{
+		{tag: "INSERT 0 5", affected: int64Ptr(5)},
+		{tag: "UPDATE 3", affected: int64Ptr(3)},
+		{tag: "DELETE 0", affected: int64Ptr(0)},
+		{tag: "MERGE 2", affected: int64Ptr(2)},
+		{tag: "SELECT 7"},
+		{tag: "CREATE TABLE"},
+	}
+	for _, tt := range tests {
+		result := &service.QueryResult{}
+		applyCommandTag(result, pgconn.NewCommandTag(tt.tag))
+		if result.CommandTag != tt.tag {
+			t.Errorf("%s: command tag = %q", tt.tag, result.CommandTag)
+		}
+		if (result.RowsAffected == nil) != (tt.affected == nil) || (tt.affected != nil && *result.RowsAffected != *tt.affected) {
+			t.Errorf("%s: rows affected = %v, want %v", tt.tag, result.RowsAffected, tt.affected)
+		}
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
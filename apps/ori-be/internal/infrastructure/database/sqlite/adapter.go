@@ -2,6 +2,7 @@ package sqlite
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 
 	_ "modernc.org/sqlite"
@@ -37,3 +38,12 @@ func NewAdapter(params service.AdapterFactoryParams) (service.ConnectionAdapter,
 		dbPath:         path,
 	}, nil
 }
+
+// dsn returns the data source name to open. Read-only resources open the file
+// read-only and refuse writes on every connection.
+func (a *Adapter) dsn() string {
+	if a.config == nil || !a.config.ReadOnly {
+		return a.dbPath
+	}
+	return (&url.URL{Scheme: "file", Path: a.dbPath, RawQuery: "mode=ro&_pragma=query_only(1)"}).String()
+}
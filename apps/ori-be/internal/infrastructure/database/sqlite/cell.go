@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+// encodeCell maps a value scanned from the sqlite driver into the typed cell encoding.
+// typeName is the declared column type, which sqlite keeps only as a hint.
+func encodeCell(value any, typeName string) any {
+	switch v := value.(type) {
+	case string:
+		switch typeName {
+		case "JSON", "JSONB":
+			return querycell.JSON(v)
+		case "UUID":
+			return querycell.UUID(v)
+		}
+	case []byte:
+		if typeName == "JSON" {
+			return querycell.JSON(v)
+		}
+	case time.Time:
+		if typeName == "DATE" {
+			return querycell.Date(v)
+		}
+		return querycell.Timestamp(v, true)
+	}
+	return querycell.Encode(value)
+}
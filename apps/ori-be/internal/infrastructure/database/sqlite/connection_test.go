@@ -0,0 +1,47 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestReadOnlyResourceRefusesWritesAtTheDriver(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "orders db.sqlite")
+	seed, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open seed: %v", err)
+	}
+	if _, err := seed.Exec("CREATE TABLE orders (id INTEGER PRIMARY KEY); INSERT INTO orders VALUES (1)"); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	_ = seed.Close()
+
+	adapter, err := NewAdapter(service.AdapterFactoryParams{
+		ConnectionName: "prod",
+		Resource:       &model.Resource{Name: "prod", Type: "sqlite", Database: path, ReadOnly: true},
+	})
+	if err != nil {
+		t.Fatalf("NewAdapter: %v", err)
+	}
+	if err := adapter.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() {
+		_ = adapter.Close()
+	}()
+
+	result, err := adapter.ExecuteQuery(ctx, "SELECT count(*) FROM orders", nil, &service.QueryExecOptions{})
+	if err != nil || result.RowCount != 1 {
+		t.Fatalf("read = %+v, %v", result, err)
+	}
+	if _, err := adapter.ExecuteQuery(ctx, "DELETE FROM orders", nil, &service.QueryExecOptions{}); err == nil || !strings.Contains(err.Error(), "readonly") {
+		t.Fatalf("delete error = %v, want a read-only error", err)
+	}
+}
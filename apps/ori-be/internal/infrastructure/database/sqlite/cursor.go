@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// openCursor runs a row-returning query and keeps its rows open so a lazy result
+// can fetch them page by page.
+func openCursor(ctx context.Context, db database.Querier, query string, params any) (*service.QueryResult, error) {
+	// The rows outlive the job, but canceling the job still interrupts opening them.
+	cursorCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	cursor := &rowsCursor{cancel: cancel}
+	var err error
+	if params != nil {
+		cursor.stmt, err = db.PreparexContext(cursorCtx, query)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("failed to prepare query: %w", err)
+		}
+		cursor.rows, err = queryWithParams(cursorCtx, cursor.stmt, params)
+	} else {
+		cursor.rows, err = db.QueryxContext(cursorCtx, query)
+	}
+	if err != nil {
+		_ = cursor.Close()
+		return nil, fmt.Errorf("query execution failed: %w", err)
+	}
+
+	cursor.columns, err = resultColumns(cursor.rows)
+	if err != nil {
+		_ = cursor.Close()
+		return nil, err
+	}
+	return service.NewCursorResult(cursor.columns, cursor), nil
+}
+
+// rowsCursor holds the open rows of a lazy result and the pooled connection they use.
+type rowsCursor struct {
+	rows    *sqlx.Rows
+	stmt    *sqlx.Stmt
+	columns []service.QueryColumn
+	cancel  context.CancelFunc
+}
+
+func (c *rowsCursor) Next(ctx context.Context, n int) ([][]any, error) {
+	// Canceling a fetch interrupts the statement, which cannot resume afterwards.
+	stop := context.AfterFunc(ctx, c.cancel)
+	defer stop()
+
+	rowData := make([]any, len(c.columns))
+	rowPtrs := make([]any, len(c.columns))
+	for i := range rowData {
+		rowPtrs[i] = &rowData[i]
+	}
+
+	var rows [][]any
+	for len(rows) < n && c.rows.Next() {
+		if err := c.rows.Scan(rowPtrs...); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		row := make([]any, len(rowData))
+		for i, value := range rowData {
+			row[i] = encodeCell(value, c.columns[i].Type)
+		}
+		rows = append(rows, row)
+	}
+	if err := c.rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return rows, nil
+}
+
+func (c *rowsCursor) Close() error {
+	var err error
+	if c.rows != nil {
+		err = c.rows.Close()
+	}
+	if c.stmt != nil {
+		_ = c.stmt.Close()
+	}
+	c.cancel()
+	return err
+}
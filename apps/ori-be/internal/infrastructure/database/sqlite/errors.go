@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sqlitedriver "modernc.org/sqlite"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// constraintCodes names the extended result codes of constraint violations.
+var constraintCodes = map[int]string{
+	275:  "SQLITE_CONSTRAINT_CHECK",
+	531:  "SQLITE_CONSTRAINT_COMMITHOOK",
+	787:  "SQLITE_CONSTRAINT_FOREIGNKEY",
+	1043: "SQLITE_CONSTRAINT_FUNCTION",
+	1299: "SQLITE_CONSTRAINT_NOTNULL",
+	1555: "SQLITE_CONSTRAINT_PRIMARYKEY",
+	1811: "SQLITE_CONSTRAINT_TRIGGER",
+	2067: "SQLITE_CONSTRAINT_UNIQUE",
+	2323: "SQLITE_CONSTRAINT_VTAB",
+	2579: "SQLITE_CONSTRAINT_ROWID",
+	2835: "SQLITE_CONSTRAINT_PINNED",
+	3091: "SQLITE_CONSTRAINT_DATATYPE",
+}
+
+// databaseError wraps a SQLite error with its result code and, for constraint
+// violations, the table, column or constraint named in the message. SQLite reports
+// no error position.
+func databaseError(err error) error {
+	var sqliteErr *sqlitedriver.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+	dbErr := &service.DatabaseError{
+		Engine:   "sqlite",
+		Code:     resultCodeName(sqliteErr.Code()),
+		Severity: "ERROR",
+		Message:  errorMessage(sqliteErr),
+		Err:      err,
+	}
+
+	// "UNIQUE constraint failed: t.a, t.b" names columns; "CHECK constraint failed: name" names the constraint.
+	if kind, target, ok := strings.Cut(dbErr.Message, " constraint failed: "); ok {
+		if kind == "CHECK" {
+			dbErr.Constraint = target
+		} else if table, column, ok := strings.Cut(strings.Split(target, ", ")[0], "."); ok {
+			dbErr.Table = table
+			if !strings.Contains(target, ", ") {
+				dbErr.ColumnName = column
+			}
+		}
+	}
+	return dbErr
+}
+
+// resultCodeName names an extended result code when it is a constraint violation
+// and its primary result code otherwise, such as SQLITE_BUSY.
+func resultCodeName(code int) string {
+	if name, ok := constraintCodes[code]; ok {
+		return name
+	}
+	description := sqlitedriver.ErrorCodeString[code&0xff]
+	if start := strings.LastIndexByte(description, '('); start >= 0 && strings.HasSuffix(description, ")") {
+		return description[start+1 : len(description)-1]
+	}
+	return fmt.Sprintf("SQLITE_%d", code)
+}
+
+// errorMessage strips the result code description and number the driver puts
+// around SQLite's message, as in "SQL logic error: no such table: t (1)".
+func errorMessage(err *sqlitedriver.Error) string {
+	message := strings.TrimSuffix(err.Error(), fmt.Sprintf(" (%d)", err.Code()))
+	if _, detail, ok := strings.Cut(message, ": "); ok {
+		return detail
+	}
+	return message
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestExecuteQueryReportsDatabaseErrors(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := NewAdapter(service.AdapterFactoryParams{
+		ConnectionName: "local",
+		Resource:       &model.Resource{Name: "local", Type: "sqlite", Database: ":memory:"},
+	})
+	if err != nil {
+		t.Fatalf("NewAdapter: %v", err)
+	}
+	if err := adapter.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() {
+		_ = adapter.Close()
+	}()
+	conn, err := adapter.PinConnection(ctx)
+	if err != nil {
+		t.Fatalf("PinConnection: %v", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	setup := "CREATE TABLE orders (id INTEGER PRIMARY KEY, note TEXT NOT NULL, total INT CONSTRAINT positive CHECK (total > 0))"
+	if _, err := conn.ExecuteQuery(ctx, setup, nil, nil); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	if _, err := conn.ExecuteQuery(ctx, "INSERT INTO orders VALUES (1, 'a', 1)", nil, nil); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  service.DatabaseError
+	}{
+		{
+			query: "SELECT * FROM missing",
+			want:  service.DatabaseError{Code: "SQLITE_ERROR", Message: "no such table: missing"},
+		},
+		{
+			query: "INSERT INTO orders VALUES (1, 'b', 1)",
+			want:  service.DatabaseError{Code: "SQLITE_CONSTRAINT_PRIMARYKEY", Message: "UNIQUE constraint failed: orders.id", Table: "orders", ColumnName: "id"},
+		},
+		{
+			query: "INSERT INTO orders VALUES (2, NULL, 1)",
+			want:  service.DatabaseError{Code: "SQLITE_CONSTRAINT_NOTNULL", Message: "NOT NULL constraint failed: orders.note", Table: "orders", ColumnName: "note"},
+		},
+		{
+			query: "INSERT INTO orders VALUES (3, 'c', 0)",
+			want:  service.DatabaseError{Code: "SQLITE_CONSTRAINT_CHECK", Message: "CHECK constraint failed: positive", Constraint: "positive"},
+		},
+	}
+	for _, tt := range tests {
+		_, err := conn.ExecuteQuery(ctx, tt.query, nil, nil)
+		var dbErr *service.DatabaseError
+		if !errors.As(err, &dbErr) {
+			t.Fatalf("%s: error = %v, want DatabaseError", tt.query, err)
+		}
+		got := *dbErr
+		got.Err = nil
+		tt.want.Engine, tt.want.Severity = "sqlite", "ERROR"
+		if got != tt.want {
+			t.Errorf("%s: database error = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
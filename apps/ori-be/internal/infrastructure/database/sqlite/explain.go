@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// ExplainQuery runs EXPLAIN QUERY PLAN and rebuilds its tree. SQLite reports no
+// row estimates, costs or timings, and has no EXPLAIN ANALYZE.
+func (a *Adapter) ExplainQuery(ctx context.Context, query string, params any, options *service.QueryExplainOptions) (*service.QueryPlan, error) {
+	if a.db == nil {
+		return nil, fmt.Errorf("database not connected")
+	}
+	if options.Analyze {
+		return nil, fmt.Errorf("%w: sqlite has no EXPLAIN ANALYZE", service.ErrExplainUnsupported)
+	}
+	args, _ := params.([]any)
+
+	rows, err := a.db.QueryxContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return nil, databaseError(fmt.Errorf("explain failed: %w", err))
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	plan := &service.QueryPlan{}
+	nodes := make(map[int64]*service.QueryPlanNode)
+	for rows.Next() {
+		var id, parent, notUsed int64
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, fmt.Errorf("failed to scan plan row: %w", err)
+		}
+		node := planNode(detail)
+		nodes[id] = node
+		// Rows arrive parent first; unknown parents (0 for top-level rows) make a root.
+		if parentNode, ok := nodes[parent]; ok {
+			parentNode.Children = append(parentNode.Children, node)
+		} else {
+			plan.Nodes = append(plan.Nodes, node)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("row iteration error: %w", err)
+	}
+	return plan, nil
+}
+
+// planNode derives the node type and relation from an EXPLAIN QUERY PLAN detail
+// such as "SEARCH users USING INDEX users_email (email=?)".
+func planNode(detail string) *service.QueryPlanNode {
+	node := &service.QueryPlanNode{NodeType: detail, Detail: detail}
+	fields := strings.Fields(detail)
+	if len(fields) < 2 || (fields[0] != "SCAN" && fields[0] != "SEARCH") {
+		return node
+	}
+
+	node.NodeType = fields[0]
+	relation := fields[1]
+	// Older SQLite versions print "SCAN TABLE t".
+	if relation == "TABLE" && len(fields) > 2 {
+		relation = fields[2]
+	}
+	if relation != "CONSTANT" && relation != "SUBQUERY" {
+		node.Relation = relation
+	}
+	return node
+}
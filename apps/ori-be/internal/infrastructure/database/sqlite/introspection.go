@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
 	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
 
 func (a *Adapter) GetScopes(ctx context.Context) ([]model.Scope, error) {
@@ -110,8 +112,9 @@ func (a *Adapter) GetRelations(ctx context.Context, scope model.Scope) ([]model.
 
 func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation string) ([]model.Column, error) {
 	database := scope.DatabaseName()
+	// table_xinfo also lists generated columns, which table_info hides.
 	query := fmt.Sprintf(
-		`PRAGMA "%s".table_info(%s)`,
+		`PRAGMA "%s".table_xinfo(%s)`,
 		stringutil.EscapeIdentifier(database),
 		stringutil.QuoteLiteral(relation),
 	)
@@ -124,13 +127,14 @@ func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation st
 	}()
 
 	var columns []model.Column
+	hasGenerated := false
 	for rows.Next() {
 		var cid int
 		var name, dataType string
-		var notNull, pk int
+		var notNull, pk, hidden int
 		var defaultValue sql.NullString
 
-		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk); err != nil {
+		if err := rows.Scan(&cid, &name, &dataType, &notNull, &defaultValue, &pk, &hidden); err != nil {
 			return nil, err
 		}
 
@@ -144,9 +148,38 @@ func (a *Adapter) GetColumns(ctx context.Context, scope model.Scope, relation st
 		if defaultValue.Valid {
 			col.DefaultValue = &defaultValue.String
 		}
+		// hidden is 1 for hidden columns of virtual tables, 2 for VIRTUAL and 3 for STORED generated columns.
+		switch hidden {
+		case 1:
+			continue
+		case 2, 3:
+			generated := "VIRTUAL"
+			if hidden == 3 {
+				generated = "STORED"
+			}
+			col.Generated = &generated
+			hasGenerated = true
+		}
 		columns = append(columns, col)
 	}
-	return columns, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if !hasGenerated {
+		return columns, nil
+	}
+
+	definition, err := a.getTableDefinition(ctx, database, relation)
+	if err != nil {
+		return nil, err
+	}
+	generated := sqlutil.GeneratedColumns(definition, sqlutil.DialectSQLite)
+	for i := range columns {
+		if column, ok := generated[columns[i].Name]; ok && columns[i].Generated != nil {
+			columns[i].GenerationExpression = &column.Expression
+		}
+	}
+	return columns, nil
 }
 
 func (a *Adapter) GetConstraints(ctx context.Context, scope model.Scope, relation string) ([]model.Constraint, error) {
@@ -271,6 +304,31 @@ func (a *Adapter) GetTriggers(ctx context.Context, scope model.Scope, relation s
 	return triggers, rows.Err()
 }
 
+// GetRoutines returns no routines; SQLite functions are registered by the application, not stored.
+func (a *Adapter) GetRoutines(context.Context, model.Scope) ([]model.Routine, error) {
+	return []model.Routine{}, nil
+}
+
+// GetSequences returns no sequences; SQLite has none.
+func (a *Adapter) GetSequences(context.Context, model.Scope) ([]model.Sequence, error) {
+	return []model.Sequence{}, nil
+}
+
+// GetTypes returns no types; SQLite has no user-defined types.
+func (a *Adapter) GetTypes(context.Context, model.Scope) ([]model.UserType, error) {
+	return []model.UserType{}, nil
+}
+
+// GetExtensions returns no extensions; SQLite does not list the ones a connection loaded.
+func (a *Adapter) GetExtensions(context.Context, model.Scope) ([]model.Extension, error) {
+	return []model.Extension{}, nil
+}
+
+// GetRolePrivileges is unsupported; SQLite has no roles.
+func (a *Adapter) GetRolePrivileges(context.Context, string, string) (*model.RolePrivileges, error) {
+	return nil, fmt.Errorf("%w: sqlite has no roles", service.ErrPrivilegesUnsupported)
+}
+
 func (a *Adapter) getPrimaryKeyConstraint(ctx context.Context, database, table string) (*model.Constraint, error) {
 	query := fmt.Sprintf(
 		`PRAGMA "%s".table_info(%s)`,
@@ -423,6 +481,19 @@ func (a *Adapter) getIndexColumns(ctx context.Context, database, indexName strin
 	return cols, nil
 }
 
+func (a *Adapter) getTableDefinition(ctx context.Context, database, table string) (string, error) {
+	query := fmt.Sprintf(
+		`SELECT COALESCE(sql, '') FROM "%s".sqlite_master WHERE type = 'table' AND name = %s`,
+		stringutil.EscapeIdentifier(database),
+		stringutil.QuoteLiteral(table),
+	)
+	var definition string
+	if err := a.db.GetContext(ctx, &definition, query); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+	return definition, nil
+}
+
 func (a *Adapter) getIndexDefinition(ctx context.Context, database, indexName string) (string, error) {
 	query := fmt.Sprintf(
 		`SELECT sql FROM "%s".sqlite_master WHERE type = 'index' AND name = %s`,
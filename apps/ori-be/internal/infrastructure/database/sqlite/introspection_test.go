@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestGetColumnsReportsGeneratedColumns(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := NewAdapter(service.AdapterFactoryParams{
+		ConnectionName: "local",
+		Resource:       &model.Resource{Name: "local", Type: "sqlite", Database: ":memory:"},
+	})
+	if err != nil {
+		t.Fatalf("NewAdapter: %v", err)
+	}
+	if err := adapter.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() {
+		_ = adapter.Close()
+	}()
+	setup := "CREATE TABLE items (price REAL, qty INT, total REAL GENERATED ALWAYS AS (price * qty) STORED, label TEXT AS (upper(qty)))"
+	if _, err := adapter.ExecuteQuery(ctx, setup, nil, nil); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	scopes, err := adapter.GetScopes(ctx)
+	if err != nil || len(scopes) == 0 {
+		t.Fatalf("GetScopes = %v, %v", scopes, err)
+	}
+	columns, err := adapter.GetColumns(ctx, scopes[0], "items")
+	if err != nil {
+		t.Fatalf("GetColumns: %v", err)
+	}
+	if len(columns) != 4 {
+		t.Fatalf("columns = %+v, want 4", columns)
+	}
+	if columns[1].Generated != nil {
+		t.Fatalf("qty reported as generated: %+v", columns[1])
+	}
+	want := map[string][2]string{
+		"total": {"STORED", "price * qty"},
+		"label": {"VIRTUAL", "upper(qty)"},
+	}
+	for _, column := range columns[2:] {
+		if column.Generated == nil || column.GenerationExpression == nil {
+			t.Fatalf("%s not reported as generated: %+v", column.Name, column)
+		}
+		if got := [2]string{*column.Generated, *column.GenerationExpression}; got != want[column.Name] {
+			t.Errorf("%s generated = %v, want %v", column.Name, got, want[column.Name])
+		}
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 
-	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database"
 	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
 	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 	"github.com/jmoiron/sqlx"
@@ -16,13 +16,21 @@ func (a *Adapter) ExecuteQuery(ctx context.Context, query string, params any, op
 	if a.db == nil {
 		return nil, fmt.Errorf("database not connected")
 	}
+	result, err := executeQuery(ctx, a.db, query, params, options)
+	return result, databaseError(err)
+}
+
+func executeQuery(ctx context.Context, db database.Querier, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+	if options != nil && options.Lazy && sqlutil.IsRowReturningQuery(query, sqlutil.DialectSQLite) {
+		return openCursor(ctx, db, query, params)
+	}
 
 	// Prepare the query if we have parameters
 	var stmt *sqlx.Stmt
 	var err error
 
 	if params != nil {
-		stmt, err = a.db.PreparexContext(ctx, query)
+		stmt, err = db.PreparexContext(ctx, query)
 		if err != nil {
 			return nil, fmt.Errorf("failed to prepare query: %w", err)
 		}
@@ -32,14 +40,14 @@ func (a *Adapter) ExecuteQuery(ctx context.Context, query string, params any, op
 	}
 
 	// Check if the query returns rows or is a statement
-	if sqlutil.IsRowReturningQuery(query) {
-		return a.executeSelect(ctx, stmt, query, params, options)
+	if sqlutil.IsRowReturningQuery(query, sqlutil.DialectSQLite) {
+		return executeSelect(ctx, db, stmt, query, params, options)
 	}
-	return a.executeStatement(ctx, stmt, query, params)
+	return executeStatement(ctx, db, stmt, query, params)
 }
 
 // executeSelect executes a SELECT query
-func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
+func executeSelect(ctx context.Context, db database.Querier, stmt *sqlx.Stmt, query string, params any, options *service.QueryExecOptions) (*service.QueryResult, error) {
 	var rows *sqlx.Rows
 	var err error
 
@@ -47,7 +55,7 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 	if stmt != nil {
 		rows, err = queryWithParams(ctx, stmt, params)
 	} else {
-		rows, err = a.db.QueryxContext(ctx, query)
+		rows, err = db.QueryxContext(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
@@ -57,43 +65,26 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 	}()
 
 	// Get column information
-	columns, err := rows.Columns()
+	queryColumns, err := resultColumns(rows)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get columns: %w", err)
-	}
-
-	// Get column types
-	columnTypes, err := rows.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get column types: %w", err)
-	}
-
-	// Build column metadata
-	queryColumns := make([]service.QueryColumn, len(columns))
-	for i, name := range columns {
-		colType := "unknown"
-		if i < len(columnTypes) {
-			dbType := columnTypes[i].DatabaseTypeName()
-			if dbType != "" {
-				colType = dbType
-			}
-		}
-		queryColumns[i] = service.QueryColumn{
-			Name: name,
-			Type: colType,
-		}
+		return nil, err
 	}
 
 	// Prepare slice to hold row data
-	rowData := make([]any, len(columns))
-	rowPtrs := make([]any, len(columns))
+	rowData := make([]any, len(queryColumns))
+	rowPtrs := make([]any, len(queryColumns))
 	for i := range rowData {
 		rowPtrs[i] = &rowData[i]
 	}
 
-	var allRows [][]any
-	rowCount := 0
+	collector := service.NewRowCollector(options)
+	defer collector.Discard()
+	collector.SetColumns(queryColumns)
 	truncated := false
+	// A data-modifying statement with RETURNING affected exactly the rows it returned.
+	kind := sqlutil.Classify(query, sqlutil.DialectSQLite).Kind
+	modifies := sqlutil.CommandTag(kind, 0) != ""
+	skipped := 0
 
 	// Collect rows up to the limit
 	for rows.Next() {
@@ -101,20 +92,25 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 			return nil, fmt.Errorf("failed to scan row: %w", err)
 		}
 
-		// Create a copy of the row data
+		// Encode the row into typed cells
 		rowCopy := make([]any, len(rowData))
 		for i, value := range rowData {
-			rowCopy[i] = querycell.Stringify(value)
+			rowCopy[i] = encodeCell(value, queryColumns[i].Type)
 		}
 
-		allRows = append(allRows, rowCopy)
-		rowCount++
+		if err := collector.Append(rowCopy); err != nil {
+			return nil, err
+		}
 
-		// Check if we've hit the row limit
-		if options.MaxRows > 0 && rowCount >= options.MaxRows {
-			// Check if there are more rows
-			if rows.Next() {
+		// Check if we've hit the row limit or the spill quota
+		if collector.Full() {
+			// Check if there are more rows; a RETURNING statement counts all it returned as affected
+			for rows.Next() {
 				truncated = true
+				skipped++
+				if !modifies {
+					break
+				}
 			}
 			break
 		}
@@ -124,17 +120,53 @@ func (a *Adapter) executeSelect(ctx context.Context, stmt *sqlx.Stmt, query stri
 		return nil, fmt.Errorf("row iteration error: %w", err)
 	}
 
-	return &service.QueryResult{
+	result := &service.QueryResult{
 		Status:    service.JobStatusSuccess,
 		Columns:   queryColumns,
-		Rows:      allRows,
-		RowCount:  rowCount,
 		Truncated: truncated,
-	}, nil
+	}
+	if err := collector.Finish(result); err != nil {
+		return nil, err
+	}
+	if modifies {
+		affected := int64(result.RowCount + skipped)
+		result.RowsAffected = &affected
+		result.CommandTag = sqlutil.CommandTag(kind, affected)
+	}
+	return result, nil
+}
+
+// resultColumns describes the columns of rows
+func resultColumns(rows *sqlx.Rows) ([]service.QueryColumn, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column types: %w", err)
+	}
+
+	queryColumns := make([]service.QueryColumn, len(columns))
+	for i, name := range columns {
+		colType := "unknown"
+		if i < len(columnTypes) {
+			dbType := columnTypes[i].DatabaseTypeName()
+			if dbType != "" {
+				colType = dbType
+			}
+		}
+		queryColumns[i] = service.QueryColumn{
+			Name: name,
+			Type: colType,
+		}
+	}
+	return queryColumns, nil
 }
 
 // executeStatement executes a non-SELECT statement (INSERT, UPDATE, DELETE, etc.)
-func (a *Adapter) executeStatement(ctx context.Context, stmt *sqlx.Stmt, query string, params any) (*service.QueryResult, error) {
+func executeStatement(ctx context.Context, db database.Querier, stmt *sqlx.Stmt, query string, params any) (*service.QueryResult, error) {
 	var result sql.Result
 	var err error
 
@@ -142,7 +174,7 @@ func (a *Adapter) executeStatement(ctx context.Context, stmt *sqlx.Stmt, query s
 	if stmt != nil {
 		result, err = execWithParams(ctx, stmt, params)
 	} else {
-		result, err = a.db.ExecContext(ctx, query)
+		result, err = db.ExecContext(ctx, query)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("statement execution failed: %w", err)
@@ -153,6 +185,7 @@ func (a *Adapter) executeStatement(ctx context.Context, stmt *sqlx.Stmt, query s
 	return &service.QueryResult{
 		Status:       service.JobStatusSuccess,
 		RowsAffected: &ra,
+		CommandTag:   sqlutil.CommandTag(sqlutil.Classify(query, sqlutil.DialectSQLite).Kind, ra),
 	}, nil
 }
 
@@ -160,8 +193,8 @@ func (a *Adapter) executeStatement(ctx context.Context, stmt *sqlx.Stmt, query s
 func queryWithParams(ctx context.Context, stmt *sqlx.Stmt, params any) (*sqlx.Rows, error) {
 	switch p := params.(type) {
 	case map[string]any:
-		// Named parameters - not supported yet for prepared statements
-		return nil, fmt.Errorf("named parameters not yet supported in prepared statements")
+		// Named parameters are rewritten to positional ones by the query service
+		return nil, fmt.Errorf("named parameters must be bound to positional arguments before execution")
 	case []any:
 		// Positional parameters
 		return stmt.QueryxContext(ctx, p...)
@@ -175,8 +208,8 @@ func queryWithParams(ctx context.Context, stmt *sqlx.Stmt, params any) (*sqlx.Ro
 func execWithParams(ctx context.Context, stmt *sqlx.Stmt, params any) (sql.Result, error) {
 	switch p := params.(type) {
 	case map[string]any:
-		// Named parameters - not supported yet
-		return nil, fmt.Errorf("named parameters not yet supported in prepared statements")
+		// Named parameters are rewritten to positional ones by the query service
+		return nil, fmt.Errorf("named parameters must be bound to positional arguments before execution")
 	case []any:
 		// Positional parameters
 		return stmt.ExecContext(ctx, p...)
@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestExecuteQueryReportsRowsAffectedWithReturning(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := NewAdapter(service.AdapterFactoryParams{
+		ConnectionName: "local",
+		Resource:       &model.Resource{Name: "local", Type: "sqlite", Database: ":memory:"},
+	})
+	if err != nil {
+		t.Fatalf("NewAdapter: %v", err)
+	}
+	if err := adapter.Connect(ctx); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() {
+		_ = adapter.Close()
+	}()
+	conn, err := adapter.PinConnection(ctx)
+	if err != nil {
+		t.Fatalf("PinConnection: %v", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if _, err := conn.ExecuteQuery(ctx, "CREATE TABLE orders (id INTEGER PRIMARY KEY, total INT)", nil, nil); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	result, err := conn.ExecuteQuery(ctx, "INSERT INTO orders (total) VALUES (10), (20), (30) RETURNING id", nil, &service.QueryExecOptions{MaxRows: 2})
+	if err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if result.RowCount != 2 || !result.Truncated {
+		t.Fatalf("returned rows = %d (truncated %v), want 2 truncated", result.RowCount, result.Truncated)
+	}
+	if result.RowsAffected == nil || *result.RowsAffected != 3 || result.CommandTag != "INSERT 0 3" {
+		t.Fatalf("rows affected = %v, command tag = %q, want 3 and INSERT 0 3", result.RowsAffected, result.CommandTag)
+	}
+
+	result, err = conn.ExecuteQuery(ctx, "UPDATE orders SET total = total + 1 WHERE total > 10", nil, nil)
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if result.RowsAffected == nil || *result.RowsAffected != 2 || result.CommandTag != "UPDATE 2" {
+		t.Fatalf("rows affected = %v, command tag = %q, want 2 and UPDATE 2", result.RowsAffected, result.CommandTag)
+	}
+
+	result, err = conn.ExecuteQuery(ctx, "SELECT id FROM orders", nil, nil)
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if result.RowsAffected != nil || result.CommandTag != "" {
+		t.Fatalf("select rows affected = %v, command tag = %q, want none", result.RowsAffected, result.CommandTag)
+	}
+}
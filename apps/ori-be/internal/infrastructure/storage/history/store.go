@@ -0,0 +1,293 @@
+// Package history persists finished query jobs in a SQLite database in the ori state directory.
+package history
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// schemaVersion is stored in PRAGMA user_version; bump it with a new entry in migrations.
+const schemaVersion = 2
+
+// DefaultMaxEntries is how many entries the history keeps; recording more drops the oldest.
+const DefaultMaxEntries = 10000
+
+// minTrigramTerm is the shortest term the trigram index can match; shorter terms fall back to a scan.
+const minTrigramTerm = 3
+
+var migrations = []string{
+	`CREATE TABLE query_history (
+		id INTEGER PRIMARY KEY,
+		job_id TEXT NOT NULL UNIQUE,
+		resource_name TEXT NOT NULL,
+		query TEXT NOT NULL,
+		params TEXT,
+		status TEXT NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		row_count INTEGER,
+		rows_affected INTEGER,
+		duration_ms INTEGER NOT NULL,
+		session_id TEXT NOT NULL DEFAULT '',
+		script INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL,
+		started_at INTEGER,
+		finished_at INTEGER NOT NULL
+	);
+	CREATE INDEX query_history_finished_at ON query_history (finished_at);
+	CREATE INDEX query_history_resource ON query_history (resource_name, finished_at);
+	CREATE VIRTUAL TABLE query_history_fts USING fts5(
+		query, error, content = 'query_history', content_rowid = 'id', tokenize = 'trigram'
+	);
+	CREATE TRIGGER query_history_ai AFTER INSERT ON query_history BEGIN
+		INSERT INTO query_history_fts (rowid, query, error) VALUES (new.id, new.query, new.error);
+	END;`,
+	`CREATE TRIGGER query_history_ad AFTER DELETE ON query_history BEGIN
+		INSERT INTO query_history_fts (query_history_fts, rowid, query, error) VALUES ('delete', old.id, old.query, old.error);
+	END;`,
+}
+
+// Store is a service.QueryHistoryStore backed by SQLite.
+type Store struct {
+	db         *sql.DB
+	maxEntries int
+}
+
+var _ service.QueryHistoryStore = (*Store)(nil)
+
+// Open opens or creates the history database at path and migrates it to the current schema.
+// The history holds query text and parameters, so only the owner may read it.
+func Open(ctx context.Context, path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	// SQLite gives the WAL and shared-memory files the mode of the database file.
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create history database: %w", err)
+	}
+	_ = file.Close()
+	if err := os.Chmod(path, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to restrict history database permissions: %w", err)
+	}
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database: %w", err)
+	}
+	// A single connection serializes writers, which SQLite would do anyway.
+	db.SetMaxOpenConns(1)
+
+	store := &Store{db: db, maxEntries: DefaultMaxEntries}
+	if err := store.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) migrate(ctx context.Context) error {
+	var version int
+	if err := s.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read history schema version: %w", err)
+	}
+	if version > schemaVersion {
+		return fmt.Errorf("history schema version %d is newer than supported version %d", version, schemaVersion)
+	}
+	for ; version < schemaVersion; version++ {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to migrate history schema: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, migrations[version]); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate history schema to version %d: %w", version+1, err)
+		}
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", version+1)); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to migrate history schema to version %d: %w", version+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to migrate history schema to version %d: %w", version+1, err)
+		}
+	}
+	return nil
+}
+
+// Record inserts a finished job and drops the oldest entries beyond the entry cap.
+// Recording the same job twice keeps the first entry.
+func (s *Store) Record(ctx context.Context, entry *service.QueryHistoryEntry) error {
+	var params any
+	if entry.Params != nil {
+		encoded, err := json.Marshal(entry.Params)
+		if err != nil {
+			return fmt.Errorf("failed to encode query params: %w", err)
+		}
+		params = string(encoded)
+	}
+	var startedAt any
+	if entry.StartedAt != nil {
+		startedAt = entry.StartedAt.UnixMilli()
+	}
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO query_history (
+		job_id, resource_name, query, params, status, error, row_count, rows_affected,
+		duration_ms, session_id, script, created_at, started_at, finished_at
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT (job_id) DO NOTHING`,
+		entry.JobID, entry.ResourceName, entry.Query, params, string(entry.Status), entry.Error,
+		entry.RowCount, entry.RowsAffected, entry.DurationMs, entry.SessionID, entry.Script,
+		entry.CreatedAt.UnixMilli(), startedAt, entry.FinishedAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("failed to record query history: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `DELETE FROM query_history WHERE id <= (
+		SELECT id FROM query_history ORDER BY id DESC LIMIT 1 OFFSET ?
+	)`, s.maxEntries)
+	if err != nil {
+		return fmt.Errorf("failed to prune query history: %w", err)
+	}
+	return nil
+}
+
+// Search returns the newest entries matching the filter.
+func (s *Store) Search(ctx context.Context, filter *service.QueryHistoryFilter) (*service.QueryHistoryPage, error) {
+	where, args := searchConditions(filter)
+
+	page := &service.QueryHistoryPage{Entries: []*service.QueryHistoryEntry{}}
+	countQuery := "SELECT COUNT(*) FROM query_history" + where
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&page.Total); err != nil {
+		return nil, fmt.Errorf("failed to count query history: %w", err)
+	}
+
+	listQuery := `SELECT job_id, resource_name, query, params, status, error, row_count, rows_affected,
+		duration_ms, session_id, script, created_at, started_at, finished_at
+	FROM query_history` + where + ` ORDER BY finished_at DESC, id DESC LIMIT ? OFFSET ?`
+	rows, err := s.db.QueryContext(ctx, listQuery, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search query history: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		page.Entries = append(page.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search query history: %w", err)
+	}
+	return page, nil
+}
+
+func searchConditions(filter *service.QueryHistoryFilter) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+		indexed    []string
+	)
+	for _, term := range strings.Fields(filter.Search) {
+		if utf8.RuneCountInString(term) >= minTrigramTerm {
+			indexed = append(indexed, `"`+strings.ReplaceAll(term, `"`, `""`)+`"`)
+			continue
+		}
+		pattern := "%" + escapeLike(term) + "%"
+		conditions = append(conditions, `(query LIKE ? ESCAPE '\' OR error LIKE ? ESCAPE '\')`)
+		args = append(args, pattern, pattern)
+	}
+	if len(indexed) > 0 {
+		conditions = append(conditions, "id IN (SELECT rowid FROM query_history_fts WHERE query_history_fts MATCH ?)")
+		args = append(args, strings.Join(indexed, " AND "))
+	}
+	if filter.ResourceName != "" {
+		conditions = append(conditions, "resource_name = ?")
+		args = append(args, filter.ResourceName)
+	}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			placeholders[i] = "?"
+			args = append(args, string(status))
+		}
+		conditions = append(conditions, "status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "finished_at >= ?")
+		args = append(args, filter.Since.UnixMilli())
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "finished_at < ?")
+		args = append(args, filter.Until.UnixMilli())
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func escapeLike(term string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(term)
+}
+
+func scanEntry(rows *sql.Rows) (*service.QueryHistoryEntry, error) {
+	var (
+		entry        service.QueryHistoryEntry
+		status       string
+		params       sql.NullString
+		rowCount     sql.NullInt64
+		rowsAffected sql.NullInt64
+		createdAt    int64
+		startedAt    sql.NullInt64
+		finishedAt   int64
+	)
+	err := rows.Scan(&entry.JobID, &entry.ResourceName, &entry.Query, &params, &status, &entry.Error,
+		&rowCount, &rowsAffected, &entry.DurationMs, &entry.SessionID, &entry.Script,
+		&createdAt, &startedAt, &finishedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read query history: %w", err)
+	}
+
+	entry.Status = service.JobStatus(status)
+	entry.CreatedAt = time.UnixMilli(createdAt)
+	entry.FinishedAt = time.UnixMilli(finishedAt)
+	if startedAt.Valid {
+		started := time.UnixMilli(startedAt.Int64)
+		entry.StartedAt = &started
+	}
+	if rowCount.Valid {
+		count := int(rowCount.Int64)
+		entry.RowCount = &count
+	}
+	if rowsAffected.Valid {
+		affected := rowsAffected.Int64
+		entry.RowsAffected = &affected
+	}
+	if params.Valid {
+		decoder := json.NewDecoder(strings.NewReader(params.String))
+		decoder.UseNumber()
+		if err := decoder.Decode(&entry.Params); err != nil {
+			return nil, fmt.Errorf("failed to decode query params: %w", err)
+		}
+	}
+	return &entry, nil
+}
@@ -0,0 +1,150 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+func TestStoreSearchesAcrossReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state", "history.db")
+	store, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	base := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	rowCount := 3
+	entries := []*service.QueryHistoryEntry{
+		{JobID: "1", ResourceName: "prod", Query: "SELECT * FROM order_items WHERE id = :id", Params: map[string]any{"id": 7}, Status: service.JobStatusSuccess, RowCount: &rowCount},
+		{JobID: "2", ResourceName: "prod", Query: "DELETE FROM Orders", Status: service.JobStatusFailed, Error: "permission denied for table orders"},
+		{JobID: "3", ResourceName: "dev", Query: "select 1 as x", Status: service.JobStatusSuccess, RowCount: &rowCount},
+		{JobID: "4", ResourceName: "prod", Query: "SELECT count(*) FROM orders", Status: service.JobStatusCanceled},
+	}
+	for i, entry := range entries {
+		entry.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		entry.FinishedAt = entry.CreatedAt.Add(time.Second)
+		if err := store.Record(ctx, entry); err != nil {
+			t.Fatalf("Record(%s): %v", entry.JobID, err)
+		}
+	}
+	if err := store.Record(ctx, entries[0]); err != nil {
+		t.Fatalf("Record duplicate: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	store, err = Open(ctx, path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	since := base.Add(90 * time.Minute)
+	tests := []struct {
+		name   string
+		filter service.QueryHistoryFilter
+		want   string
+		total  int
+	}{
+		{name: "all newest first", filter: service.QueryHistoryFilter{Limit: 10}, want: "[4 3 2 1]", total: 4},
+		{name: "terms ignore case", filter: service.QueryHistoryFilter{Search: "ORDERS select", Limit: 10}, want: "[4]", total: 1},
+		{name: "error text", filter: service.QueryHistoryFilter{Search: "permission", Limit: 10}, want: "[2]", total: 1},
+		{name: "short term", filter: service.QueryHistoryFilter{Search: "x", Limit: 10}, want: "[3]", total: 1},
+		{name: "substring", filter: service.QueryHistoryFilter{Search: "der_it", Limit: 10}, want: "[1]", total: 1},
+		{name: "resource and status", filter: service.QueryHistoryFilter{ResourceName: "prod", Statuses: []service.JobStatus{service.JobStatusSuccess, service.JobStatusFailed}, Limit: 10}, want: "[2 1]", total: 2},
+		{name: "since", filter: service.QueryHistoryFilter{Since: &since, Limit: 10}, want: "[4 3]", total: 2},
+		{name: "paging", filter: service.QueryHistoryFilter{Limit: 2, Offset: 1}, want: "[3 2]", total: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := store.Search(ctx, &tt.filter)
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+			ids := make([]string, len(page.Entries))
+			for i, entry := range page.Entries {
+				ids[i] = entry.JobID
+			}
+			if got := fmt.Sprint(ids); got != tt.want {
+				t.Fatalf("ids = %s, want %s", got, tt.want)
+			}
+			if page.Total != tt.total {
+				t.Fatalf("total = %d, want %d", page.Total, tt.total)
+			}
+		})
+	}
+
+	page, err := store.Search(ctx, &service.QueryHistoryFilter{Search: "order_items", Limit: 1})
+	if err != nil || len(page.Entries) != 1 {
+		t.Fatalf("Search order_items = %+v, %v", page, err)
+	}
+	entry := page.Entries[0]
+	if fmt.Sprint(entry.Params) != "map[id:7]" || entry.RowCount == nil || *entry.RowCount != 3 || entry.StartedAt != nil {
+		t.Fatalf("entry = %+v", entry)
+	}
+	if !entry.FinishedAt.Equal(base.Add(time.Second)) {
+		t.Fatalf("finishedAt = %v", entry.FinishedAt)
+	}
+}
+
+func TestStoreKeepsNewestEntriesPrivately(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "state")
+	path := filepath.Join(dir, "history.db")
+	store, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	store.maxEntries = 2
+
+	for _, mode := range []struct {
+		path string
+		want os.FileMode
+	}{{path: dir, want: 0o700}, {path: path, want: 0o600}} {
+		info, err := os.Stat(mode.path)
+		if err != nil {
+			t.Fatalf("Stat(%s): %v", mode.path, err)
+		}
+		if got := info.Mode().Perm(); got != mode.want {
+			t.Fatalf("%s mode = %v, want %v", mode.path, got, mode.want)
+		}
+	}
+
+	base := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	for i, query := range []string{"SELECT secret_one", "SELECT secret_two", "SELECT secret_three"} {
+		entry := &service.QueryHistoryEntry{JobID: fmt.Sprint(i + 1), ResourceName: "prod", Query: query, Status: service.JobStatusSuccess}
+		entry.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		entry.FinishedAt = entry.CreatedAt
+		if err := store.Record(ctx, entry); err != nil {
+			t.Fatalf("Record(%s): %v", entry.JobID, err)
+		}
+	}
+
+	page, err := store.Search(ctx, &service.QueryHistoryFilter{Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 2 || page.Entries[0].JobID != "3" || page.Entries[1].JobID != "2" {
+		t.Fatalf("entries after cap = %+v, total %d", page.Entries, page.Total)
+	}
+	page, err = store.Search(ctx, &service.QueryHistoryFilter{Search: "secret_one", Limit: 10})
+	if err != nil {
+		t.Fatalf("Search pruned: %v", err)
+	}
+	if page.Total != 0 {
+		t.Fatalf("pruned entry still searchable: %+v", page.Entries)
+	}
+}
@@ -62,6 +62,17 @@ func (cl *ResourceLoader) validate(config *model.Config) error {
 		if conn.AutoLimitRows != nil && *conn.AutoLimitRows <= 0 {
 			return fmt.Errorf("resource '%s': autoLimitRows must be positive or null", conn.Name)
 		}
+		if conn.QueryTimeout != nil && *conn.QueryTimeout <= 0 {
+			return fmt.Errorf("resource '%s': queryTimeout must be positive", conn.Name)
+		}
+		if conn.MaxConcurrentQueries != nil && *conn.MaxConcurrentQueries <= 0 {
+			return fmt.Errorf("resource '%s': maxConcurrentQueries must be positive", conn.Name)
+		}
+		switch conn.DestructiveGuard {
+		case "", model.DestructiveGuardOff, model.DestructiveGuardWarn, model.DestructiveGuardConfirm:
+		default:
+			return fmt.Errorf("resource '%s': destructiveGuard must be off, warn or confirm", conn.Name)
+		}
 		// Driver-specific validation
 		switch conn.Type {
 		case "sqlite", "duckdb":
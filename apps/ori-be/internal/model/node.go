@@ -17,13 +17,20 @@ type Node interface {
 }
 
 const (
-	NodeRelationTables      = "tables"
-	NodeRelationViews       = "views"
-	NodeRelationPartitions  = "partitions"
-	NodeRelationColumns     = "columns"
-	NodeRelationConstraints = "constraints"
-	NodeRelationIndexes     = "indexes"
-	NodeRelationTriggers    = "triggers"
+	NodeRelationTables        = "tables"
+	NodeRelationViews         = "views"
+	NodeRelationMatviews      = "materialized_views"
+	NodeRelationForeignTables = "foreign_tables"
+	NodeRelationPartitions    = "partitions"
+	NodeRelationColumns       = "columns"
+	NodeRelationConstraints   = "constraints"
+	NodeRelationIndexes       = "indexes"
+	NodeRelationTriggers      = "triggers"
+	NodeRelationFunctions     = "functions"
+	NodeRelationSequences     = "sequences"
+	NodeRelationTypes         = "types"
+	NodeRelationExtensions    = "extensions"
+	NodeRelationType          = "type"
 )
 
 // Nodes is a typed list of graph nodes.
@@ -101,3 +108,25 @@ func relationToDTO(ids []string) dto.NodeEdge {
 	}
 	return dto.NodeEdge{Items: ids, Truncated: false}
 }
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func grantsToDTO(grants []Grant) *[]dto.Privilege {
+	if len(grants) == 0 {
+		return nil
+	}
+	out := make([]dto.Privilege, 0, len(grants))
+	for _, grant := range grants {
+		out = append(out, dto.Privilege{
+			Grantee:     grant.Grantee,
+			Privilege:   grant.Privilege,
+			GrantOption: grant.GrantOption,
+		})
+	}
+	return &out
+}
@@ -11,17 +11,21 @@ import (
 
 type ColumnNode struct {
 	BaseNode
-	CharMaxLength      *int64
-	Column             string
-	Connection         string
-	DataType           string
-	DefaultValue       *string
-	NotNull            bool
-	NumericPrecision   *int64
-	NumericScale       *int64
-	Ordinal            int
-	PrimaryKeyPosition *int
-	Table              string
+	CharMaxLength        *int64
+	Column               string
+	Connection           string
+	DataType             string
+	DefaultValue         *string
+	NotNull              bool
+	NumericPrecision     *int64
+	NumericScale         *int64
+	Ordinal              int
+	PrimaryKeyPosition   *int
+	Table                string
+	Identity             *string
+	Generated            *string
+	GenerationExpression *string
+	UserType             *string // ID of the user-defined type node, if any
 }
 
 func NewColumnNode(scope Scope, relation string, col Column) *ColumnNode {
@@ -31,6 +35,12 @@ func NewColumnNode(scope Scope, relation string, col Column) *ColumnNode {
 		primaryKeyPosition = &v
 	}
 
+	var userType *string
+	if col.TypeName != nil {
+		id := TypeNodeID(scope.WithSchema(col.TypeSchema), *col.TypeName)
+		userType = &id
+	}
+
 	return &ColumnNode{
 		BaseNode: BaseNode{
 			ID:       stringutil.Slug(scope.Slug(), relation, col.Name, "column"),
@@ -38,17 +48,21 @@ func NewColumnNode(scope Scope, relation string, col Column) *ColumnNode {
 			Scope:    scope,
 			Hydrated: true,
 		},
-		Connection:         scope.Connection(),
-		Table:              relation,
-		Column:             col.Name,
-		Ordinal:            col.Ordinal,
-		DataType:           col.DataType,
-		NotNull:            col.NotNull,
-		DefaultValue:       col.DefaultValue,
-		PrimaryKeyPosition: primaryKeyPosition,
-		CharMaxLength:      col.CharMaxLength,
-		NumericPrecision:   col.NumericPrecision,
-		NumericScale:       col.NumericScale,
+		Connection:           scope.Connection(),
+		Table:                relation,
+		Column:               col.Name,
+		Ordinal:              col.Ordinal,
+		DataType:             col.DataType,
+		NotNull:              col.NotNull,
+		DefaultValue:         col.DefaultValue,
+		PrimaryKeyPosition:   primaryKeyPosition,
+		CharMaxLength:        col.CharMaxLength,
+		NumericPrecision:     col.NumericPrecision,
+		NumericScale:         col.NumericScale,
+		Identity:             col.Identity,
+		Generated:            col.Generated,
+		GenerationExpression: col.GenerationExpression,
+		UserType:             userType,
 	}
 }
 
@@ -63,6 +77,10 @@ func (n *ColumnNode) Clone() Node {
 	clone.NumericPrecision = cloneutil.Ptr(n.NumericPrecision)
 	clone.NumericScale = cloneutil.Ptr(n.NumericScale)
 	clone.PrimaryKeyPosition = cloneutil.Ptr(n.PrimaryKeyPosition)
+	clone.Identity = cloneutil.Ptr(n.Identity)
+	clone.Generated = cloneutil.Ptr(n.Generated)
+	clone.GenerationExpression = cloneutil.Ptr(n.GenerationExpression)
+	clone.UserType = cloneutil.Ptr(n.UserType)
 	return &clone
 }
 
@@ -70,23 +88,30 @@ func (node *ColumnNode) ToDTO() (dto.Node, error) {
 	if node == nil {
 		return dto.Node{}, fmt.Errorf("column node is nil")
 	}
+	edges := map[string]dto.NodeEdge{}
+	if node.UserType != nil {
+		edges[NodeRelationType] = relationToDTO([]string{*node.UserType})
+	}
 	out := dto.Node{}
 	err := out.FromColumnNode(dto.ColumnNode{
 		Id:    node.GetID(),
 		Name:  node.GetName(),
-		Edges: map[string]dto.NodeEdge{},
+		Edges: edges,
 		Attributes: dto.ColumnNodeAttributes{
-			CharMaxLength:      node.CharMaxLength,
-			Column:             node.Column,
-			Resource:           node.Connection,
-			DataType:           node.DataType,
-			DefaultValue:       node.DefaultValue,
-			NotNull:            node.NotNull,
-			NumericPrecision:   node.NumericPrecision,
-			NumericScale:       node.NumericScale,
-			Ordinal:            node.Ordinal,
-			PrimaryKeyPosition: node.PrimaryKeyPosition,
-			Table:              node.Table,
+			CharMaxLength:        node.CharMaxLength,
+			Column:               node.Column,
+			Resource:             node.Connection,
+			DataType:             node.DataType,
+			DefaultValue:         node.DefaultValue,
+			NotNull:              node.NotNull,
+			NumericPrecision:     node.NumericPrecision,
+			NumericScale:         node.NumericScale,
+			Ordinal:              node.Ordinal,
+			PrimaryKeyPosition:   node.PrimaryKeyPosition,
+			Table:                node.Table,
+			Identity:             (*dto.ColumnNodeAttributesIdentity)(node.Identity),
+			Generated:            (*dto.ColumnNodeAttributesGenerated)(node.Generated),
+			GenerationExpression: node.GenerationExpression,
 		},
 	})
 	if err != nil {
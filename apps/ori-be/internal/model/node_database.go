@@ -19,6 +19,7 @@ type DatabaseNode struct {
 	Sequence   *int
 	Tables     []string
 	Views      []string
+	Extensions []string
 }
 
 func NewDatabaseNode(scope Database) *DatabaseNode {
@@ -51,6 +52,7 @@ func (n *DatabaseNode) Clone() Node {
 	clone.Sequence = cloneutil.Ptr(n.Sequence)
 	clone.Tables = cloneutil.Slice(n.Tables)
 	clone.Views = cloneutil.Slice(n.Views)
+	clone.Extensions = cloneutil.Slice(n.Extensions)
 	return &clone
 }
 
@@ -63,8 +65,9 @@ func (node *DatabaseNode) ToDTO() (dto.Node, error) {
 		Id:   node.GetID(),
 		Name: node.GetName(),
 		Edges: map[string]dto.NodeEdge{
-			NodeRelationTables: relationToDTO(node.Tables),
-			NodeRelationViews:  relationToDTO(node.Views),
+			NodeRelationTables:     relationToDTO(node.Tables),
+			NodeRelationViews:      relationToDTO(node.Views),
+			NodeRelationExtensions: relationToDTO(node.Extensions),
 		},
 		Attributes: dto.DatabaseNodeAttributes{
 			Resource:  node.Connection,
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"fmt"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+)
+
+type ExtensionNode struct {
+	BaseNode
+	Connection    string
+	Description   *string
+	ExtensionName string
+	Loaded        *bool
+	Schema        *string
+	Version       string
+}
+
+func NewExtensionNode(scope Scope, ext Extension) *ExtensionNode {
+	node := &ExtensionNode{
+		BaseNode: BaseNode{
+			ID:       stringutil.Slug(scope.Slug(), ext.Name, "extension"),
+			Name:     ext.Name,
+			Scope:    scope,
+			Hydrated: true,
+		},
+		Connection:    scope.Connection(),
+		ExtensionName: ext.Name,
+		Loaded:        ext.Loaded,
+		Version:       ext.Version,
+	}
+	if ext.Description != "" {
+		node.Description = &ext.Description
+	}
+	if ext.Schema != "" {
+		node.Schema = &ext.Schema
+	}
+	return node
+}
+
+func (n *ExtensionNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.Description = cloneutil.Ptr(n.Description)
+	clone.Loaded = cloneutil.Ptr(n.Loaded)
+	clone.Schema = cloneutil.Ptr(n.Schema)
+	return &clone
+}
+
+func (node *ExtensionNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("extension node is nil")
+	}
+	out := dto.Node{}
+	err := out.FromExtensionNode(dto.ExtensionNode{
+		Id:    node.GetID(),
+		Name:  node.GetName(),
+		Edges: map[string]dto.NodeEdge{},
+		Attributes: dto.ExtensionNodeAttributes{
+			Description:   node.Description,
+			ExtensionName: node.ExtensionName,
+			Loaded:        node.Loaded,
+			Resource:      node.Connection,
+			Schema:        node.Schema,
+			Version:       node.Version,
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
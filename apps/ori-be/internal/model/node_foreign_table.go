@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+	dto "github.com/crueladdict/ori/libs/contract/go"
+)
+
+type ForeignTableNode struct {
+	BaseNode
+	Connection  string
+	Owner       *string
+	Grants      []Grant
+	Table       string
+	TableType   string
+	Server      string
+	Options     *[]string
+	Columns     []string
+	Constraints []string
+	Indexes     []string
+	Triggers    []string
+}
+
+func NewForeignTableNode(scope Scope, rel Relation) *ForeignTableNode {
+	id := stringutil.Slug(scope.Slug(), rel.Name, rel.Type)
+
+	return &ForeignTableNode{
+		BaseNode: BaseNode{
+			ID:       id,
+			Name:     rel.Name,
+			Scope:    scope,
+			Hydrated: false,
+		},
+		Connection: scope.Connection(),
+		Owner:      optionalString(rel.Owner),
+		Grants:     rel.Grants,
+		Table:      rel.Name,
+		TableType:  rel.Type,
+		Server:     rel.Server,
+		Options:    &rel.Options,
+	}
+}
+
+func (n *ForeignTableNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	clone.Grants = cloneutil.Slice(n.Grants)
+	clone.Options = cloneutil.SlicePtr(n.Options)
+	clone.Columns = cloneutil.Slice(n.Columns)
+	clone.Constraints = cloneutil.Slice(n.Constraints)
+	clone.Indexes = cloneutil.Slice(n.Indexes)
+	clone.Triggers = cloneutil.Slice(n.Triggers)
+	return &clone
+}
+
+func (n *ForeignTableNode) RelationName() string {
+	if n == nil {
+		return ""
+	}
+	return n.Table
+}
+
+func (node *ForeignTableNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("foreign table node is nil")
+	}
+	out := dto.Node{}
+	err := out.FromForeignTableNode(dto.ForeignTableNode{
+		Id:   node.GetID(),
+		Name: node.GetName(),
+		Edges: map[string]dto.NodeEdge{
+			NodeRelationColumns:     relationToDTO(node.Columns),
+			NodeRelationConstraints: relationToDTO(node.Constraints),
+			NodeRelationIndexes:     relationToDTO(node.Indexes),
+			NodeRelationTriggers:    relationToDTO(node.Triggers),
+		},
+		Attributes: dto.ForeignTableNodeAttributes{
+			Resource:   node.Connection,
+			Owner:      node.Owner,
+			Privileges: grantsToDTO(node.Grants),
+			Table:      node.Table,
+			TableType:  node.TableType,
+			Server:     node.Server,
+			Options:    node.Options,
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
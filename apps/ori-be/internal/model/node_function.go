@@ -0,0 +1,112 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+)
+
+type FunctionNode struct {
+	BaseNode
+	Arguments       *string
+	Connection      string
+	Definition      *string
+	FunctionName    string
+	Grants          []Grant
+	Language        string
+	Owner           *string
+	ReturnType      *string
+	SecurityDefiner bool
+	Signature       string
+	Volatility      *string
+}
+
+func NewRoutineNode(scope Scope, routine Routine) Node {
+	if routine.Kind == "procedure" {
+		return NewProcedureNode(scope, routine)
+	}
+	return NewFunctionNode(scope, routine)
+}
+
+// routineNodeID keeps the signature readable in the ID and appends a hash of it:
+// slugs fold punctuation, so overloads such as f(integer) and f(integer[]) would
+// otherwise share an ID.
+func routineNodeID(scope Scope, signature, kind string) string {
+	sum := sha256.Sum256([]byte(signature))
+	return stringutil.Slug(scope.Slug(), signature, hex.EncodeToString(sum[:6]), kind)
+}
+
+func NewFunctionNode(scope Scope, routine Routine) *FunctionNode {
+	var volatility *string
+	if routine.Volatility != "" {
+		volatility = &routine.Volatility
+	}
+	return &FunctionNode{
+		BaseNode: BaseNode{
+			ID:       routineNodeID(scope, routine.Signature, "function"),
+			Name:     routine.Name,
+			Scope:    scope,
+			Hydrated: true,
+		},
+		Arguments:       &routine.Arguments,
+		Connection:      scope.Connection(),
+		Definition:      &routine.Definition,
+		FunctionName:    routine.Name,
+		Grants:          routine.Grants,
+		Language:        routine.Language,
+		Owner:           optionalString(routine.Owner),
+		ReturnType:      &routine.ReturnType,
+		SecurityDefiner: routine.SecurityDefiner,
+		Signature:       routine.Signature,
+		Volatility:      volatility,
+	}
+}
+
+func (n *FunctionNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.Arguments = cloneutil.Ptr(n.Arguments)
+	clone.Definition = cloneutil.Ptr(n.Definition)
+	clone.Grants = cloneutil.Slice(n.Grants)
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	clone.ReturnType = cloneutil.Ptr(n.ReturnType)
+	clone.Volatility = cloneutil.Ptr(n.Volatility)
+	return &clone
+}
+
+func (node *FunctionNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("function node is nil")
+	}
+	out := dto.Node{}
+	err := out.FromFunctionNode(dto.FunctionNode{
+		Id:    node.GetID(),
+		Name:  node.GetName(),
+		Edges: map[string]dto.NodeEdge{},
+		Attributes: dto.FunctionNodeAttributes{
+			Arguments:       node.Arguments,
+			Definition:      node.Definition,
+			FunctionName:    node.FunctionName,
+			Language:        node.Language,
+			Owner:           node.Owner,
+			Privileges:      grantsToDTO(node.Grants),
+			Resource:        node.Connection,
+			ReturnType:      node.ReturnType,
+			SecurityDefiner: node.SecurityDefiner,
+			Signature:       node.Signature,
+			Volatility:      (*dto.FunctionNodeAttributesVolatility)(node.Volatility),
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+	dto "github.com/crueladdict/ori/libs/contract/go"
+)
+
+type MatviewNode struct {
+	BaseNode
+	Connection  string
+	Owner       *string
+	Grants      []Grant
+	Definition  *string
+	Table       string
+	TableType   string
+	Populated   bool
+	Columns     []string
+	Constraints []string
+	Indexes     []string
+	Triggers    []string
+}
+
+func NewMatviewNode(scope Scope, rel Relation) *MatviewNode {
+	id := stringutil.Slug(scope.Slug(), rel.Name, rel.Type)
+
+	return &MatviewNode{
+		BaseNode: BaseNode{
+			ID:       id,
+			Name:     rel.Name,
+			Scope:    scope,
+			Hydrated: false,
+		},
+		Connection: scope.Connection(),
+		Owner:      optionalString(rel.Owner),
+		Grants:     rel.Grants,
+		Definition: &rel.Definition,
+		Table:      rel.Name,
+		TableType:  rel.Type,
+		Populated:  rel.Populated,
+	}
+}
+
+func (n *MatviewNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	clone.Grants = cloneutil.Slice(n.Grants)
+	clone.Definition = cloneutil.Ptr(n.Definition)
+	clone.Columns = cloneutil.Slice(n.Columns)
+	clone.Constraints = cloneutil.Slice(n.Constraints)
+	clone.Indexes = cloneutil.Slice(n.Indexes)
+	clone.Triggers = cloneutil.Slice(n.Triggers)
+	return &clone
+}
+
+func (n *MatviewNode) RelationName() string {
+	if n == nil {
+		return ""
+	}
+	return n.Table
+}
+
+func (node *MatviewNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("matview node is nil")
+	}
+	out := dto.Node{}
+	err := out.FromMatviewNode(dto.MatviewNode{
+		Id:   node.GetID(),
+		Name: node.GetName(),
+		Edges: map[string]dto.NodeEdge{
+			NodeRelationColumns:     relationToDTO(node.Columns),
+			NodeRelationConstraints: relationToDTO(node.Constraints),
+			NodeRelationIndexes:     relationToDTO(node.Indexes),
+			NodeRelationTriggers:    relationToDTO(node.Triggers),
+		},
+		Attributes: dto.MatviewNodeAttributes{
+			Resource:   node.Connection,
+			Owner:      node.Owner,
+			Privileges: grantsToDTO(node.Grants),
+			Definition: node.Definition,
+			Table:      node.Table,
+			TableType:  node.TableType,
+			Populated:  node.Populated,
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
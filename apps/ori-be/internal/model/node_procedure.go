@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+)
+
+type ProcedureNode struct {
+	BaseNode
+	Arguments       *string
+	Connection      string
+	Definition      *string
+	Grants          []Grant
+	Language        string
+	Owner           *string
+	ProcedureName   string
+	SecurityDefiner bool
+	Signature       string
+}
+
+func NewProcedureNode(scope Scope, routine Routine) *ProcedureNode {
+	return &ProcedureNode{
+		BaseNode: BaseNode{
+			ID:       routineNodeID(scope, routine.Signature, "procedure"),
+			Name:     routine.Name,
+			Scope:    scope,
+			Hydrated: true,
+		},
+		Arguments:       &routine.Arguments,
+		Connection:      scope.Connection(),
+		Definition:      &routine.Definition,
+		Grants:          routine.Grants,
+		Language:        routine.Language,
+		Owner:           optionalString(routine.Owner),
+		ProcedureName:   routine.Name,
+		SecurityDefiner: routine.SecurityDefiner,
+		Signature:       routine.Signature,
+	}
+}
+
+func (n *ProcedureNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.Arguments = cloneutil.Ptr(n.Arguments)
+	clone.Definition = cloneutil.Ptr(n.Definition)
+	clone.Grants = cloneutil.Slice(n.Grants)
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	return &clone
+}
+
+func (node *ProcedureNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("procedure node is nil")
+	}
+	out := dto.Node{}
+	err := out.FromProcedureNode(dto.ProcedureNode{
+		Id:    node.GetID(),
+		Name:  node.GetName(),
+		Edges: map[string]dto.NodeEdge{},
+		Attributes: dto.ProcedureNodeAttributes{
+			Arguments:       node.Arguments,
+			Definition:      node.Definition,
+			Language:        node.Language,
+			Owner:           node.Owner,
+			Privileges:      grantsToDTO(node.Grants),
+			ProcedureName:   node.ProcedureName,
+			Resource:        node.Connection,
+			SecurityDefiner: node.SecurityDefiner,
+			Signature:       node.Signature,
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
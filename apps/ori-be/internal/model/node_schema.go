@@ -10,11 +10,19 @@ import (
 
 type SchemaNode struct {
 	BaseNode
-	Connection string
-	Engine     string
-	IsDefault  bool
-	Tables     []string
-	Views      []string
+	Connection    string
+	Owner         *string
+	Grants        []Grant
+	Engine        string
+	IsDefault     bool
+	Tables        []string
+	Views         []string
+	Matviews      []string
+	ForeignTables []string
+	Functions     []string
+	Sequences     []string
+	Types         []string
+	Extensions    []string
 }
 
 func NewSchemaNode(scope Schema) *SchemaNode {
@@ -26,6 +34,8 @@ func NewSchemaNode(scope Schema) *SchemaNode {
 			Hydrated: false,
 		},
 		Connection: scope.ConnectionName,
+		Owner:      optionalString(scope.Owner),
+		Grants:     scope.Grants,
 		Engine:     scope.Engine,
 		IsDefault:  scope.IsDefault,
 	}
@@ -37,8 +47,16 @@ func (n *SchemaNode) Clone() Node {
 	}
 	clone := *n
 	clone.BaseNode = n.cloneBase()
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	clone.Grants = cloneutil.Slice(n.Grants)
 	clone.Tables = cloneutil.Slice(n.Tables)
 	clone.Views = cloneutil.Slice(n.Views)
+	clone.Matviews = cloneutil.Slice(n.Matviews)
+	clone.ForeignTables = cloneutil.Slice(n.ForeignTables)
+	clone.Functions = cloneutil.Slice(n.Functions)
+	clone.Sequences = cloneutil.Slice(n.Sequences)
+	clone.Types = cloneutil.Slice(n.Types)
+	clone.Extensions = cloneutil.Slice(n.Extensions)
 	return &clone
 }
 
@@ -51,13 +69,21 @@ func (node *SchemaNode) ToDTO() (dto.Node, error) {
 		Id:   node.GetID(),
 		Name: node.GetName(),
 		Edges: map[string]dto.NodeEdge{
-			NodeRelationTables: relationToDTO(node.Tables),
-			NodeRelationViews:  relationToDTO(node.Views),
+			NodeRelationTables:        relationToDTO(node.Tables),
+			NodeRelationViews:         relationToDTO(node.Views),
+			NodeRelationMatviews:      relationToDTO(node.Matviews),
+			NodeRelationForeignTables: relationToDTO(node.ForeignTables),
+			NodeRelationFunctions:     relationToDTO(node.Functions),
+			NodeRelationSequences:     relationToDTO(node.Sequences),
+			NodeRelationTypes:         relationToDTO(node.Types),
+			NodeRelationExtensions:    relationToDTO(node.Extensions),
 		},
 		Attributes: dto.SchemaNodeAttributes{
-			Resource:  node.Connection,
-			Engine:    node.Engine,
-			IsDefault: node.IsDefault,
+			Resource:   node.Connection,
+			Owner:      node.Owner,
+			Privileges: grantsToDTO(node.Grants),
+			Engine:     node.Engine,
+			IsDefault:  node.IsDefault,
 		},
 	})
 	if err != nil {
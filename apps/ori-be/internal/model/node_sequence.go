@@ -0,0 +1,91 @@
+package model
+
+import (
+	"fmt"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+)
+
+type SequenceNode struct {
+	BaseNode
+	Connection    string
+	CurrentValue  *int64
+	Cycle         bool
+	DataType      string
+	Increment     int64
+	MaxValue      int64
+	MinValue      int64
+	OwnedByColumn *string
+	OwnedByTable  *string
+	SequenceName  string
+	StartValue    int64
+}
+
+func NewSequenceNode(scope Scope, seq Sequence) *SequenceNode {
+	node := &SequenceNode{
+		BaseNode: BaseNode{
+			ID:       stringutil.Slug(scope.Slug(), seq.Name, "sequence"),
+			Name:     seq.Name,
+			Scope:    scope,
+			Hydrated: true,
+		},
+		Connection:   scope.Connection(),
+		CurrentValue: seq.CurrentValue,
+		Cycle:        seq.Cycle,
+		DataType:     seq.DataType,
+		Increment:    seq.Increment,
+		MaxValue:     seq.MaxValue,
+		MinValue:     seq.MinValue,
+		SequenceName: seq.Name,
+		StartValue:   seq.Start,
+	}
+	if seq.OwnedByTable != "" {
+		node.OwnedByTable = &seq.OwnedByTable
+		node.OwnedByColumn = &seq.OwnedByColumn
+	}
+	return node
+}
+
+func (n *SequenceNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.CurrentValue = cloneutil.Ptr(n.CurrentValue)
+	clone.OwnedByColumn = cloneutil.Ptr(n.OwnedByColumn)
+	clone.OwnedByTable = cloneutil.Ptr(n.OwnedByTable)
+	return &clone
+}
+
+func (node *SequenceNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("sequence node is nil")
+	}
+	out := dto.Node{}
+	err := out.FromSequenceNode(dto.SequenceNode{
+		Id:    node.GetID(),
+		Name:  node.GetName(),
+		Edges: map[string]dto.NodeEdge{},
+		Attributes: dto.SequenceNodeAttributes{
+			CurrentValue:  node.CurrentValue,
+			Cycle:         node.Cycle,
+			DataType:      node.DataType,
+			Increment:     node.Increment,
+			MaxValue:      node.MaxValue,
+			MinValue:      node.MinValue,
+			OwnedByColumn: node.OwnedByColumn,
+			OwnedByTable:  node.OwnedByTable,
+			Resource:      node.Connection,
+			SequenceName:  node.SequenceName,
+			StartValue:    node.StartValue,
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
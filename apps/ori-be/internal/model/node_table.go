@@ -10,28 +10,39 @@ import (
 
 type TableNode struct {
 	BaseNode
-	Connection  string
-	Definition  *string
-	Table       string
-	TableType   string
-	Partitions  []string
-	Columns     []string
-	Constraints []string
-	Indexes     []string
-	Triggers    []string
+	Connection        string
+	Owner             *string
+	Grants            []Grant
+	Definition        *string
+	Table             string
+	TableType         string
+	PartitionStrategy *string
+	PartitionKey      *string
+	PartitionBound    *string
+	Partitions        []string
+	Columns           []string
+	Constraints       []string
+	Indexes           []string
+	Triggers          []string
 }
 
 func NewRelationNode(scope Scope, rel Relation) Node {
-	if rel.Type == "view" {
+	switch rel.Type {
+	case "view":
 		return NewViewNode(scope, rel)
+	case "matview":
+		return NewMatviewNode(scope, rel)
+	case "foreign_table":
+		return NewForeignTableNode(scope, rel)
+	default:
+		return NewTableNode(scope, rel)
 	}
-	return NewTableNode(scope, rel)
 }
 
 func NewTableNode(scope Scope, rel Relation) *TableNode {
 	id := stringutil.Slug(scope.Slug(), rel.Name, rel.Type)
 
-	return &TableNode{
+	node := &TableNode{
 		BaseNode: BaseNode{
 			ID:       id,
 			Name:     rel.Name,
@@ -39,10 +50,20 @@ func NewTableNode(scope Scope, rel Relation) *TableNode {
 			Hydrated: false,
 		},
 		Connection: scope.Connection(),
+		Owner:      optionalString(rel.Owner),
+		Grants:     rel.Grants,
 		Definition: &rel.Definition,
 		Table:      rel.Name,
 		TableType:  rel.Type,
 	}
+	if rel.PartitionStrategy != "" {
+		node.PartitionStrategy = &rel.PartitionStrategy
+		node.PartitionKey = &rel.PartitionKey
+	}
+	if rel.PartitionBound != "" {
+		node.PartitionBound = &rel.PartitionBound
+	}
+	return node
 }
 
 func (n *TableNode) Clone() Node {
@@ -51,7 +72,12 @@ func (n *TableNode) Clone() Node {
 	}
 	clone := *n
 	clone.BaseNode = n.cloneBase()
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	clone.Grants = cloneutil.Slice(n.Grants)
 	clone.Definition = cloneutil.Ptr(n.Definition)
+	clone.PartitionStrategy = cloneutil.Ptr(n.PartitionStrategy)
+	clone.PartitionKey = cloneutil.Ptr(n.PartitionKey)
+	clone.PartitionBound = cloneutil.Ptr(n.PartitionBound)
 	clone.Partitions = cloneutil.Slice(n.Partitions)
 	clone.Columns = cloneutil.Slice(n.Columns)
 	clone.Constraints = cloneutil.Slice(n.Constraints)
@@ -83,10 +109,15 @@ func (node *TableNode) ToDTO() (dto.Node, error) {
 			NodeRelationTriggers:    relationToDTO(node.Triggers),
 		},
 		Attributes: dto.TableNodeAttributes{
-			Resource:   node.Connection,
-			Definition: node.Definition,
-			Table:      node.Table,
-			TableType:  node.TableType,
+			Resource:          node.Connection,
+			Owner:             node.Owner,
+			Privileges:        grantsToDTO(node.Grants),
+			Definition:        node.Definition,
+			Table:             node.Table,
+			TableType:         node.TableType,
+			PartitionStrategy: (*dto.TableNodeAttributesPartitionStrategy)(node.PartitionStrategy),
+			PartitionKey:      node.PartitionKey,
+			PartitionBound:    node.PartitionBound,
 		},
 	})
 	if err != nil {
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"fmt"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+)
+
+type TypeNode struct {
+	BaseNode
+	BaseType     *string
+	Connection   string
+	Constraints  *[]string
+	DefaultValue *string
+	Fields       *[]TypeField
+	Kind         string
+	Labels       *[]string
+	NotNull      bool
+	TypeName     string
+}
+
+// TypeNodeID returns the ID of the node for a user-defined type, which columns use to link to it.
+func TypeNodeID(scope Scope, name string) string {
+	return stringutil.Slug(scope.Slug(), name, "type")
+}
+
+func NewTypeNode(scope Scope, typ UserType) *TypeNode {
+	node := &TypeNode{
+		BaseNode: BaseNode{
+			ID:       TypeNodeID(scope, typ.Name),
+			Name:     typ.Name,
+			Scope:    scope,
+			Hydrated: true,
+		},
+		Connection:   scope.Connection(),
+		DefaultValue: typ.Default,
+		Kind:         typ.Kind,
+		NotNull:      typ.NotNull,
+		TypeName:     typ.Name,
+	}
+	switch typ.Kind {
+	case "enum":
+		node.Labels = &typ.Labels
+	case "domain":
+		node.BaseType = &typ.BaseType
+		node.Constraints = &typ.Constraints
+	case "composite":
+		node.Fields = &typ.Fields
+	}
+	return node
+}
+
+func (n *TypeNode) Clone() Node {
+	if n == nil {
+		return nil
+	}
+	clone := *n
+	clone.BaseNode = n.cloneBase()
+	clone.BaseType = cloneutil.Ptr(n.BaseType)
+	clone.Constraints = cloneutil.SlicePtr(n.Constraints)
+	clone.DefaultValue = cloneutil.Ptr(n.DefaultValue)
+	clone.Fields = cloneutil.SlicePtr(n.Fields)
+	clone.Labels = cloneutil.SlicePtr(n.Labels)
+	return &clone
+}
+
+func (node *TypeNode) ToDTO() (dto.Node, error) {
+	if node == nil {
+		return dto.Node{}, fmt.Errorf("type node is nil")
+	}
+	var fields *[]dto.TypeField
+	if node.Fields != nil {
+		converted := make([]dto.TypeField, 0, len(*node.Fields))
+		for _, field := range *node.Fields {
+			converted = append(converted, dto.TypeField{Name: field.Name, DataType: field.DataType})
+		}
+		fields = &converted
+	}
+	out := dto.Node{}
+	err := out.FromTypeNode(dto.TypeNode{
+		Id:    node.GetID(),
+		Name:  node.GetName(),
+		Edges: map[string]dto.NodeEdge{},
+		Attributes: dto.TypeNodeAttributes{
+			BaseType:     node.BaseType,
+			Constraints:  node.Constraints,
+			DefaultValue: node.DefaultValue,
+			Fields:       fields,
+			Kind:         dto.TypeNodeAttributesKind(node.Kind),
+			Labels:       node.Labels,
+			NotNull:      node.NotNull,
+			Resource:     node.Connection,
+			TypeName:     node.TypeName,
+		},
+	})
+	if err != nil {
+		return dto.Node{}, fmt.Errorf("node %s: %w", node.GetID(), err)
+	}
+	return out, nil
+}
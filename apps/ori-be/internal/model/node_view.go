@@ -11,6 +11,8 @@ import (
 type ViewNode struct {
 	BaseNode
 	Connection  string
+	Owner       *string
+	Grants      []Grant
 	Definition  *string
 	Table       string
 	TableType   string
@@ -31,6 +33,8 @@ func NewViewNode(scope Scope, rel Relation) *ViewNode {
 			Hydrated: false,
 		},
 		Connection: scope.Connection(),
+		Owner:      optionalString(rel.Owner),
+		Grants:     rel.Grants,
 		Definition: &rel.Definition,
 		Table:      rel.Name,
 		TableType:  rel.Type,
@@ -43,6 +47,8 @@ func (n *ViewNode) Clone() Node {
 	}
 	clone := *n
 	clone.BaseNode = n.cloneBase()
+	clone.Owner = cloneutil.Ptr(n.Owner)
+	clone.Grants = cloneutil.Slice(n.Grants)
 	clone.Definition = cloneutil.Ptr(n.Definition)
 	clone.Columns = cloneutil.Slice(n.Columns)
 	clone.Constraints = cloneutil.Slice(n.Constraints)
@@ -74,6 +80,8 @@ func (node *ViewNode) ToDTO() (dto.Node, error) {
 		},
 		Attributes: dto.ViewNodeAttributes{
 			Resource:   node.Connection,
+			Owner:      node.Owner,
+			Privileges: grantsToDTO(node.Grants),
 			Definition: node.Definition,
 			Table:      node.Table,
 			TableType:  node.TableType,
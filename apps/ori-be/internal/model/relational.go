@@ -1,6 +1,9 @@
 package model
 
-import "github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+import (
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/cloneutil"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/stringutil"
+)
 
 // Scope identifies a namespace for relations and can create its root graph node.
 type Scope interface {
@@ -69,6 +72,9 @@ type Schema struct {
 	Database       string
 	Name           string
 	IsDefault      bool
+	// Ownership and grants (Postgres).
+	Owner  string
+	Grants []Grant
 }
 
 func (s Schema) Slug() string {
@@ -102,6 +108,7 @@ func (s Schema) WithSchema(name *string) Scope {
 }
 
 func (s Schema) Clone() Scope {
+	s.Grants = cloneutil.Slice(s.Grants)
 	return s
 }
 
@@ -109,14 +116,26 @@ func (s Schema) NewRootNode() Node {
 	return NewSchemaNode(s)
 }
 
-// Relation describes a table or view.
+// Relation describes a table, view, materialized view or foreign table.
 type Relation struct {
 	Name         string
-	Type         string  // "table" or "view"
-	Definition   string  // View definition SQL, if applicable
+	Type         string  // "table", "view", "matview" or "foreign_table"
+	Definition   string  // View or materialized view definition SQL, if applicable
 	Schema       *string // Relation schema (Postgres), if applicable
 	ParentSchema *string // Partition parent schema (Postgres), if applicable
 	ParentTable  *string // Partition parent table name (Postgres), if applicable
+	// Partitioning (Postgres): the key of a partitioned table and the bound of a partition.
+	PartitionStrategy string // "RANGE", "LIST" or "HASH"
+	PartitionKey      string // Key expression, as in "created_at" or "lower(email)"
+	PartitionBound    string // As in "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')"
+	// Materialized views (Postgres).
+	Populated bool
+	// Foreign tables (Postgres).
+	Server  string
+	Options []string // As in "schema_name=public"
+	// Ownership and grants (Postgres).
+	Owner  string
+	Grants []Grant
 }
 
 // Column describes a table/view column.
@@ -130,6 +149,13 @@ type Column struct {
 	CharMaxLength    *int64
 	NumericPrecision *int64
 	NumericScale     *int64
+	// Identity and generated columns get their values from the database.
+	Identity             *string // "ALWAYS" or "BY DEFAULT"
+	Generated            *string // "STORED" or "VIRTUAL"
+	GenerationExpression *string
+	// User-defined type of the column, if any.
+	TypeSchema *string
+	TypeName   *string
 }
 
 // Constraint describes a table constraint.
@@ -170,3 +196,85 @@ type Trigger struct {
 	EnabledState string
 	Definition   string
 }
+
+// Routine describes a function or procedure.
+type Routine struct {
+	Name            string
+	Kind            string // "function" or "procedure"
+	Signature       string // Name with argument types, unique among overloads
+	Arguments       string // Argument list with names, modes and defaults
+	ReturnType      string // Function result type; empty for procedures
+	Language        string
+	Volatility      string // "IMMUTABLE", "STABLE" or "VOLATILE", if known
+	SecurityDefiner bool
+	Definition      string
+	// Ownership and grants (Postgres).
+	Owner  string
+	Grants []Grant
+}
+
+// Sequence describes a sequence generator.
+type Sequence struct {
+	Name          string
+	DataType      string
+	Start         int64
+	Increment     int64
+	MinValue      int64
+	MaxValue      int64
+	Cycle         bool
+	CurrentValue  *int64 // Last value handed out, if any
+	OwnedByTable  string // Table of the column the sequence feeds, if any
+	OwnedByColumn string
+}
+
+// UserType describes a user-defined enum, domain or composite type.
+type UserType struct {
+	Name        string
+	Kind        string      // "enum", "domain" or "composite"
+	Labels      []string    // Enum labels in sort order
+	BaseType    string      // Domain base type
+	NotNull     bool        // Domain NOT NULL
+	Default     *string     // Domain default, if any
+	Constraints []string    // Domain CHECK constraints, as in "CHECK (VALUE > 0)"
+	Fields      []TypeField // Composite type attributes
+}
+
+// TypeField is one attribute of a composite type.
+type TypeField struct {
+	Name     string
+	DataType string
+}
+
+// Extension describes an installed extension.
+type Extension struct {
+	Name        string
+	Version     string
+	Schema      string // Schema holding the extension's objects (Postgres), if any
+	Description string
+	Loaded      *bool // Whether the extension is loaded (DuckDB); installed ones load on first use
+}
+
+// Grant is a privilege a role holds on an object, as in SELECT on a table or
+// USAGE on a schema.
+type Grant struct {
+	Grantee     string // Role name, or "PUBLIC"
+	Privilege   string
+	GrantOption bool // Whether the grantee may grant the privilege on
+}
+
+// RolePrivileges sums up what a role can do across the objects of a database,
+// counting privileges it holds through role membership and PUBLIC.
+type RolePrivileges struct {
+	Role      string
+	Superuser bool
+	Objects   []ObjectPrivileges
+}
+
+// ObjectPrivileges lists the privileges a role has on one object. Grants leave
+// Grantee empty since they all belong to the role.
+type ObjectPrivileges struct {
+	ObjectType string // "schema", "table", "view", "matview", "foreign_table", "function" or "procedure"
+	Schema     string
+	Name       string // Object name; routines carry their signature and schemas their own name
+	Privileges []Grant
+}
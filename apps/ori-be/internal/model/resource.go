@@ -12,6 +12,13 @@ const (
 	DefaultAutoLimitRows = 500
 )
 
+// Destructive guard modes decide what happens to statements that may destroy data.
+const (
+	DestructiveGuardOff     = "off"
+	DestructiveGuardWarn    = "warn"
+	DestructiveGuardConfirm = "confirm"
+)
+
 type PasswordConfig struct {
 	Type string `json:"type"`          // Password provider type (plain_text, shell, keychain)
 	Key  string `json:"key,omitempty"` // Provider-specific value (plain text, shell command, or keychain account)
@@ -25,15 +32,27 @@ type TLSConfig struct {
 }
 
 type Resource struct {
-	Name          string          `json:"name"`
-	Type          string          `json:"type"`
-	Host          *string         `json:"host,omitempty"`
-	Port          *int            `json:"port,omitempty"`
-	Database      string          `json:"database"`
-	Username      *string         `json:"username,omitempty"`
-	AutoLimitRows *int            `json:"autoLimitRows"`
-	Password      *PasswordConfig `json:"password,omitempty"`
-	TLS           *TLSConfig      `json:"tls,omitempty"`
+	Name                 string          `json:"name"`
+	Type                 string          `json:"type"`
+	Host                 *string         `json:"host,omitempty"`
+	Port                 *int            `json:"port,omitempty"`
+	Database             string          `json:"database"`
+	Username             *string         `json:"username,omitempty"`
+	AutoLimitRows        *int            `json:"autoLimitRows"`
+	QueryTimeout         *int            `json:"queryTimeout,omitempty"`         // Milliseconds a query may run before it is canceled
+	MaxConcurrentQueries *int            `json:"maxConcurrentQueries,omitempty"` // Jobs run at once; further jobs wait in a queue
+	ReadOnly             bool            `json:"readOnly,omitempty"`             // Refuse writes at the driver and before execution
+	DestructiveGuard     string          `json:"destructiveGuard,omitempty"`     // off, warn (default) or confirm
+	Password             *PasswordConfig `json:"password,omitempty"`
+	TLS                  *TLSConfig      `json:"tls,omitempty"`
+}
+
+// DestructiveGuardMode returns the configured destructive guard mode, defaulting to warn.
+func (r *Resource) DestructiveGuardMode() string {
+	if r.DestructiveGuard == "" {
+		return DestructiveGuardWarn
+	}
+	return r.DestructiveGuard
 }
 
 func (r *Resource) UnmarshalJSON(data []byte) error {
@@ -79,16 +98,21 @@ func ConvertResourcesToDTO(configs []Resource) *dto.ResourcesResponse {
 			}
 		}
 
+		destructiveGuard := dto.ResourceDestructiveGuard(cfg.DestructiveGuardMode())
 		dtoConfigs[i] = dto.Resource{
-			Name:          cfg.Name,
-			Type:          cfg.Type,
-			Database:      cfg.Database,
-			Host:          cloneutil.Ptr(cfg.Host),
-			Port:          cloneutil.Ptr(cfg.Port),
-			Username:      cloneutil.Ptr(cfg.Username),
-			AutoLimitRows: cloneutil.Ptr(cfg.AutoLimitRows),
-			Password:      password,
-			Tls:           tls,
+			Name:                 cfg.Name,
+			Type:                 cfg.Type,
+			Database:             cfg.Database,
+			Host:                 cloneutil.Ptr(cfg.Host),
+			Port:                 cloneutil.Ptr(cfg.Port),
+			Username:             cloneutil.Ptr(cfg.Username),
+			AutoLimitRows:        cloneutil.Ptr(cfg.AutoLimitRows),
+			QueryTimeout:         cloneutil.Ptr(cfg.QueryTimeout),
+			MaxConcurrentQueries: cloneutil.Ptr(cfg.MaxConcurrentQueries),
+			ReadOnly:             cloneutil.Ptr(&cfg.ReadOnly),
+			DestructiveGuard:     &destructiveGuard,
+			Password:             password,
+			Tls:                  tls,
 		}
 	}
 	return &dto.ResourcesResponse{Resources: dtoConfigs}
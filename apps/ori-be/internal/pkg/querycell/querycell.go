@@ -1,36 +1,220 @@
+// Package querycell encodes scanned database values into the typed cell format of
+// query results. Values that JSON represents losslessly are emitted natively
+// (strings, bools and numbers within the float64 integer range); everything else
+// is wrapped in a Tagged value naming its type.
 package querycell
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"math/big"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 )
 
-func Stringify(value any) any {
+// Tag names the type of a tagged cell.
+type Tag string
+
+const (
+	TagDecimal     Tag = "decimal"
+	TagBytes       Tag = "bytes"
+	TagTimestamp   Tag = "timestamp"
+	TagTimestampTZ Tag = "timestamptz"
+	TagDate        Tag = "date"
+	TagTime        Tag = "time"
+	TagInterval    Tag = "interval"
+	TagUUID        Tag = "uuid"
+	TagArray       Tag = "array"
+	TagJSON        Tag = "json"
+)
+
+// maxSafeInteger is the largest integer a float64 (and so a JSON number) holds exactly.
+const maxSafeInteger = 1<<53 - 1
+
+const (
+	timestampLayout = "2006-01-02T15:04:05.999999999"
+	dateLayout      = "2006-01-02"
+)
+
+// Tagged is a cell whose type JSON cannot express natively.
+type Tagged struct {
+	Type        Tag    `json:"$type"`
+	Value       any    `json:"value"`
+	Length      *int   `json:"length,omitempty"`
+	ElementType string `json:"elementType,omitempty"`
+}
+
+// Int encodes an integer, falling back to a decimal tag outside the float64 integer range.
+func Int(value int64) any {
+	if value > maxSafeInteger || value < -maxSafeInteger {
+		return Decimal(strconv.FormatInt(value, 10))
+	}
+	return value
+}
+
+// Uint encodes an unsigned integer, falling back to a decimal tag outside the float64 integer range.
+func Uint(value uint64) any {
+	if value > maxSafeInteger {
+		return Decimal(strconv.FormatUint(value, 10))
+	}
+	return int64(value)
+}
+
+// BigInt encodes an arbitrary precision integer.
+func BigInt(value *big.Int) any {
 	if value == nil {
 		return nil
 	}
+	if value.IsInt64() {
+		return Int(value.Int64())
+	}
+	return Decimal(value.String())
+}
+
+// Float encodes a float; NaN and infinities become decimal tags.
+func Float(value float64) any {
+	switch {
+	case math.IsNaN(value):
+		return Decimal("NaN")
+	case math.IsInf(value, 1):
+		return Decimal("Infinity")
+	case math.IsInf(value, -1):
+		return Decimal("-Infinity")
+	}
+	return value
+}
+
+// Decimal tags the text form of an exact numeric value.
+func Decimal(text string) Tagged {
+	return Tagged{Type: TagDecimal, Value: text}
+}
+
+// Bytes tags binary data as base64 along with its length.
+func Bytes(value []byte) Tagged {
+	length := len(value)
+	return Tagged{Type: TagBytes, Value: base64.StdEncoding.EncodeToString(value), Length: &length}
+}
+
+// Timestamp tags a point in time. Zoned timestamps keep their offset; plain ones drop it.
+func Timestamp(value time.Time, withZone bool) Tagged {
+	if withZone {
+		return Tagged{Type: TagTimestampTZ, Value: value.Format(time.RFC3339Nano)}
+	}
+	return Tagged{Type: TagTimestamp, Value: value.Format(timestampLayout)}
+}
 
+// Date tags a calendar date.
+func Date(value time.Time) Tagged {
+	return Tagged{Type: TagDate, Value: value.Format(dateLayout)}
+}
+
+// Time tags a time of day in the driver's text form.
+func Time(text string) Tagged {
+	return Tagged{Type: TagTime, Value: text}
+}
+
+// Interval tags a duration in the driver's text form.
+func Interval(text string) Tagged {
+	return Tagged{Type: TagInterval, Value: text}
+}
+
+// UUID tags a UUID given either as text or as its 16 raw bytes.
+func UUID(value any) any {
 	switch v := value.(type) {
 	case string:
-		return v
+		return Tagged{Type: TagUUID, Value: v}
 	case []byte:
-		if utf8.Valid(v) {
-			return string(v)
+		if len(v) != 16 {
+			return Bytes(v)
+		}
+		text := hex.EncodeToString(v)
+		return Tagged{Type: TagUUID, Value: text[0:8] + "-" + text[8:12] + "-" + text[12:16] + "-" + text[16:20] + "-" + text[20:]}
+	case fmt.Stringer:
+		return Tagged{Type: TagUUID, Value: v.String()}
+	}
+	return Encode(value)
+}
+
+// Array tags a list of already encoded elements.
+func Array(elements []any, elementType string) Tagged {
+	if elements == nil {
+		elements = []any{}
+	}
+	return Tagged{Type: TagArray, Value: elements, ElementType: elementType}
+}
+
+// JSON tags a JSON document. Raw text is parsed, keeping numbers as json.Number so
+// integers beyond 2^53 survive; invalid documents are kept as text.
+func JSON(value any) any {
+	switch v := value.(type) {
+	case []byte:
+		return JSON(string(v))
+	case string:
+		decoder := json.NewDecoder(strings.NewReader(v))
+		decoder.UseNumber()
+		var document any
+		if err := decoder.Decode(&document); err != nil {
+			return v
 		}
-		return hex.EncodeToString(v)
+		if _, err := decoder.Token(); err != io.EOF {
+			return v
+		}
+		return Tagged{Type: TagJSON, Value: document}
+	}
+	return Tagged{Type: TagJSON, Value: jsonValue(value)}
+}
+
+// Encode maps a driver value to a cell from its Go type alone. Adapters handle
+// values whose meaning depends on the column type before falling back to Encode.
+func Encode(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case bool:
+		return v
+	case []byte:
+		return Bytes(v)
+	case int:
+		return Int(int64(v))
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return Int(v)
+	case uint:
+		return Uint(uint64(v))
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case uint64:
+		return Uint(v)
+	case float32:
+		return Float(float64(v))
+	case float64:
+		return Float(v)
+	case *big.Int:
+		return BigInt(v)
 	case time.Time:
-		return v.Format(time.RFC3339Nano)
+		return Timestamp(v, true)
+	case Tagged:
+		return v
 	case fmt.Stringer:
 		return v.String()
-	case bool,
-		int, int8, int16, int32, int64,
-		uint, uint8, uint16, uint32, uint64,
-		float32, float64:
-		return fmt.Sprint(v)
 	}
 
 	rv := reflect.ValueOf(value)
@@ -40,13 +224,57 @@ func Stringify(value any) any {
 		}
 		rv = rv.Elem()
 	}
-
 	switch rv.Kind() {
-	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
-		if payload, err := json.Marshal(value); err == nil {
-			return string(payload)
+	case reflect.Slice, reflect.Array:
+		elements := make([]any, rv.Len())
+		for i := range elements {
+			elements[i] = Encode(rv.Index(i).Interface())
 		}
+		return Array(elements, "")
+	case reflect.Map, reflect.Struct:
+		return Tagged{Type: TagJSON, Value: jsonValue(rv.Interface())}
 	}
+	return fmt.Sprint(value)
+}
 
+// jsonValue converts nested driver values into something encoding/json renders faithfully.
+func jsonValue(value any) any {
+	switch v := value.(type) {
+	case nil, string, bool, float64, json.Number:
+		return v
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []any:
+		out := make([]any, len(v))
+		for i, element := range v {
+			out[i] = jsonValue(element)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for key, element := range v {
+			out[key] = jsonValue(element)
+		}
+		return out
+	}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Map {
+		out := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out[fmt.Sprint(iter.Key().Interface())] = jsonValue(iter.Value().Interface())
+		}
+		return out
+	}
+	if payload, err := json.Marshal(value); err == nil {
+		var document any
+		if json.Unmarshal(payload, &document) == nil {
+			return document
+		}
+	}
 	return fmt.Sprint(value)
 }
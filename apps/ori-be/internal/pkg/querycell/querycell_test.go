@@ -0,0 +1,72 @@
+package querycell
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestEncode(t *testing.T) {
+	instant := time.Date(2024, 3, 1, 12, 30, 0, 500, time.FixedZone("", 2*3600))
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "null", value: nil, want: `null`},
+		{name: "text stays text", value: "1", want: `"1"`},
+		{name: "integer is a number", value: int32(1), want: `1`},
+		{name: "unsafe integer is a decimal", value: int64(math.MaxInt64), want: `{"$type":"decimal","value":"9223372036854775807"}`},
+		{name: "big integer is a decimal", value: new(big.Int).Lsh(big.NewInt(1), 70), want: `{"$type":"decimal","value":"1180591620717411303424"}`},
+		{name: "float is a number", value: 1.5, want: `1.5`},
+		{name: "nan is a decimal", value: math.NaN(), want: `{"$type":"decimal","value":"NaN"}`},
+		{name: "bool is a bool", value: true, want: `true`},
+		{name: "bytes are base64 with length", value: []byte{0xff, 0x00}, want: `{"$type":"bytes","value":"/wA=","length":2}`},
+		{name: "time keeps its zone", value: instant, want: `{"$type":"timestamptz","value":"2024-03-01T12:30:00.0000005+02:00"}`},
+		{name: "slice is an array", value: []any{int64(1), nil, "x"}, want: `{"$type":"array","value":[1,null,"x"]}`},
+		{name: "map is json", value: map[string]any{"a": []any{int64(1)}}, want: `{"$type":"json","value":{"a":[1]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.Marshal(Encode(tt.value))
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(payload) != tt.want {
+				t.Fatalf("Encode(%#v) = %s, want %s", tt.value, payload, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaggedConstructors(t *testing.T) {
+	tests := []struct {
+		name string
+		cell any
+		want string
+	}{
+		{name: "json text is parsed", cell: JSON([]byte(`{"a":1}`)), want: `{"$type":"json","value":{"a":1}}`},
+		{name: "json keeps large integers", cell: JSON(`{"id": 9007199254740993}`), want: `{"$type":"json","value":{"id":9007199254740993}}`},
+		{name: "invalid json stays text", cell: JSON("{oops"), want: `"{oops"`},
+		{name: "trailing data stays text", cell: JSON(`{"a":1} {}`), want: `"{\"a\":1} {}"`},
+		{name: "uuid from raw bytes", cell: UUID([]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0}), want: `{"$type":"uuid","value":"12345678-9abc-def0-1234-56789abcdef0"}`},
+		{name: "timestamp without zone", cell: Timestamp(time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC), false), want: `{"$type":"timestamp","value":"2024-03-01T12:30:00"}`},
+		{name: "date", cell: Date(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)), want: `{"$type":"date","value":"2024-03-01"}`},
+		{name: "empty array", cell: Array(nil, "int4"), want: `{"$type":"array","value":[],"elementType":"int4"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := json.Marshal(tt.cell)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(payload) != tt.want {
+				t.Fatalf("cell = %s, want %s", payload, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package sqlutil
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParamShift records a named parameter BindNamed replaced with a positional placeholder.
+type ParamShift struct {
+	// Offset is the byte offset of the placeholder in the bound query.
+	Offset int
+	// Bound and Source are the byte lengths of the placeholder and of the parameter it replaced.
+	Bound  int
+	Source int
+}
+
+// SourceOffset maps a byte offset of a bound query back to the query it was bound
+// from. Offsets inside a placeholder map to the start of its parameter.
+func SourceOffset(shifts []ParamShift, offset int) int {
+	delta := 0
+	for _, shift := range shifts {
+		if offset < shift.Offset+shift.Bound {
+			offset = min(offset, shift.Offset)
+			break
+		}
+		delta += shift.Source - shift.Bound
+	}
+	return offset + delta
+}
+
+// BindNamed rewrites :name and @name parameters into the dialect's positional
+// placeholders and returns the matching argument list, along with the shifts
+// that map offsets of the bound query back to the original. A name used several
+// times is bound to a single argument. Every placeholder must have a value
+// and every value must be used. Parameters are the TokenParameter tokens of
+// Tokenize, so literals, comments and casts are never bound.
+func BindNamed(query string, params map[string]any, dialect Dialect) (string, []any, []ParamShift, error) {
+	var (
+		builder strings.Builder
+		args    []any
+		shifts  []ParamShift
+		missing []string
+	)
+	positions := make(map[string]int)
+	used := make(map[string]struct{})
+
+	last := 0
+	for _, token := range Tokenize(query, dialect) {
+		if token.Kind != TokenParameter || (token.Text[0] != ':' && token.Text[0] != '@') {
+			continue
+		}
+		name := token.Text[1:]
+		value, ok := params[name]
+		if !ok {
+			if _, reported := positions[name]; !reported {
+				positions[name] = 0
+				missing = append(missing, token.Text)
+			}
+			continue
+		}
+		used[name] = struct{}{}
+		position, seen := positions[name]
+		if !seen {
+			args = append(args, value)
+			position = len(args)
+			positions[name] = position
+		}
+		builder.WriteString(query[last:token.Offset])
+		placeholder := dialect.placeholder(position)
+		shifts = append(shifts, ParamShift{Offset: builder.Len(), Bound: len(placeholder), Source: len(token.Text)})
+		builder.WriteString(placeholder)
+		last = token.Offset + len(token.Text)
+	}
+	builder.WriteString(query[last:])
+
+	if len(missing) > 0 {
+		return "", nil, nil, fmt.Errorf("missing value for parameter %s", strings.Join(missing, ", "))
+	}
+	var unused []string
+	for name := range params {
+		if _, ok := used[name]; !ok {
+			unused = append(unused, name)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return "", nil, nil, fmt.Errorf("unused parameter %s", strings.Join(unused, ", "))
+	}
+	return builder.String(), args, shifts, nil
+}
+
+func (d Dialect) placeholder(position int) string {
+	if d == DialectSQLite {
+		return "?" + strconv.Itoa(position)
+	}
+	return "$" + strconv.Itoa(position)
+}
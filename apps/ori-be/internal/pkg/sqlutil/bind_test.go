@@ -0,0 +1,110 @@
+package sqlutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBindNamed(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   Dialect
+		query     string
+		params    map[string]any
+		wantQuery string
+		wantArgs  []any
+		wantErr   string
+	}{
+		{
+			name:      "binds colon and at names in postgres",
+			dialect:   DialectPostgres,
+			query:     "SELECT * FROM t WHERE a = :a AND b = @b AND c = :a",
+			params:    map[string]any{"a": 1, "b": "x"},
+			wantQuery: "SELECT * FROM t WHERE a = $1 AND b = $2 AND c = $1",
+			wantArgs:  []any{1, "x"},
+		},
+		{
+			name:      "uses numbered question marks in sqlite",
+			dialect:   DialectSQLite,
+			query:     "UPDATE t SET v = :value WHERE id = :id",
+			params:    map[string]any{"id": 7, "value": true},
+			wantQuery: "UPDATE t SET v = ?1 WHERE id = ?2",
+			wantArgs:  []any{true, 7},
+		},
+		{
+			name:      "uses dollar placeholders in duckdb",
+			dialect:   DialectDuckDB,
+			query:     "SELECT :x",
+			params:    map[string]any{"x": 1.5},
+			wantQuery: "SELECT $1",
+			wantArgs:  []any{1.5},
+		},
+		{
+			name:      "skips literals comments casts and slices",
+			dialect:   DialectPostgres,
+			query:     "SELECT ':a', \":a\", arr[lo:hi], v::text, $$ :a $$ -- :a\n, :a /* @a */",
+			params:    map[string]any{"a": 1},
+			wantQuery: "SELECT ':a', \":a\", arr[lo:hi], v::text, $$ :a $$ -- :a\n, $1 /* @a */",
+			wantArgs:  []any{1},
+		},
+		{
+			name:      "leaves operators alone",
+			dialect:   DialectPostgres,
+			query:     "SELECT doc @@to_tsquery(:q), tags @> :tags",
+			params:    map[string]any{"q": "x", "tags": "{a}"},
+			wantQuery: "SELECT doc @@to_tsquery($1), tags @> $2",
+			wantArgs:  []any{"x", "{a}"},
+		},
+		{
+			name:      "binds names right after operators",
+			dialect:   DialectPostgres,
+			query:     "SELECT * FROM t WHERE a=:a AND b<>@b",
+			params:    map[string]any{"a": 1, "b": 2},
+			wantQuery: "SELECT * FROM t WHERE a=$1 AND b<>$2",
+			wantArgs:  []any{1, 2},
+		},
+		{
+			name:    "rejects missing names",
+			dialect: DialectPostgres,
+			query:   "SELECT :a, :b",
+			params:  map[string]any{"a": 1},
+			wantErr: "missing value for parameter :b",
+		},
+		{
+			name:    "rejects unused names",
+			dialect: DialectSQLite,
+			query:   "SELECT :a",
+			params:  map[string]any{"a": 1, "z": 2, "y": 3},
+			wantErr: "unused parameter y, z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, shifts, err := BindNamed(tt.query, tt.params, tt.dialect)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("BindNamed() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("BindNamed() error = %v", err)
+			}
+			if query != tt.wantQuery {
+				t.Fatalf("BindNamed() query = %q, want %q", query, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Fatalf("BindNamed() args = %#v, want %#v", args, tt.wantArgs)
+			}
+			for _, shift := range shifts {
+				if source := SourceOffset(shifts, shift.Offset); tt.query[source] != ':' && tt.query[source] != '@' {
+					t.Fatalf("SourceOffset(%d) = %d, want the start of a parameter", shift.Offset, source)
+				}
+			}
+			if end := SourceOffset(shifts, len(query)); end != len(tt.query) {
+				t.Fatalf("SourceOffset(end) = %d, want %d", end, len(tt.query))
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package sqlutil
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StatementKind names what a statement does after WITH and EXPLAIN prefixes are set aside.
+type StatementKind string
+
+const (
+	KindSelect      StatementKind = "select"
+	KindInsert      StatementKind = "insert"
+	KindUpdate      StatementKind = "update"
+	KindDelete      StatementKind = "delete"
+	KindMerge       StatementKind = "merge"
+	KindCreate      StatementKind = "create"
+	KindAlter       StatementKind = "alter"
+	KindDrop        StatementKind = "drop"
+	KindTruncate    StatementKind = "truncate"
+	KindExplain     StatementKind = "explain"
+	KindPragma      StatementKind = "pragma"
+	KindShow        StatementKind = "show"
+	KindDescribe    StatementKind = "describe"
+	KindSet         StatementKind = "set"
+	KindTransaction StatementKind = "transaction"
+	KindCopy        StatementKind = "copy"
+	KindCall        StatementKind = "call"
+	KindGrant       StatementKind = "grant"
+	KindMaintenance StatementKind = "maintenance"
+	KindOther       StatementKind = "other"
+)
+
+// Classification describes a single SQL statement.
+type Classification struct {
+	// Kind is empty when the statement holds only comments.
+	Kind StatementKind
+	// ReturnsRows reports whether running the statement yields a result set.
+	ReturnsRows bool
+	// Writes reports whether the statement may change data, schema or files on the server.
+	Writes bool
+	// Objects lists the relations and schema objects the statement names, as written
+	// without quotes, in order of first appearance. CTE names and table functions are
+	// left out.
+	Objects []string
+}
+
+// CommandTag describes a finished data-modifying statement the way PostgreSQL tags
+// it, as in "INSERT 0 5" or "UPDATE 3", for engines whose drivers report only the
+// affected row count. Other kinds get no tag.
+func CommandTag(kind StatementKind, rows int64) string {
+	switch kind {
+	case KindInsert:
+		return "INSERT 0 " + strconv.FormatInt(rows, 10)
+	case KindUpdate, KindDelete, KindMerge:
+		return strings.ToUpper(string(kind)) + " " + strconv.FormatInt(rows, 10)
+	default:
+		return ""
+	}
+}
+
+var statementKinds = map[string]StatementKind{
+	"SELECT":     KindSelect,
+	"VALUES":     KindSelect,
+	"TABLE":      KindSelect,
+	"FROM":       KindSelect,
+	"PIVOT":      KindSelect,
+	"UNPIVOT":    KindSelect,
+	"INSERT":     KindInsert,
+	"REPLACE":    KindInsert,
+	"UPDATE":     KindUpdate,
+	"DELETE":     KindDelete,
+	"MERGE":      KindMerge,
+	"CREATE":     KindCreate,
+	"ALTER":      KindAlter,
+	"DROP":       KindDrop,
+	"TRUNCATE":   KindTruncate,
+	"EXPLAIN":    KindExplain,
+	"PRAGMA":     KindPragma,
+	"SHOW":       KindShow,
+	"DESCRIBE":   KindDescribe,
+	"DESC":       KindDescribe,
+	"SUMMARIZE":  KindDescribe,
+	"SET":        KindSet,
+	"RESET":      KindSet,
+	"BEGIN":      KindTransaction,
+	"START":      KindTransaction,
+	"COMMIT":     KindTransaction,
+	"END":        KindTransaction,
+	"ROLLBACK":   KindTransaction,
+	"ABORT":      KindTransaction,
+	"SAVEPOINT":  KindTransaction,
+	"RELEASE":    KindTransaction,
+	"COPY":       KindCopy,
+	"CALL":       KindCall,
+	"GRANT":      KindGrant,
+	"REVOKE":     KindGrant,
+	"VACUUM":     KindMaintenance,
+	"ANALYZE":    KindMaintenance,
+	"ANALYSE":    KindMaintenance,
+	"REINDEX":    KindMaintenance,
+	"CLUSTER":    KindMaintenance,
+	"CHECKPOINT": KindMaintenance,
+	"REFRESH":    KindMaintenance,
+}
+
+// otherWrites are the statements of KindOther that change the database or files on the server.
+var otherWrites = map[string]struct{}{
+	"ATTACH":   {},
+	"COMMENT":  {},
+	"DETACH":   {},
+	"DO":       {},
+	"EXPORT":   {},
+	"IMPORT":   {},
+	"LOCK":     {},
+	"REASSIGN": {},
+	"SECURITY": {},
+}
+
+// otherSilent are the statements of KindOther known not to return rows. Unknown
+// statements are assumed to return rows, which drivers handle for both cases.
+var otherSilent = map[string]struct{}{
+	"ATTACH":     {},
+	"COMMENT":    {},
+	"DEALLOCATE": {},
+	"DETACH":     {},
+	"DISCARD":    {},
+	"DO":         {},
+	"EXPORT":     {},
+	"IMPORT":     {},
+	"INSTALL":    {},
+	"LISTEN":     {},
+	"LOAD":       {},
+	"LOCK":       {},
+	"NOTIFY":     {},
+	"PREPARE":    {},
+	"REASSIGN":   {},
+	"SECURITY":   {},
+	"UNLISTEN":   {},
+	"USE":        {},
+}
+
+// Classify reads a single statement and reports its kind, whether it returns rows,
+// whether it writes and which objects it names. WITH queries are classified by the
+// statement their CTEs feed and write when a CTE does; EXPLAIN writes only when
+// ANALYZE runs a writing statement.
+func Classify(query string, dialect Dialect) Classification {
+	return classifyTokens(statementTokens(query, dialect))
+}
+
+// statementTokens tokenizes query without its trailing semicolons.
+func statementTokens(query string, dialect Dialect) []Token {
+	tokens := Tokenize(query, dialect)
+	for len(tokens) > 0 && tokens[len(tokens)-1].isPunctuation(";") {
+		tokens = tokens[:len(tokens)-1]
+	}
+	return tokens
+}
+
+func classifyTokens(tokens []Token) Classification {
+	if len(tokens) == 0 {
+		return Classification{}
+	}
+	if tokens[0].IsWord("EXPLAIN") {
+		body, analyze := explainBody(tokens)
+		inner := classifyTokens(body)
+		return Classification{
+			Kind:        KindExplain,
+			ReturnsRows: true,
+			Writes:      analyze && inner.Writes,
+			Objects:     inner.Objects,
+		}
+	}
+
+	main, ctes := mainStatement(tokens)
+	if main >= len(tokens) {
+		return Classification{Kind: KindOther, ReturnsRows: true, Objects: collectObjects(tokens, 0, ctes)}
+	}
+	keyword := tokens[main].Value
+	kind, known := statementKinds[keyword]
+	if tokens[main].Kind != TokenWord || !known {
+		kind = KindOther
+	}
+	result := Classification{Kind: kind, Objects: collectObjects(tokens, main, ctes)}
+	cteWrites := modifiesInSubquery(tokens[:main])
+
+	switch kind {
+	case KindSelect:
+		result.ReturnsRows = true
+		// SELECT ... INTO creates a table on PostgreSQL.
+		result.Writes = cteWrites || hasWordAtDepth(tokens[main:], "INTO", tokens[main].Depth)
+	case KindInsert, KindUpdate, KindDelete, KindMerge:
+		result.ReturnsRows = hasWordAtDepth(tokens[main:], "RETURNING", tokens[main].Depth)
+		result.Writes = true
+	case KindCreate, KindAlter, KindDrop, KindTruncate, KindGrant, KindMaintenance, KindCopy:
+		result.Writes = true
+	case KindCall:
+		// Procedures may write and return OUT parameters as a row.
+		result.ReturnsRows = true
+		result.Writes = true
+	case KindPragma:
+		// PRAGMA name = value changes a setting, possibly one stored in the database.
+		assigns := hasAssignment(tokens[main:])
+		result.ReturnsRows = !assigns
+		result.Writes = assigns
+	case KindShow, KindDescribe:
+		result.ReturnsRows = true
+	case KindSet, KindTransaction:
+	default:
+		_, writes := otherWrites[keyword]
+		_, silent := otherSilent[keyword]
+		result.Writes = writes
+		result.ReturnsRows = !silent
+	}
+	return result
+}
+
+// explainBody returns the statement an EXPLAIN wraps and whether ANALYZE runs it.
+// It understands EXPLAIN ANALYZE VERBOSE, EXPLAIN (ANALYZE, FORMAT JSON) and
+// SQLite's EXPLAIN QUERY PLAN.
+func explainBody(tokens []Token) ([]Token, bool) {
+	analyze := false
+	index := 1
+	for index < len(tokens) {
+		token := tokens[index]
+		switch {
+		case token.isPunctuation("("):
+			closing := closingParen(tokens, index)
+			analyze = analyze || analyzeOption(tokens[index+1:closing])
+			index = closing + 1
+		case token.IsWord("ANALYZE") || token.IsWord("ANALYSE"):
+			analyze = true
+			index++
+		case token.IsWord("VERBOSE") || token.IsWord("QUERY") || token.IsWord("PLAN"):
+			index++
+		default:
+			return tokens[index:], analyze
+		}
+	}
+	return nil, analyze
+}
+
+// analyzeOption reports whether a parenthesized EXPLAIN option list turns ANALYZE on.
+func analyzeOption(options []Token) bool {
+	for i, token := range options {
+		if !token.IsWord("ANALYZE") && !token.IsWord("ANALYSE") {
+			continue
+		}
+		if i+1 == len(options) || options[i+1].isPunctuation(",") {
+			return true
+		}
+		value := strings.ToUpper(options[i+1].Text)
+		return value != "FALSE" && value != "OFF" && value != "0"
+	}
+	return false
+}
+
+// mainStatement skips a WITH clause and opening parentheses and returns the index of
+// the main statement's keyword together with the CTE names.
+func mainStatement(tokens []Token) (int, map[string]struct{}) {
+	ctes := map[string]struct{}{}
+	index := 0
+	for index < len(tokens) && tokens[index].isPunctuation("(") {
+		index++
+	}
+	if index >= len(tokens) || !tokens[index].IsWord("WITH") {
+		return index, ctes
+	}
+
+	index++
+	if index < len(tokens) && tokens[index].IsWord("RECURSIVE") {
+		index++
+	}
+	for index < len(tokens) {
+		name := tokens[index]
+		if name.Kind != TokenWord && name.Kind != TokenQuotedIdentifier {
+			return index, ctes
+		}
+		ctes[strings.ToUpper(name.Value)] = struct{}{}
+		index++
+		if index < len(tokens) && tokens[index].isPunctuation("(") {
+			index = closingParen(tokens, index) + 1
+		}
+		if index >= len(tokens) || !tokens[index].IsWord("AS") {
+			return index, ctes
+		}
+		index++
+		for index < len(tokens) && (tokens[index].IsWord("NOT") || tokens[index].IsWord("MATERIALIZED")) {
+			index++
+		}
+		if index >= len(tokens) || !tokens[index].isPunctuation("(") {
+			return index, ctes
+		}
+		index = closingParen(tokens, index) + 1
+		if index >= len(tokens) || !tokens[index].isPunctuation(",") {
+			return index, ctes
+		}
+		index++
+	}
+	return index, ctes
+}
+
+// closingParen returns the index of the parenthesis closing the one at open, or the
+// last index when it is never closed.
+func closingParen(tokens []Token, open int) int {
+	depth := tokens[open].Depth
+	for i := open + 1; i < len(tokens); i++ {
+		if tokens[i].isPunctuation(")") && tokens[i].Depth == depth {
+			return i
+		}
+	}
+	return len(tokens) - 1
+}
+
+// modifiesInSubquery reports whether a parenthesized body, such as a CTE, starts
+// with a data-modifying statement.
+func modifiesInSubquery(tokens []Token) bool {
+	for i := 1; i < len(tokens); i++ {
+		if !tokens[i-1].isPunctuation("(") || tokens[i].Kind != TokenWord {
+			continue
+		}
+		switch tokens[i].Value {
+		case "INSERT", "UPDATE", "DELETE", "MERGE":
+			return true
+		}
+	}
+	return false
+}
+
+func hasWordAtDepth(tokens []Token, word string, depth int) bool {
+	for _, token := range tokens {
+		if token.Depth == depth && token.IsWord(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAssignment(tokens []Token) bool {
+	for _, token := range tokens {
+		if token.Kind == TokenOperator && token.Text == "=" {
+			return true
+		}
+	}
+	return false
+}
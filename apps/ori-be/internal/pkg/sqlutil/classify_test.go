@@ -0,0 +1,147 @@
+package sqlutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+type classifyCase struct {
+	query   string
+	kind    StatementKind
+	rows    bool
+	writes  bool
+	objects []string
+}
+
+func runClassifyCases(t *testing.T, dialect Dialect, tests []classifyCase) {
+	t.Helper()
+	for _, tt := range tests {
+		got := Classify(tt.query, dialect)
+		want := Classification{Kind: tt.kind, ReturnsRows: tt.rows, Writes: tt.writes, Objects: tt.objects}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Classify(%q, %s) = %+v, want %+v", tt.query, dialect, got, want)
+		}
+	}
+}
+
+func TestClassifyPostgres(t *testing.T) {
+	runClassifyCases(t, DialectPostgres, []classifyCase{
+		{query: "", kind: ""},
+		{query: "-- only a comment", kind: ""},
+		{query: "SELECT 1;", kind: KindSelect, rows: true},
+		{query: "select * from public.orders o join customers c on c.id = o.customer_id", kind: KindSelect, rows: true, objects: []string{"public.orders", "customers"}},
+		{query: `SELECT * FROM "Order Items", app."Users" u`, kind: KindSelect, rows: true, objects: []string{"Order Items", "app.Users"}},
+		{query: "SELECT * FROM generate_series(1, 3) g JOIN t USING (id)", kind: KindSelect, rows: true, objects: []string{"t"}},
+		{query: "SELECT extract(year FROM created_at), a IS DISTINCT FROM b FROM events", kind: KindSelect, rows: true, objects: []string{"events"}},
+		{query: "SELECT * INTO archive FROM orders", kind: KindSelect, rows: true, writes: true, objects: []string{"archive", "orders"}},
+		{query: "SELECT * FROM t FOR UPDATE OF t", kind: KindSelect, rows: true, objects: []string{"t"}},
+		{query: "VALUES (1), (2)", kind: KindSelect, rows: true},
+		{query: "TABLE orders", kind: KindSelect, rows: true, objects: []string{"orders"}},
+		{query: "(SELECT 1) UNION (SELECT 2)", kind: KindSelect, rows: true},
+		{query: "WITH recent AS (SELECT * FROM orders) SELECT * FROM recent", kind: KindSelect, rows: true, objects: []string{"orders"}},
+		{query: "WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n) SELECT * FROM n", kind: KindSelect, rows: true},
+		{query: "WITH gone AS (DELETE FROM t RETURNING id) SELECT * FROM gone", kind: KindSelect, rows: true, writes: true, objects: []string{"t"}},
+		{query: "WITH x AS MATERIALIZED (SELECT 1), y AS (SELECT 2) DELETE FROM orders WHERE id IN (SELECT * FROM x)", kind: KindDelete, writes: true, objects: []string{"orders"}},
+		{query: "WITH s AS (SELECT id FROM stale) UPDATE orders SET total = 0 FROM s RETURNING *", kind: KindUpdate, rows: true, writes: true, objects: []string{"stale", "orders"}},
+		{query: "INSERT INTO orders (id) VALUES (1)", kind: KindInsert, writes: true, objects: []string{"orders"}},
+		{query: "INSERT INTO orders SELECT * FROM staging RETURNING id", kind: KindInsert, rows: true, writes: true, objects: []string{"orders", "staging"}},
+		{query: "INSERT INTO t VALUES (1) ON CONFLICT (id) DO UPDATE SET n = 1", kind: KindInsert, writes: true, objects: []string{"t"}},
+		{query: "UPDATE ONLY orders SET total = 0 WHERE id = $1", kind: KindUpdate, writes: true, objects: []string{"orders"}},
+		{query: "DELETE FROM orders USING customers WHERE customers.id = orders.customer_id", kind: KindDelete, writes: true, objects: []string{"orders", "customers"}},
+		{query: "MERGE INTO stock s USING deliveries d ON s.id = d.id WHEN MATCHED THEN UPDATE SET n = d.n", kind: KindMerge, writes: true, objects: []string{"stock", "deliveries"}},
+		{query: "EXPLAIN SELECT * FROM orders", kind: KindExplain, rows: true, objects: []string{"orders"}},
+		{query: "EXPLAIN DELETE FROM orders", kind: KindExplain, rows: true, objects: []string{"orders"}},
+		{query: "EXPLAIN ANALYZE VERBOSE UPDATE orders SET total = 0", kind: KindExplain, rows: true, writes: true, objects: []string{"orders"}},
+		{query: "EXPLAIN (ANALYZE, FORMAT JSON) DELETE FROM orders", kind: KindExplain, rows: true, writes: true, objects: []string{"orders"}},
+		{query: "EXPLAIN (ANALYZE false) DELETE FROM orders", kind: KindExplain, rows: true, objects: []string{"orders"}},
+		{query: "EXPLAIN ANALYZE SELECT 1", kind: KindExplain, rows: true},
+		{query: "SHOW search_path", kind: KindShow, rows: true},
+		{query: "SET search_path = app", kind: KindSet},
+		{query: "RESET ALL", kind: KindSet},
+		{query: "BEGIN", kind: KindTransaction},
+		{query: "ROLLBACK TO SAVEPOINT sp", kind: KindTransaction},
+		{query: "CREATE TABLE IF NOT EXISTS app.items (id int REFERENCES app.orders(id))", kind: KindCreate, writes: true, objects: []string{"app.items", "app.orders"}},
+		{query: "CREATE TABLE copy AS SELECT * FROM orders", kind: KindCreate, writes: true, objects: []string{"copy", "orders"}},
+		{query: "CREATE OR REPLACE VIEW v AS SELECT * FROM orders", kind: KindCreate, writes: true, objects: []string{"v", "orders"}},
+		{query: "CREATE UNIQUE INDEX CONCURRENTLY idx ON orders (id)", kind: KindCreate, writes: true, objects: []string{"idx", "orders"}},
+		{query: "CREATE FUNCTION f() RETURNS int AS $$ SELECT count(*) FROM hidden $$ LANGUAGE sql", kind: KindCreate, writes: true, objects: []string{"f"}},
+		{query: "ALTER TABLE orders DROP COLUMN note", kind: KindAlter, writes: true, objects: []string{"orders"}},
+		{query: "DROP MATERIALIZED VIEW IF EXISTS a, b CASCADE", kind: KindDrop, writes: true, objects: []string{"a", "b"}},
+		{query: "TRUNCATE TABLE a, b RESTART IDENTITY", kind: KindTruncate, writes: true, objects: []string{"a", "b"}},
+		{query: "COMMENT ON TABLE orders IS 'x'", kind: KindOther, writes: true, objects: []string{"orders"}},
+		{query: "GRANT SELECT ON orders TO reader", kind: KindGrant, writes: true, objects: []string{"orders"}},
+		{query: "COPY orders TO STDOUT", kind: KindCopy, writes: true, objects: []string{"orders"}},
+		{query: "COPY (SELECT * FROM orders) TO '/tmp/o.csv'", kind: KindCopy, writes: true, objects: []string{"orders"}},
+		{query: "VACUUM (VERBOSE) orders", kind: KindMaintenance, writes: true, objects: []string{"orders"}},
+		{query: "REFRESH MATERIALIZED VIEW CONCURRENTLY totals", kind: KindMaintenance, writes: true, objects: []string{"totals"}},
+		{query: "CALL archive_orders()", kind: KindCall, rows: true, writes: true},
+		{query: "DO $$ BEGIN DELETE FROM orders; END $$", kind: KindOther, writes: true},
+		{query: "LOCK TABLE orders", kind: KindOther, writes: true, objects: []string{"orders"}},
+		{query: "LISTEN jobs", kind: KindOther},
+		{query: "SELECT '; DROP TABLE t' /* ; DELETE FROM u */", kind: KindSelect, rows: true},
+	})
+}
+
+func TestClassifySQLite(t *testing.T) {
+	runClassifyCases(t, DialectSQLite, []classifyCase{
+		{query: "SELECT * FROM [order items] JOIN `users` ON 1", kind: KindSelect, rows: true, objects: []string{"order items", "users"}},
+		{query: "INSERT OR REPLACE INTO kv VALUES (?1, ?2)", kind: KindInsert, writes: true, objects: []string{"kv"}},
+		{query: "REPLACE INTO kv VALUES (1, 2)", kind: KindInsert, writes: true, objects: []string{"kv"}},
+		{query: "DELETE FROM kv WHERE k = :key RETURNING v", kind: KindDelete, rows: true, writes: true, objects: []string{"kv"}},
+		{query: "UPDATE kv SET v = @value", kind: KindUpdate, writes: true, objects: []string{"kv"}},
+		{query: "EXPLAIN QUERY PLAN DELETE FROM kv", kind: KindExplain, rows: true, objects: []string{"kv"}},
+		{query: "EXPLAIN SELECT * FROM kv", kind: KindExplain, rows: true, objects: []string{"kv"}},
+		{query: "PRAGMA table_info(kv)", kind: KindPragma, rows: true},
+		{query: "PRAGMA main.journal_mode", kind: KindPragma, rows: true},
+		{query: "PRAGMA user_version = 3", kind: KindPragma, writes: true},
+		{query: "BEGIN IMMEDIATE", kind: KindTransaction},
+		{query: "END TRANSACTION", kind: KindTransaction},
+		{query: "ATTACH 'other.db' AS other", kind: KindOther, writes: true},
+		{query: "VACUUM", kind: KindMaintenance, writes: true},
+		{query: "ANALYZE kv", kind: KindMaintenance, writes: true, objects: []string{"kv"}},
+		{query: "CREATE TRIGGER log_kv AFTER INSERT ON kv BEGIN INSERT INTO log VALUES (new.k); END", kind: KindCreate, writes: true, objects: []string{"log_kv", "kv", "log"}},
+		{query: "CREATE TEMP TABLE scratch (x)", kind: KindCreate, writes: true, objects: []string{"scratch"}},
+		{query: "/* a /* b */ SELECT 1", kind: KindSelect, rows: true},
+	})
+}
+
+func TestClassifyDuckDB(t *testing.T) {
+	runClassifyCases(t, DialectDuckDB, []classifyCase{
+		{query: "FROM orders", kind: KindSelect, rows: true, objects: []string{"orders"}},
+		{query: "FROM orders SELECT id", kind: KindSelect, rows: true, objects: []string{"orders"}},
+		{query: "SELECT * FROM 'data/*.parquet'", kind: KindSelect, rows: true},
+		{query: "SELECT * FROM read_csv('orders.csv')", kind: KindSelect, rows: true},
+		{query: "SELECT * FROM a ASOF JOIN b USING (ts)", kind: KindSelect, rows: true, objects: []string{"a", "b"}},
+		{query: "PIVOT sales ON year USING sum(amount)", kind: KindSelect, rows: true},
+		{query: "DESCRIBE orders", kind: KindDescribe, rows: true, objects: []string{"orders"}},
+		{query: "DESCRIBE SELECT * FROM orders", kind: KindDescribe, rows: true, objects: []string{"orders"}},
+		{query: "SUMMARIZE orders", kind: KindDescribe, rows: true, objects: []string{"orders"}},
+		{query: "SHOW TABLES", kind: KindShow, rows: true},
+		{query: "PRAGMA database_size", kind: KindPragma, rows: true},
+		{query: "SET threads = 4", kind: KindSet},
+		{query: "EXPLAIN ANALYZE DELETE FROM orders", kind: KindExplain, rows: true, writes: true, objects: []string{"orders"}},
+		{query: "INSERT INTO orders BY NAME SELECT * FROM staging RETURNING *", kind: KindInsert, rows: true, writes: true, objects: []string{"orders", "staging"}},
+		{query: "CREATE MACRO add(a, b) AS a + b", kind: KindCreate, writes: true, objects: []string{"add"}},
+		{query: "COPY orders TO 'orders.parquet' (FORMAT parquet)", kind: KindCopy, writes: true, objects: []string{"orders"}},
+		{query: "EXPORT DATABASE 'backup'", kind: KindOther, writes: true},
+		{query: "INSTALL httpfs", kind: KindOther},
+		{query: "CHECKPOINT", kind: KindMaintenance, writes: true},
+		{query: "SELECT $tag$ DROP TABLE x $tag$", kind: KindSelect, rows: true},
+	})
+}
+
+func TestCommandTag(t *testing.T) {
+	tests := map[StatementKind]string{
+		KindInsert: "INSERT 0 3",
+		KindUpdate: "UPDATE 3",
+		KindDelete: "DELETE 3",
+		KindMerge:  "MERGE 3",
+		KindSelect: "",
+		KindCreate: "",
+	}
+	for kind, want := range tests {
+		if got := CommandTag(kind, 3); got != want {
+			t.Errorf("CommandTag(%s, 3) = %q, want %q", kind, got, want)
+		}
+	}
+}
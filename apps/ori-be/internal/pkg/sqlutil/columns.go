@@ -0,0 +1,69 @@
+package sqlutil
+
+// GeneratedColumn is a column whose value is computed from an expression.
+type GeneratedColumn struct {
+	Expression string
+	// Stored is set for STORED columns; others are VIRTUAL and computed on read.
+	Stored bool
+}
+
+// GeneratedColumns finds the generated columns a CREATE TABLE statement declares,
+// keyed by column name as written. It serves engines whose catalogs do not keep
+// the generation expression apart from the rest of the column definition.
+func GeneratedColumns(createTable string, dialect Dialect) map[string]GeneratedColumn {
+	tokens := Tokenize(createTable, dialect)
+	start := 0
+	for start < len(tokens) && !tokens[start].isPunctuation("(") {
+		start++
+	}
+
+	columns := make(map[string]GeneratedColumn)
+	for i := start + 1; i < len(tokens) && tokens[i].Depth > 0; {
+		end := i
+		for end < len(tokens) && tokens[end].Depth > 0 && !(tokens[end].Depth == 1 && tokens[end].isPunctuation(",")) {
+			end++
+		}
+		if name, column, ok := generatedColumn(createTable, tokens[i:end]); ok {
+			columns[name] = column
+		}
+		i = end + 1
+	}
+	return columns
+}
+
+// generatedColumn reads a column definition such as
+// "total INT GENERATED ALWAYS AS (price * qty) STORED".
+func generatedColumn(source string, definition []Token) (string, GeneratedColumn, bool) {
+	if len(definition) == 0 {
+		return "", GeneratedColumn{}, false
+	}
+	name := definition[0].Text
+	switch definition[0].Kind {
+	case TokenQuotedIdentifier:
+		name = definition[0].Value
+	case TokenWord:
+	default:
+		return "", GeneratedColumn{}, false
+	}
+
+	for i := 1; i+1 < len(definition); i++ {
+		if definition[i].Depth != 1 || !definition[i].IsWord("AS") || !definition[i+1].isPunctuation("(") {
+			continue
+		}
+		closing := i + 2
+		for closing < len(definition) && !(definition[closing].Depth == 1 && definition[closing].isPunctuation(")")) {
+			closing++
+		}
+		if closing == len(definition) {
+			return "", GeneratedColumn{}, false
+		}
+		column := GeneratedColumn{Expression: source[definition[i+1].Offset+1 : definition[closing].Offset]}
+		for _, token := range definition[closing+1:] {
+			if token.Depth == 1 && token.IsWord("STORED") {
+				column.Stored = true
+			}
+		}
+		return name, column, true
+	}
+	return "", GeneratedColumn{}, false
+}
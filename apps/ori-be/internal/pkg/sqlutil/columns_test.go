@@ -0,0 +1,38 @@
+package sqlutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGeneratedColumns(t *testing.T) {
+	tests := []struct {
+		query   string
+		dialect Dialect
+		want    map[string]GeneratedColumn
+	}{
+		{
+			query:   `CREATE TABLE items (price REAL, qty INT DEFAULT (1), total REAL GENERATED ALWAYS AS (price * qty) STORED, "Label" TEXT AS (upper(name)), CHECK (qty > 0))`,
+			dialect: DialectSQLite,
+			want: map[string]GeneratedColumn{
+				"total": {Expression: "price * qty", Stored: true},
+				"Label": {Expression: "upper(name)"},
+			},
+		},
+		{
+			query:   "CREATE TABLE t(id INTEGER DEFAULT(nextval('seq')), b INTEGER GENERATED ALWAYS AS((a * 2)));",
+			dialect: DialectDuckDB,
+			want:    map[string]GeneratedColumn{"b": {Expression: "(a * 2)"}},
+		},
+		{
+			query:   "CREATE TABLE plain (id INTEGER PRIMARY KEY, note TEXT)",
+			dialect: DialectSQLite,
+			want:    map[string]GeneratedColumn{},
+		},
+	}
+	for _, tt := range tests {
+		if got := GeneratedColumns(tt.query, tt.dialect); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("GeneratedColumns(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
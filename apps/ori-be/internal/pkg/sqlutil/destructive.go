@@ -0,0 +1,94 @@
+package sqlutil
+
+// keptByAlterDrop are the words after ALTER ... DROP that remove something other than a column.
+var keptByAlterDrop = map[string]struct{}{
+	"CHECK":      {},
+	"CONSTRAINT": {},
+	"DEFAULT":    {},
+	"EXPRESSION": {},
+	"FOREIGN":    {},
+	"IDENTITY":   {},
+	"INDEX":      {},
+	"KEY":        {},
+	"NOT":        {},
+	"PRIMARY":    {},
+}
+
+// DestructiveRisks describes what a statement may irreversibly destroy: UPDATE or
+// DELETE without WHERE, DROP, TRUNCATE and ALTER ... DROP COLUMN. The bodies of
+// CTEs are inspected before the main statement.
+func DestructiveRisks(query string, dialect Dialect) []string {
+	tokens := statementTokens(query, dialect)
+	if len(tokens) > 0 && tokens[0].IsWord("EXPLAIN") {
+		body, analyze := explainBody(tokens)
+		if !analyze {
+			return nil
+		}
+		tokens = body
+	}
+	return statementRisks(tokens)
+}
+
+func statementRisks(tokens []Token) []string {
+	main, _ := mainStatement(tokens)
+	var risks []string
+	for _, body := range cteBodies(tokens[:main]) {
+		risks = append(risks, statementRisks(body)...)
+	}
+	if main >= len(tokens) || tokens[main].Kind != TokenWord {
+		return risks
+	}
+	return append(risks, mainStatementRisks(tokens[main:])...)
+}
+
+// cteBodies returns the statements inside the CTE definitions of a WITH clause.
+func cteBodies(tokens []Token) [][]Token {
+	var bodies [][]Token
+	for i := 2; i < len(tokens); i++ {
+		open := tokens[i-1]
+		if !open.isPunctuation("(") || open.Depth != tokens[0].Depth {
+			continue
+		}
+		if !tokens[i-2].IsWord("AS") && !tokens[i-2].IsWord("MATERIALIZED") {
+			continue
+		}
+		bodies = append(bodies, tokens[i:closingParen(tokens, i-1)])
+	}
+	return bodies
+}
+
+func mainStatementRisks(tokens []Token) []string {
+	depth := tokens[0].Depth
+	rest := tokens[1:]
+
+	switch tokens[0].Value {
+	case "DROP":
+		risk := "DROP"
+		for _, token := range rest {
+			if token.Kind != TokenWord {
+				break
+			}
+			risk += " " + token.Value
+			if _, ok := objectTypes[token.Value]; ok {
+				break
+			}
+		}
+		return []string{risk}
+	case "TRUNCATE":
+		return []string{"TRUNCATE"}
+	case "ALTER":
+		for i := 0; i+1 < len(rest); i++ {
+			if rest[i].Depth != depth || !rest[i].IsWord("DROP") {
+				continue
+			}
+			if _, ok := keptByAlterDrop[rest[i+1].Value]; !ok {
+				return []string{"ALTER ... DROP COLUMN"}
+			}
+		}
+	case "UPDATE", "DELETE":
+		if !hasWordAtDepth(rest, "WHERE", depth) {
+			return []string{tokens[0].Value + " without WHERE"}
+		}
+	}
+	return nil
+}
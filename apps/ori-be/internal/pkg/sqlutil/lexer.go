@@ -0,0 +1,268 @@
+package sqlutil
+
+import "strings"
+
+// TokenKind is the lexical class of a Token.
+type TokenKind int
+
+const (
+	// TokenWord is a bare keyword or identifier.
+	TokenWord TokenKind = iota
+	// TokenQuotedIdentifier is a "quoted", `backquoted` or [bracketed] identifier.
+	TokenQuotedIdentifier
+	// TokenString is a string literal, including E'...' and dollar-quoted bodies.
+	TokenString
+	TokenNumber
+	// TokenParameter is a placeholder: ?, ?1, $1, :name or @name.
+	TokenParameter
+	// TokenPunctuation is one of ( ) , ; or a dot between name parts.
+	TokenPunctuation
+	TokenOperator
+)
+
+// Token is a lexical unit of a SQL statement. Comments and whitespace are not tokens.
+type Token struct {
+	Kind TokenKind
+	// Text is the token as written.
+	Text string
+	// Value is the upper-cased word of a TokenWord and the unquoted name of a
+	// TokenQuotedIdentifier. Other tokens repeat Text.
+	Value string
+	// Offset is the byte offset of Text within the query.
+	Offset int
+	// Depth is the number of parentheses the token is nested in. Parentheses
+	// carry the depth of the text around them.
+	Depth int
+}
+
+// IsWord reports whether t is the bare word (keyword) word, given in upper case.
+func (t Token) IsWord(word string) bool {
+	return t.Kind == TokenWord && t.Value == word
+}
+
+func (t Token) isPunctuation(text string) bool {
+	return t.Kind == TokenPunctuation && t.Text == text
+}
+
+// Tokenize splits query into tokens following the lexical rules of dialect:
+// nested block comments and dollar quoting for PostgreSQL, dollar quoting for
+// DuckDB, backquoted and bracketed identifiers for SQLite.
+func Tokenize(query string, dialect Dialect) []Token {
+	var tokens []Token
+	length := len(query)
+	depth := 0
+	index := 0
+	emit := func(kind TokenKind, start, end int, value string) {
+		tokens = append(tokens, Token{Kind: kind, Text: query[start:end], Value: value, Offset: start, Depth: depth})
+		index = end
+	}
+
+	for index < length {
+		char := query[index]
+		start := index
+		switch {
+		case isWhitespace(char) || char == '\f' || char == '\v':
+			index++
+		case char == '-' && index+1 < length && query[index+1] == '-':
+			index = skipLineComment(query, index)
+		case char == '/' && index+1 < length && query[index+1] == '*':
+			index = skipBlockComment(query, index, dialect.nestedComments())
+		case char == '\'':
+			end := skipQuoted(query, index, '\'', false)
+			emit(TokenString, start, end, query[start:end])
+		case (char == 'E' || char == 'e') && dialect.escapeStrings() && index+1 < length && query[index+1] == '\'':
+			end := skipQuoted(query, index+1, '\'', true)
+			emit(TokenString, start, end, query[start:end])
+		case char == '"':
+			end := skipQuoted(query, index, '"', false)
+			emit(TokenQuotedIdentifier, start, end, unquote(query[start:end], '"'))
+		case char == '`' && dialect == DialectSQLite:
+			end := skipQuoted(query, index, '`', false)
+			emit(TokenQuotedIdentifier, start, end, unquote(query[start:end], '`'))
+		case char == '[' && dialect.bracketIdentifiers():
+			end := skipUntil(query, index+1, ']')
+			emit(TokenQuotedIdentifier, start, end, strings.TrimSuffix(query[start+1:end], "]"))
+		case char == '$' && index+1 < length && query[index+1] >= '0' && query[index+1] <= '9':
+			end := index + 1
+			for end < length && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+			emit(TokenParameter, start, end, query[start:end])
+		case char == '$' && dialect.dollarQuoting():
+			if tagEnd, ok := dollarTagEnd(query, index); ok {
+				end := skipDollarQuoted(query, index, query[index:tagEnd])
+				emit(TokenString, start, end, query[start:end])
+			} else {
+				emit(TokenOperator, start, index+1, "$")
+			}
+		case char == '?':
+			end := index + 1
+			for end < length && query[end] >= '0' && query[end] <= '9' {
+				end++
+			}
+			emit(TokenParameter, start, end, query[start:end])
+		case (char == ':' || char == '@') && isNamedParameterStart(query, index):
+			end := index + 1
+			for end < length && isIdentifierChar(query[end]) && query[end] != '$' {
+				end++
+			}
+			emit(TokenParameter, start, end, query[start:end])
+		case isIdentifierStart(char):
+			end := index
+			for end < length && isIdentifierChar(query[end]) {
+				end++
+			}
+			emit(TokenWord, start, end, strings.ToUpper(query[start:end]))
+		case isDigit(char) || (char == '.' && index+1 < length && isDigit(query[index+1])):
+			end := index
+			for end < length && (isDigit(query[end]) || query[end] == '.' || query[end] == '_' || isKeywordChar(query[end])) {
+				end++
+			}
+			emit(TokenNumber, start, end, query[start:end])
+		case char == '(':
+			emit(TokenPunctuation, start, index+1, "(")
+			depth++
+		case char == ')':
+			depth = max(depth-1, 0)
+			emit(TokenPunctuation, start, index+1, ")")
+		case char == ',' || char == ';' || char == '.':
+			emit(TokenPunctuation, start, index+1, query[start:index+1])
+		default:
+			end := index + 1
+			for isOperatorChar(char) && end < length && isOperatorChar(query[end]) && !opensComment(query, end) && !isNamedParameterStart(query, end) {
+				end++
+			}
+			emit(TokenOperator, start, end, query[start:end])
+		}
+	}
+	return tokens
+}
+
+// unquote strips the quotes around an identifier and undoubles escaped quotes.
+func unquote(text string, quote byte) string {
+	if len(text) >= 2 && text[len(text)-1] == quote {
+		text = text[1 : len(text)-1]
+	} else {
+		text = text[1:]
+	}
+	return strings.ReplaceAll(text, string([]byte{quote, quote}), string(quote))
+}
+
+func opensComment(text string, index int) bool {
+	return index+1 < len(text) && (text[index] == '-' && text[index+1] == '-' || text[index] == '/' && text[index+1] == '*')
+}
+
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
+
+func isOperatorChar(char byte) bool {
+	return strings.IndexByte("+-*/<>=~!@#%^&|`?:", char) >= 0
+}
+
+func skipLineComment(text string, index int) int {
+	for index < len(text) && text[index] != '\n' {
+		index++
+	}
+	return index
+}
+
+func skipBlockComment(text string, index int, nested bool) int {
+	depth := 1
+	index += 2
+	for index < len(text) {
+		switch {
+		case text[index] == '*' && index+1 < len(text) && text[index+1] == '/':
+			depth--
+			index += 2
+			if depth == 0 || !nested {
+				return index
+			}
+		case nested && text[index] == '/' && index+1 < len(text) && text[index+1] == '*':
+			depth++
+			index += 2
+		default:
+			index++
+		}
+	}
+	return index
+}
+
+// skipQuoted skips a literal opened at index, treating a doubled quote as an escape.
+func skipQuoted(text string, index int, quote byte, backslashEscapes bool) int {
+	index++
+	for index < len(text) {
+		char := text[index]
+		switch {
+		case backslashEscapes && char == '\\':
+			index += 2
+		case char == quote:
+			if index+1 < len(text) && text[index+1] == quote {
+				index += 2
+				continue
+			}
+			return index + 1
+		default:
+			index++
+		}
+	}
+	return index
+}
+
+func skipUntil(text string, index int, terminator byte) int {
+	for index < len(text) && text[index] != terminator {
+		index++
+	}
+	if index < len(text) {
+		index++
+	}
+	return index
+}
+
+// dollarTagEnd returns the end of a $tag$ opener starting at index.
+func dollarTagEnd(text string, index int) (int, bool) {
+	end := index + 1
+	if end < len(text) && text[end] >= '0' && text[end] <= '9' {
+		return 0, false
+	}
+	for end < len(text) && isIdentifierChar(text[end]) && text[end] != '$' {
+		end++
+	}
+	if end >= len(text) || text[end] != '$' {
+		return 0, false
+	}
+	return end + 1, true
+}
+
+func skipDollarQuoted(text string, index int, tag string) int {
+	bodyStart := index + len(tag)
+	closing := strings.Index(text[bodyStart:], tag)
+	if closing < 0 {
+		return len(text)
+	}
+	return bodyStart + closing + len(tag)
+}
+
+func isIdentifierStart(char byte) bool {
+	return isKeywordChar(char) || char == '_' || char >= 0x80
+}
+
+func isIdentifierChar(char byte) bool {
+	return isIdentifierStart(char) || (char >= '0' && char <= '9') || char == '$'
+}
+
+// isNamedParameterStart reports whether the ':' or '@' at index opens a named parameter.
+// Array slices (a[lo:hi]) and operators such as @@ are left untouched.
+func isNamedParameterStart(query string, index int) bool {
+	if query[index] != ':' && query[index] != '@' {
+		return false
+	}
+	if index+1 >= len(query) || !isIdentifierStart(query[index+1]) {
+		return false
+	}
+	if index == 0 {
+		return true
+	}
+	previous := query[index-1]
+	return !isIdentifierChar(previous) && previous != ':' && previous != '@'
+}
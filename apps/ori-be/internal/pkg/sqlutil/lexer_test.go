@@ -0,0 +1,129 @@
+package sqlutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	type token struct {
+		Kind  TokenKind
+		Value string
+		Depth int
+	}
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    []token
+	}{
+		{
+			name:    "words are upper-cased and literals kept",
+			dialect: DialectPostgres,
+			query:   "select 'it''s', 1.5e3 from t",
+			want: []token{
+				{TokenWord, "SELECT", 0},
+				{TokenString, "'it''s'", 0},
+				{TokenPunctuation, ",", 0},
+				{TokenNumber, "1.5e3", 0},
+				{TokenWord, "FROM", 0},
+				{TokenWord, "T", 0},
+			},
+		},
+		{
+			name:    "comments are skipped and nested on postgres",
+			dialect: DialectPostgres,
+			query:   "/* a /* b */ delete */ SELECT -- drop\n1",
+			want:    []token{{TokenWord, "SELECT", 0}, {TokenNumber, "1", 0}},
+		},
+		{
+			name:    "block comments do not nest on sqlite",
+			dialect: DialectSQLite,
+			query:   "/* a /* b */ x */ SELECT 1",
+			want: []token{
+				{TokenWord, "X", 0}, {TokenOperator, "*/", 0}, {TokenWord, "SELECT", 0}, {TokenNumber, "1", 0},
+			},
+		},
+		{
+			name:    "dollar-quoted bodies are one string",
+			dialect: DialectPostgres,
+			query:   "DO $body$ BEGIN DELETE FROM t; END $body$",
+			want:    []token{{TokenWord, "DO", 0}, {TokenString, "$body$ BEGIN DELETE FROM t; END $body$", 0}},
+		},
+		{
+			name:    "escape strings honour backslashes",
+			dialect: DialectPostgres,
+			query:   `SELECT E'a\'b', x`,
+			want: []token{
+				{TokenWord, "SELECT", 0}, {TokenString, `E'a\'b'`, 0}, {TokenPunctuation, ",", 0}, {TokenWord, "X", 0},
+			},
+		},
+		{
+			name:    "quoted identifiers are unquoted",
+			dialect: DialectSQLite,
+			query:   "SELECT \"a\"\"b\", `c`, [d e]",
+			want: []token{
+				{TokenWord, "SELECT", 0},
+				{TokenQuotedIdentifier, `a"b`, 0},
+				{TokenPunctuation, ",", 0},
+				{TokenQuotedIdentifier, "c", 0},
+				{TokenPunctuation, ",", 0},
+				{TokenQuotedIdentifier, "d e", 0},
+			},
+		},
+		{
+			name:    "parameters and casts",
+			dialect: DialectPostgres,
+			query:   "WHERE a = $1 AND b = :name AND c::text = ?",
+			want: []token{
+				{TokenWord, "WHERE", 0},
+				{TokenWord, "A", 0},
+				{TokenOperator, "=", 0},
+				{TokenParameter, "$1", 0},
+				{TokenWord, "AND", 0},
+				{TokenWord, "B", 0},
+				{TokenOperator, "=", 0},
+				{TokenParameter, ":name", 0},
+				{TokenWord, "AND", 0},
+				{TokenWord, "C", 0},
+				{TokenOperator, "::", 0},
+				{TokenWord, "TEXT", 0},
+				{TokenOperator, "=", 0},
+				{TokenParameter, "?", 0},
+			},
+		},
+		{
+			name:    "operators stop before named parameters",
+			dialect: DialectPostgres,
+			query:   "a=:b",
+			want:    []token{{TokenWord, "A", 0}, {TokenOperator, "=", 0}, {TokenParameter, ":b", 0}},
+		},
+		{
+			name:    "parentheses track depth",
+			dialect: DialectDuckDB,
+			query:   "f((a), b)",
+			want: []token{
+				{TokenWord, "F", 0},
+				{TokenPunctuation, "(", 0},
+				{TokenPunctuation, "(", 1},
+				{TokenWord, "A", 2},
+				{TokenPunctuation, ")", 1},
+				{TokenPunctuation, ",", 1},
+				{TokenWord, "B", 1},
+				{TokenPunctuation, ")", 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []token
+			for _, tok := range Tokenize(tt.query, tt.dialect) {
+				got = append(got, token{tok.Kind, tok.Value, tok.Depth})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Tokenize(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package sqlutil
+
+import "strings"
+
+// objectTypes are the words after CREATE, ALTER, DROP and COMMENT ON that precede an object name.
+var objectTypes = map[string]struct{}{
+	"DATABASE":  {},
+	"EXTENSION": {},
+	"FUNCTION":  {},
+	"INDEX":     {},
+	"MACRO":     {},
+	"PROCEDURE": {},
+	"SCHEMA":    {},
+	"SEQUENCE":  {},
+	"TABLE":     {},
+	"TRIGGER":   {},
+	"TYPE":      {},
+	"VIEW":      {},
+}
+
+// reservedWords cannot be object names or aliases, which lets name lists stop at them.
+var reservedWords = map[string]struct{}{
+	"ALL":          {},
+	"AND":          {},
+	"ANTI":         {},
+	"AS":           {},
+	"ASOF":         {},
+	"BY":           {},
+	"CASCADE":      {},
+	"COLUMN":       {},
+	"CONCURRENTLY": {},
+	"CONFLICT":     {},
+	"CONSTRAINT":   {},
+	"CROSS":        {},
+	"DEFAULT":      {},
+	"DO":           {},
+	"EXCEPT":       {},
+	"EXISTS":       {},
+	"FETCH":        {},
+	"FOR":          {},
+	"FROM":         {},
+	"FULL":         {},
+	"GROUP":        {},
+	"HAVING":       {},
+	"IF":           {},
+	"INNER":        {},
+	"INTERSECT":    {},
+	"INTO":         {},
+	"JOIN":         {},
+	"LATERAL":      {},
+	"LEFT":         {},
+	"LIMIT":        {},
+	"NATURAL":      {},
+	"NOT":          {},
+	"OF":           {},
+	"OFFSET":       {},
+	"ON":           {},
+	"ONLY":         {},
+	"OR":           {},
+	"ORDER":        {},
+	"OUTER":        {},
+	"OVERRIDING":   {},
+	"PARTITION":    {},
+	"POSITIONAL":   {},
+	"QUALIFY":      {},
+	"RESTRICT":     {},
+	"RETURNING":    {},
+	"RIGHT":        {},
+	"SELECT":       {},
+	"SEMI":         {},
+	"SET":          {},
+	"TABLESAMPLE":  {},
+	"THEN":         {},
+	"TO":           {},
+	"UNION":        {},
+	"USING":        {},
+	"VALUES":       {},
+	"WHEN":         {},
+	"WHERE":        {},
+	"WINDOW":       {},
+	"WITH":         {},
+}
+
+// nameModifiers may sit between a keyword and the object name it introduces.
+var nameModifiers = map[string]struct{}{
+	"CONCURRENTLY": {},
+	"EXISTS":       {},
+	"IF":           {},
+	"NOT":          {},
+	"ONLY":         {},
+}
+
+// headerModifiers may sit between CREATE, DROP or a maintenance keyword and the object type.
+var headerModifiers = map[string]struct{}{
+	"ANALYZE":      {},
+	"FOREIGN":      {},
+	"FREEZE":       {},
+	"FULL":         {},
+	"GLOBAL":       {},
+	"LOCAL":        {},
+	"MATERIALIZED": {},
+	"OR":           {},
+	"RECURSIVE":    {},
+	"REPLACE":      {},
+	"TEMP":         {},
+	"TEMPORARY":    {},
+	"UNIQUE":       {},
+	"UNLOGGED":     {},
+	"VERBOSE":      {},
+}
+
+// fromFunctions take FROM as an argument separator, as in extract(year FROM ts).
+var fromFunctions = map[string]struct{}{
+	"EXTRACT":   {},
+	"OVERLAY":   {},
+	"SUBSTR":    {},
+	"SUBSTRING": {},
+	"TRIM":      {},
+}
+
+// collectObjects gathers the objects a statement names: relations after FROM, JOIN,
+// INTO, UPDATE, REFERENCES and USING, and the object a DDL or utility statement
+// acts on.
+func collectObjects(tokens []Token, main int, ctes map[string]struct{}) []string {
+	collector := objectCollector{tokens: tokens, ctes: ctes, seen: map[string]struct{}{}}
+	keyword := ""
+	if main < len(tokens) && tokens[main].Kind == TokenWord {
+		keyword = tokens[main].Value
+	}
+
+	switch keyword {
+	case "CREATE", "ALTER", "DROP", "TRUNCATE", "COMMENT", "LOCK", "VACUUM", "ANALYZE", "ANALYSE",
+		"REINDEX", "CLUSTER", "REFRESH", "COPY", "DESCRIBE", "DESC", "SUMMARIZE", "TABLE":
+		collector.header(main + 1)
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		if token.Kind != TokenWord {
+			continue
+		}
+		switch token.Value {
+		case "FROM":
+			if !collector.isArgumentFrom(i) {
+				collector.list(i+1, true)
+			}
+		case "JOIN", "INTO", "REFERENCES":
+			collector.name(i+1, token.Value == "JOIN")
+		case "UPDATE":
+			if i == main || (i > 0 && tokens[i-1].isPunctuation("(")) {
+				collector.name(i+1, false)
+			}
+		case "USING":
+			if keyword == "DELETE" || keyword == "MERGE" {
+				collector.name(i+1, true)
+			}
+		case "ON":
+			if keyword == "CREATE" || keyword == "GRANT" || keyword == "REVOKE" || keyword == "COMMENT" {
+				collector.header(i + 1)
+			}
+		}
+	}
+	return collector.objects
+}
+
+type objectCollector struct {
+	tokens  []Token
+	ctes    map[string]struct{}
+	seen    map[string]struct{}
+	objects []string
+}
+
+// header reads the object named after a statement keyword, skipping modifiers and the
+// object type: DROP MATERIALIZED VIEW IF EXISTS v, TRUNCATE TABLE a, b.
+func (c *objectCollector) header(index int) {
+	for index < len(c.tokens) && c.tokens[index].Kind == TokenWord {
+		value := c.tokens[index].Value
+		_, modifier := headerModifiers[value]
+		_, objectType := objectTypes[value]
+		if !modifier && !objectType {
+			break
+		}
+		index++
+	}
+	if index < len(c.tokens) && c.tokens[index].isPunctuation("(") {
+		// VACUUM (VERBOSE) t
+		index = closingParen(c.tokens, index) + 1
+	}
+	c.list(index, false)
+}
+
+// list reads a comma-separated list of names with optional aliases.
+func (c *objectCollector) list(index int, relation bool) {
+	for {
+		next, ok := c.name(index, relation)
+		if !ok {
+			return
+		}
+		next = c.skipAlias(next)
+		if next >= len(c.tokens) || !c.tokens[next].isPunctuation(",") {
+			return
+		}
+		index = next + 1
+	}
+}
+
+// name records the possibly qualified name starting at index and returns the index
+// after it. A relation name followed by a parenthesis is a table function and is not
+// recorded.
+func (c *objectCollector) name(index int, relation bool) (int, bool) {
+	for index < len(c.tokens) && c.tokens[index].Kind == TokenWord {
+		if _, ok := nameModifiers[c.tokens[index].Value]; !ok {
+			break
+		}
+		index++
+	}
+
+	var parts []string
+	for index < len(c.tokens) {
+		part, ok := identifier(c.tokens[index])
+		if !ok {
+			break
+		}
+		parts = append(parts, part)
+		index++
+		if index+1 >= len(c.tokens) || !c.tokens[index].isPunctuation(".") {
+			break
+		}
+		index++
+	}
+	if len(parts) == 0 {
+		return index, false
+	}
+	if relation && index < len(c.tokens) && c.tokens[index].isPunctuation("(") {
+		return closingParen(c.tokens, index) + 1, false
+	}
+
+	name := strings.Join(parts, ".")
+	if _, cte := c.ctes[strings.ToUpper(name)]; !cte {
+		if _, seen := c.seen[name]; !seen {
+			c.seen[name] = struct{}{}
+			c.objects = append(c.objects, name)
+		}
+	}
+	return index, true
+}
+
+// isArgumentFrom reports whether the FROM at index separates function arguments or
+// belongs to IS DISTINCT FROM rather than introducing relations.
+func (c *objectCollector) isArgumentFrom(index int) bool {
+	if index > 0 && c.tokens[index-1].IsWord("DISTINCT") {
+		return true
+	}
+	depth := c.tokens[index].Depth
+	for i := index - 1; i > 0 && depth > 0; i-- {
+		if c.tokens[i].isPunctuation("(") && c.tokens[i].Depth == depth-1 {
+			_, ok := fromFunctions[c.tokens[i-1].Value]
+			return ok && c.tokens[i-1].Kind == TokenWord
+		}
+	}
+	return false
+}
+
+func (c *objectCollector) skipAlias(index int) int {
+	if index < len(c.tokens) && c.tokens[index].IsWord("AS") {
+		index++
+	}
+	if index < len(c.tokens) {
+		if _, ok := identifier(c.tokens[index]); ok {
+			index++
+		}
+	}
+	return index
+}
+
+// identifier returns the name a token spells when it can name an object.
+func identifier(token Token) (string, bool) {
+	switch token.Kind {
+	case TokenQuotedIdentifier:
+		return token.Value, true
+	case TokenWord:
+		if _, reserved := reservedWords[token.Value]; reserved {
+			return "", false
+		}
+		if _, objectType := objectTypes[token.Value]; objectType {
+			return "", false
+		}
+		return token.Text, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,136 @@
+package sqlutil
+
+import "strings"
+
+// Dialect selects the lexical rules used when reading SQL text.
+type Dialect string
+
+const (
+	DialectPostgres Dialect = "postgres"
+	DialectSQLite   Dialect = "sqlite"
+	DialectDuckDB   Dialect = "duckdb"
+)
+
+// DialectForResourceType maps a resource type to the dialect of its SQL.
+func DialectForResourceType(resourceType string) Dialect {
+	switch strings.ToLower(resourceType) {
+	case "postgres", "postgresql":
+		return DialectPostgres
+	case "sqlite":
+		return DialectSQLite
+	case "duckdb":
+		return DialectDuckDB
+	default:
+		return Dialect(strings.ToLower(resourceType))
+	}
+}
+
+func (d Dialect) dollarQuoting() bool {
+	return d == DialectPostgres || d == DialectDuckDB
+}
+
+func (d Dialect) escapeStrings() bool {
+	return d == DialectPostgres || d == DialectDuckDB
+}
+
+func (d Dialect) nestedComments() bool {
+	return d == DialectPostgres
+}
+
+func (d Dialect) bracketIdentifiers() bool {
+	return d == DialectSQLite
+}
+
+// Statement is a single statement of a script.
+type Statement struct {
+	// Text is the statement without surrounding whitespace or its terminating semicolon.
+	Text string
+	// Offset is the byte offset of Text within the script.
+	Offset int
+	// Line is the 1-based line on which Text starts.
+	Line int
+}
+
+// SplitStatements splits a script on top-level semicolons. Semicolons inside
+// string literals, quoted identifiers, comments, dollar-quoted bodies,
+// BEGIN ATOMIC blocks and SQLite trigger bodies do not end a statement.
+// Fragments holding only whitespace and comments are dropped. The script is
+// read with Tokenize, so statements end where the classifier expects them to.
+func SplitStatements(script string, dialect Dialect) []Statement {
+	splitter := scriptSplitter{script: script, dialect: dialect}
+	return splitter.split()
+}
+
+type scriptSplitter struct {
+	script  string
+	dialect Dialect
+
+	statements []Statement
+	start      int
+	tokens     int
+
+	// Per-statement keyword state used to detect block bodies.
+	firstKeyword string
+	prevKeyword  string
+	sawTrigger   bool
+	blockDepth   int
+}
+
+func (s *scriptSplitter) split() []Statement {
+	for _, token := range Tokenize(s.script, s.dialect) {
+		switch {
+		case token.isPunctuation(";") && s.blockDepth == 0:
+			s.emit(token.Offset)
+			s.start = token.Offset + 1
+		case token.Kind == TokenWord:
+			s.tokens++
+			s.observeKeyword(token.Value)
+		default:
+			s.tokens++
+		}
+	}
+	s.emit(len(s.script))
+	return s.statements
+}
+
+// observeKeyword tracks the keywords that open and close compound bodies.
+func (s *scriptSplitter) observeKeyword(keyword string) {
+	if s.firstKeyword == "" {
+		s.firstKeyword = keyword
+	}
+	if keyword == "TRIGGER" {
+		s.sawTrigger = true
+	}
+
+	switch {
+	case keyword == "ATOMIC" && s.prevKeyword == "BEGIN" && s.dialect != DialectSQLite:
+		s.blockDepth++
+	case keyword == "BEGIN" && s.dialect == DialectSQLite && s.firstKeyword == "CREATE" && s.sawTrigger:
+		s.blockDepth++
+	case keyword == "CASE" && s.blockDepth > 0:
+		s.blockDepth++
+	case keyword == "END" && s.blockDepth > 0:
+		s.blockDepth--
+	}
+	s.prevKeyword = keyword
+}
+
+// emit records the statement ending at end unless it holds no tokens, only
+// whitespace and comments.
+func (s *scriptSplitter) emit(end int) {
+	if s.tokens > 0 {
+		raw := s.script[s.start:end]
+		trimmed := strings.TrimSpace(raw)
+		offset := s.start + strings.Index(raw, trimmed)
+		s.statements = append(s.statements, Statement{
+			Text:   trimmed,
+			Offset: offset,
+			Line:   1 + strings.Count(s.script[:offset], "\n"),
+		})
+	}
+	s.tokens = 0
+	s.firstKeyword = ""
+	s.prevKeyword = ""
+	s.sawTrigger = false
+	s.blockDepth = 0
+}
@@ -0,0 +1,105 @@
+package sqlutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		script  string
+		want    []string
+	}{
+		{
+			name:    "splits on top-level semicolons",
+			dialect: DialectPostgres,
+			script:  "SELECT 1; SELECT 2;\nSELECT 3",
+			want:    []string{"SELECT 1", "SELECT 2", "SELECT 3"},
+		},
+		{
+			name:    "drops empty and comment-only fragments",
+			dialect: DialectPostgres,
+			script:  ";; -- trailing comment\nSELECT 1;\n/* done */",
+			want:    []string{"-- trailing comment\nSELECT 1"},
+		},
+		{
+			name:    "ignores semicolons in literals and identifiers",
+			dialect: DialectPostgres,
+			script:  `SELECT 'a;b', "c;d", 'it''s;'; SELECT E'\';'`,
+			want:    []string{`SELECT 'a;b', "c;d", 'it''s;'`, `SELECT E'\';'`},
+		},
+		{
+			name:    "ignores semicolons in comments",
+			dialect: DialectPostgres,
+			script:  "SELECT 1 -- ;\n; /* ; /* nested; */ ; */ SELECT 2",
+			want:    []string{"SELECT 1 -- ;", "/* ; /* nested; */ ; */ SELECT 2"},
+		},
+		{
+			name:    "keeps dollar-quoted bodies intact",
+			dialect: DialectPostgres,
+			script:  "CREATE FUNCTION f() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql; SELECT $1, $$;$$",
+			want: []string{
+				"CREATE FUNCTION f() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql",
+				"SELECT $1, $$;$$",
+			},
+		},
+		{
+			name:    "keeps BEGIN ATOMIC bodies intact",
+			dialect: DialectPostgres,
+			script:  "CREATE FUNCTION f(x int) RETURNS int LANGUAGE sql BEGIN ATOMIC SELECT CASE WHEN x > 0 THEN 1 ELSE 0 END; SELECT 2; END; SELECT 3",
+			want: []string{
+				"CREATE FUNCTION f(x int) RETURNS int LANGUAGE sql BEGIN ATOMIC SELECT CASE WHEN x > 0 THEN 1 ELSE 0 END; SELECT 2; END",
+				"SELECT 3",
+			},
+		},
+		{
+			name:    "treats BEGIN and END as transaction control outside bodies",
+			dialect: DialectPostgres,
+			script:  "BEGIN; UPDATE t SET a = 1; END;",
+			want:    []string{"BEGIN", "UPDATE t SET a = 1", "END"},
+		},
+		{
+			name:    "keeps sqlite trigger bodies intact",
+			dialect: DialectSQLite,
+			script:  "CREATE TRIGGER tr AFTER INSERT ON t BEGIN UPDATE t SET a = 1; DELETE FROM u; END; SELECT [a;b] FROM `t;`",
+			want: []string{
+				"CREATE TRIGGER tr AFTER INSERT ON t BEGIN UPDATE t SET a = 1; DELETE FROM u; END",
+				"SELECT [a;b] FROM `t;`",
+			},
+		},
+		{
+			name:    "does not treat dollar signs as quotes in sqlite",
+			dialect: DialectSQLite,
+			script:  "SELECT $a; SELECT 2",
+			want:    []string{"SELECT $a", "SELECT 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := SplitStatements(tt.script, tt.dialect)
+			got := make([]string, len(statements))
+			for i, statement := range statements {
+				got[i] = statement.Text
+				if tt.script[statement.Offset:statement.Offset+len(statement.Text)] != statement.Text {
+					t.Fatalf("statement %d offset %d does not point at its text", i, statement.Offset)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("SplitStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitStatementsReportsLines(t *testing.T) {
+	statements := SplitStatements("SELECT 1;\n\n  SELECT\n 2;", DialectDuckDB)
+	if len(statements) != 2 {
+		t.Fatalf("got %d statements, want 2", len(statements))
+	}
+	if statements[0].Line != 1 || statements[1].Line != 3 {
+		t.Fatalf("lines = %d, %d, want 1, 3", statements[0].Line, statements[1].Line)
+	}
+}
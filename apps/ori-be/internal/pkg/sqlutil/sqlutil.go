@@ -1,81 +1,60 @@
 package sqlutil
 
-import "strings"
-
-var nonRowKeywords = map[string]struct{}{
-	"ALTER":    {},
-	"ANALYZE":  {},
-	"BEGIN":    {},
-	"COMMIT":   {},
-	"COPY":     {},
-	"CREATE":   {},
-	"DELETE":   {},
-	"DROP":     {},
-	"END":      {},
-	"GRANT":    {},
-	"INSERT":   {},
-	"REVOKE":   {},
-	"ROLLBACK": {},
-	"SET":      {},
-	"TRUNCATE": {},
-	"UPDATE":   {},
-	"VACUUM":   {},
+// IsRowReturningQuery reports whether running query yields a result set, such as a
+// SELECT, a write with RETURNING, EXPLAIN, SHOW or PRAGMA.
+func IsRowReturningQuery(query string, dialect Dialect) bool {
+	return Classify(query, dialect).ReturnsRows
 }
 
-// IsRowReturningQuery returns true unless the query is clearly non-row.
-func IsRowReturningQuery(query string) bool {
-	keyword := firstKeyword(query)
-	if keyword == "" {
-		return false
-	}
-	_, isNonRow := nonRowKeywords[keyword]
-	return !isNonRow
+// IsCursorQuery reports whether query is a read-only SELECT, VALUES, TABLE or WITH
+// query, the forms a server-side cursor can be declared for.
+func IsCursorQuery(query string, dialect Dialect) bool {
+	classification := Classify(query, dialect)
+	return classification.Kind == KindSelect && !classification.Writes
 }
 
-func firstKeyword(query string) string {
-	length := len(query)
-	index := 0
-	for index < length {
-		char := query[index]
-		if isWhitespace(char) {
-			index++
-			continue
-		}
-		if char == '-' && index+1 < length && query[index+1] == '-' {
-			index += 2
-			for index < length && query[index] != '\n' {
-				index++
-			}
-			continue
-		}
-		if char == '/' && index+1 < length && query[index+1] == '*' {
-			index += 2
-			for index+1 < length {
-				if query[index] == '*' && query[index+1] == '/' {
-					index += 2
-					break
-				}
-				index++
-			}
-			continue
+// TransactionControl describes how a statement changes the session's transaction state.
+type TransactionControl int
+
+const (
+	TransactionNone TransactionControl = iota
+	TransactionOpen
+	TransactionClose
+)
+
+// ClassifyTransactionControl reports whether a statement opens or ends a transaction.
+// SAVEPOINT and ROLLBACK TO keep the current transaction and are reported as TransactionNone.
+func ClassifyTransactionControl(query string) TransactionControl {
+	tokens := Tokenize(query, "")
+	word := func(index int) string {
+		if index < len(tokens) && tokens[index].Kind == TokenWord {
+			return tokens[index].Value
 		}
-		break
-	}
-	if index >= length {
 		return ""
 	}
-	start := index
-	for index < length {
-		char := query[index]
-		if !isKeywordChar(char) {
-			break
+	switch word(0) {
+	case "BEGIN", "START":
+		return TransactionOpen
+	case "COMMIT", "END":
+		return TransactionClose
+	case "ROLLBACK", "ABORT":
+		next := 1
+		if word(next) == "TRANSACTION" || word(next) == "WORK" {
+			next++
 		}
-		index++
-	}
-	if start == index {
-		return ""
+		if word(next) == "TO" {
+			return TransactionNone
+		}
+		return TransactionClose
+	default:
+		return TransactionNone
 	}
-	return strings.ToUpper(query[start:index])
+}
+
+// AbortsTransactionOnError reports whether a failed statement leaves an open
+// transaction unusable until it is rolled back.
+func (d Dialect) AbortsTransactionOnError() bool {
+	return d == DialectPostgres || d == DialectDuckDB
 }
 
 func isWhitespace(char byte) bool {
@@ -0,0 +1,107 @@
+package sqlutil
+
+import "testing"
+
+func TestClassifyTransactionControl(t *testing.T) {
+	tests := []struct {
+		query string
+		want  TransactionControl
+	}{
+		{query: "BEGIN", want: TransactionOpen},
+		{query: "start transaction read only", want: TransactionOpen},
+		{query: "COMMIT", want: TransactionClose},
+		{query: "end", want: TransactionClose},
+		{query: "ROLLBACK", want: TransactionClose},
+		{query: "ROLLBACK TO SAVEPOINT sp", want: TransactionNone},
+		{query: "rollback transaction to sp", want: TransactionNone},
+		{query: "SAVEPOINT sp", want: TransactionNone},
+		{query: "-- note\nSELECT 1", want: TransactionNone},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyTransactionControl(tt.query); got != tt.want {
+			t.Fatalf("ClassifyTransactionControl(%q) = %d, want %d", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestIsCursorQuery(t *testing.T) {
+	for query, want := range map[string]bool{
+		"/* list */ select 1":                   true,
+		"WITH t AS (SELECT 1) SELECT *":         true,
+		"values (1)":                            true,
+		"SHOW search_path":                      false,
+		"INSERT INTO t VALUES (1) RETURNING id": false,
+	} {
+		if got := IsCursorQuery(query, DialectPostgres); got != want {
+			t.Fatalf("IsCursorQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestIsWriteQuery(t *testing.T) {
+	for query, want := range map[string]bool{
+		"SELECT * FROM orders":                                             false,
+		"-- DELETE FROM orders\nSELECT 'insert into t'":                    false,
+		"EXPLAIN DELETE FROM orders":                                       false,
+		"PRAGMA table_info(orders)":                                        false,
+		"show search_path":                                                 false,
+		"delete from orders":                                               true,
+		"/* report */ INSERT INTO t VALUES (1)":                            true,
+		"WITH gone AS (DELETE FROM t RETURNING id) SELECT * FROM gone":     true,
+		"SELECT * INTO archive FROM orders":                                true,
+		"EXPLAIN (ANALYZE, BUFFERS) UPDATE t SET x = 1":                    true,
+		"SET default_transaction_read_only = off":                          true,
+		"BEGIN READ WRITE":                                                 true,
+		"SELECT set_config('transaction_read_only', 'off', false)":         true,
+		"PRAGMA query_only = 0":                                            true,
+		`SET "default_transaction_read_only" = off`:                        true,
+		`SELECT "set_config"('default_transaction_read_only','off',false)`: true,
+		"ATTACH 'other.db' AS other":                                       true,
+		"COPY orders TO '/tmp/orders.csv'":                                 true,
+	} {
+		if got := IsWriteQuery(query, DialectPostgres); got != want {
+			t.Fatalf("IsWriteQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestDestructiveRisks(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "DELETE FROM orders", want: "DELETE without WHERE"},
+		{query: "delete from orders where id = 1"},
+		{query: "DELETE FROM orders WHERE id IN (SELECT id FROM stale)"},
+		{query: "DELETE FROM orders USING (SELECT id FROM t WHERE x) s", want: "DELETE without WHERE"},
+		{query: "update orders set total = 0 -- where id = 1", want: "UPDATE without WHERE"},
+		{query: "UPDATE orders SET note = 'where' ", want: "UPDATE without WHERE"},
+		{query: "WITH stale AS (SELECT id FROM orders WHERE old) DELETE FROM orders", want: "DELETE without WHERE"},
+		{query: "WITH stale AS (SELECT id FROM orders) DELETE FROM orders WHERE id IN (SELECT id FROM stale)"},
+		{query: "WITH gone AS (DELETE FROM orders RETURNING id) SELECT count(*) FROM gone", want: "DELETE without WHERE"},
+		{query: "WITH moved AS MATERIALIZED (UPDATE orders SET total = 0 RETURNING id) SELECT * FROM moved", want: "UPDATE without WHERE"},
+		{query: "WITH gone AS (DELETE FROM orders WHERE id = 1 RETURNING id) SELECT * FROM gone"},
+		{query: "WITH a AS (SELECT 1), b (id) AS (WITH c AS (DELETE FROM t RETURNING id) SELECT id FROM c) SELECT * FROM b", want: "DELETE without WHERE"},
+		{query: "EXPLAIN ANALYZE UPDATE orders SET total = 0", want: "UPDATE without WHERE"},
+		{query: "EXPLAIN (ANALYZE, BUFFERS) DELETE FROM orders", want: "DELETE without WHERE"},
+		{query: "EXPLAIN UPDATE orders SET total = 0"},
+		{query: "INSERT INTO t VALUES (1) ON CONFLICT (id) DO UPDATE SET x = 1"},
+		{query: "DROP TABLE orders", want: "DROP TABLE"},
+		{query: "/* cleanup */ drop schema app cascade", want: "DROP SCHEMA"},
+		{query: "TRUNCATE orders", want: "TRUNCATE"},
+		{query: "ALTER TABLE orders DROP COLUMN note", want: "ALTER ... DROP COLUMN"},
+		{query: "ALTER TABLE orders DROP note", want: "ALTER ... DROP COLUMN"},
+		{query: "ALTER TABLE orders DROP CONSTRAINT orders_pkey"},
+		{query: "ALTER TABLE orders ALTER COLUMN note DROP NOT NULL"},
+		{query: "ALTER TABLE orders ADD COLUMN note text"},
+		{query: "SELECT * FROM orders"},
+	}
+
+	for _, tt := range tests {
+		got := DestructiveRisks(tt.query, DialectPostgres)
+		if (tt.want == "" && len(got) != 0) || (tt.want != "" && (len(got) != 1 || got[0] != tt.want)) {
+			t.Fatalf("DestructiveRisks(%q) = %v, want %q", tt.query, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package sqlutil
+
+import "strings"
+
+// readOnlySettings are the settings that switch a connection out of read-only mode.
+var readOnlySettings = map[string]struct{}{
+	"DEFAULT_TRANSACTION_READ_ONLY": {},
+	"TRANSACTION_READ_ONLY":         {},
+	"SET_CONFIG":                    {},
+	"QUERY_ONLY":                    {},
+}
+
+// IsWriteQuery reports whether query may write: Classify reports a write, or the
+// statement tries to leave read-only mode.
+func IsWriteQuery(query string, dialect Dialect) bool {
+	tokens := statementTokens(query, dialect)
+	if classifyTokens(tokens).Writes {
+		return true
+	}
+	for i, token := range tokens {
+		if leavesReadOnly(token) {
+			return true
+		}
+		if token.IsWord("WRITE") && i > 0 && tokens[i-1].IsWord("READ") {
+			return true
+		}
+	}
+	return false
+}
+
+// leavesReadOnly reports whether token names a setting in readOnlySettings, bare or
+// quoted, as in SET "default_transaction_read_only" or "set_config"(...).
+func leavesReadOnly(token Token) bool {
+	var name string
+	switch token.Kind {
+	case TokenWord:
+		name = token.Value
+	case TokenQuotedIdentifier:
+		name = strings.ToUpper(token.Value)
+	default:
+		return false
+	}
+	_, ok := readOnlySettings[name]
+	return ok
+}
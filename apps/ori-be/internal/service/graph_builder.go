@@ -96,6 +96,78 @@ func (b *GraphBuilder) BuildTriggerNodes(scope model.Scope, relation string, tri
 	return nodes, triggerIDs
 }
 
+// BuildRoutineNodes creates nodes for functions and procedures.
+func (b *GraphBuilder) BuildRoutineNodes(scope model.Scope, routines []model.Routine) ([]model.Node, []string) {
+	sort.Slice(routines, func(i, j int) bool {
+		return routines[i].Signature < routines[j].Signature
+	})
+
+	nodes := make([]model.Node, 0, len(routines))
+	routineIDs := make([]string, 0, len(routines))
+
+	for _, routine := range routines {
+		node := model.NewRoutineNode(scope, routine)
+		nodes = append(nodes, node)
+		routineIDs = append(routineIDs, node.GetID())
+	}
+
+	return nodes, routineIDs
+}
+
+// BuildSequenceNodes creates nodes for sequences.
+func (b *GraphBuilder) BuildSequenceNodes(scope model.Scope, sequences []model.Sequence) ([]model.Node, []string) {
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i].Name < sequences[j].Name
+	})
+
+	nodes := make([]model.Node, 0, len(sequences))
+	sequenceIDs := make([]string, 0, len(sequences))
+
+	for _, seq := range sequences {
+		node := model.NewSequenceNode(scope, seq)
+		nodes = append(nodes, node)
+		sequenceIDs = append(sequenceIDs, node.GetID())
+	}
+
+	return nodes, sequenceIDs
+}
+
+// BuildTypeNodes creates nodes for user-defined types.
+func (b *GraphBuilder) BuildTypeNodes(scope model.Scope, types []model.UserType) ([]model.Node, []string) {
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+
+	nodes := make([]model.Node, 0, len(types))
+	typeIDs := make([]string, 0, len(types))
+
+	for _, typ := range types {
+		node := model.NewTypeNode(scope, typ)
+		nodes = append(nodes, node)
+		typeIDs = append(typeIDs, node.GetID())
+	}
+
+	return nodes, typeIDs
+}
+
+// BuildExtensionNodes creates nodes for installed extensions.
+func (b *GraphBuilder) BuildExtensionNodes(scope model.Scope, extensions []model.Extension) ([]model.Node, []string) {
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Name < extensions[j].Name
+	})
+
+	nodes := make([]model.Node, 0, len(extensions))
+	extensionIDs := make([]string, 0, len(extensions))
+
+	for _, ext := range extensions {
+		node := model.NewExtensionNode(scope, ext)
+		nodes = append(nodes, node)
+		extensionIDs = append(extensionIDs, node.GetID())
+	}
+
+	return nodes, extensionIDs
+}
+
 func constraintTypeOrder(t string) int {
 	switch t {
 	case "PRIMARY KEY":
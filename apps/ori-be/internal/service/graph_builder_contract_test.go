@@ -1,6 +1,7 @@
 package service
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
@@ -80,6 +81,18 @@ func TestGraphBuilderNodesConvertToDTO(t *testing.T) {
 		CharMaxLength:    &charMaxLength,
 		NumericPrecision: &numericPrecision,
 		NumericScale:     &numericScale,
+	}, {
+		Name:                 "total",
+		Ordinal:              2,
+		DataType:             "numeric",
+		Generated:            stringPtr("STORED"),
+		GenerationExpression: stringPtr("price * qty"),
+	}, {
+		Name:     "seq",
+		Ordinal:  3,
+		DataType: "integer",
+		NotNull:  true,
+		Identity: stringPtr("BY DEFAULT"),
 	}})
 	constraintNodes, _ := b.BuildConstraintNodes(scope, relation.Name, []model.Constraint{constraint})
 	indexNodes, _ := b.BuildIndexNodes(scope, relation.Name, []model.Index{index})
@@ -90,9 +103,156 @@ func TestGraphBuilderNodesConvertToDTO(t *testing.T) {
 	nodes = append(nodes, indexNodes...)
 	nodes = append(nodes, triggerNodes...)
 
+	grants := []model.Grant{
+		{Grantee: "PUBLIC", Privilege: "USAGE"},
+		{Grantee: "app_owner", Privilege: "SELECT", GrantOption: true},
+	}
+	schema := model.Schema{
+		Engine:         "postgres",
+		ConnectionName: "local-postgres",
+		Database:       "app",
+		Name:           "public",
+		Owner:          "app_owner",
+		Grants:         grants,
+	}
+	routineNodes, routineIDs := b.BuildRoutineNodes(schema, []model.Routine{
+		{
+			Name:            "touch",
+			Kind:            "procedure",
+			Signature:       "touch(integer)",
+			Arguments:       "id integer",
+			Language:        "plpgsql",
+			SecurityDefiner: true,
+			Definition:      "CREATE OR REPLACE PROCEDURE public.touch(id integer) ...",
+		},
+		{
+			Name:       "add",
+			Kind:       "function",
+			Signature:  "add(integer, integer)",
+			Arguments:  "a integer, b integer DEFAULT 1",
+			ReturnType: "integer",
+			Language:   "sql",
+			Volatility: "IMMUTABLE",
+			Definition: "CREATE OR REPLACE FUNCTION public.add(a integer, b integer DEFAULT 1) ...",
+			Owner:      "app_owner",
+			Grants:     []model.Grant{{Grantee: "PUBLIC", Privilege: "EXECUTE"}},
+		},
+		{Name: "total", Kind: "function", Signature: "total(integer)", ReturnType: "integer", Language: "sql"},
+		{Name: "total", Kind: "function", Signature: "total(integer[])", ReturnType: "integer", Language: "sql"},
+	})
+	if len(routineIDs) != 4 ||
+		!strings.HasPrefix(routineIDs[0], "postgres-local-postgres-app-public-add-integer-integer-") ||
+		!strings.HasSuffix(routineIDs[0], "-function") {
+		t.Fatalf("unexpected routine node ids: %v", routineIDs)
+	}
+	if routineIDs[1] == routineIDs[2] {
+		t.Fatalf("expected overloads to get distinct ids, both got %q", routineIDs[1])
+	}
+	schemaNode := b.BuildScopeNode(schema).(*model.SchemaNode)
+	sequenceNodes, sequenceIDs := b.BuildSequenceNodes(schema, []model.Sequence{{
+		Name:          "users_id_seq",
+		DataType:      "bigint",
+		Start:         1,
+		Increment:     1,
+		MinValue:      1,
+		MaxValue:      9223372036854775807,
+		CurrentValue:  int64Ptr(42),
+		OwnedByTable:  "users",
+		OwnedByColumn: "id",
+	}})
+	schemaNode.Functions = routineIDs
+	schemaNode.Sequences = sequenceIDs
+	nodes = append(nodes, schemaNode)
+	nodes = append(nodes, routineNodes...)
+	nodes = append(nodes, sequenceNodes...)
+
+	typeNodes, typeIDs := b.BuildTypeNodes(schema, []model.UserType{
+		{Name: "mood", Kind: "enum", Labels: []string{"sad", "ok", "happy"}},
+		{
+			Name:        "positive_int",
+			Kind:        "domain",
+			BaseType:    "integer",
+			NotNull:     true,
+			Default:     stringPtr("1"),
+			Constraints: []string{"CHECK (VALUE > 0)"},
+		},
+		{Name: "point", Kind: "composite", Fields: []model.TypeField{{Name: "x", DataType: "integer"}}},
+	})
+	schemaNode.Types = typeIDs
+	nodes = append(nodes, typeNodes...)
+	extensionNodes, extensionIDs := b.BuildExtensionNodes(schema, []model.Extension{
+		{Name: "pg_trgm", Version: "1.6", Schema: "public", Description: "text similarity measurement"},
+		{Name: "json", Version: "v1.4.4", Loaded: boolPtr(true)},
+	})
+	if len(extensionIDs) != 2 || extensionIDs[0] != "postgres-local-postgres-app-public-json-extension" {
+		t.Fatalf("unexpected extension node ids: %v", extensionIDs)
+	}
+	schemaNode.Extensions = extensionIDs
+	nodes = append(nodes, extensionNodes...)
+	typedColumns, _ := b.BuildColumnNodes(schema, "users", []model.Column{{
+		Name:       "status",
+		Ordinal:    1,
+		DataType:   "USER-DEFINED",
+		TypeSchema: stringPtr("public"),
+		TypeName:   stringPtr("mood"),
+	}})
+	if got := typedColumns[0].(*model.ColumnNode).UserType; got == nil || *got != typeIDs[0] {
+		t.Fatalf("expected column to link to type node %q, got %v", typeIDs[0], got)
+	}
+	nodes = append(nodes, typedColumns...)
+
+	nodes = append(nodes,
+		b.BuildRelationNode(schema, model.Relation{
+			Name:              "events",
+			Type:              "table",
+			PartitionStrategy: "RANGE",
+			PartitionKey:      "happened_on",
+			Owner:             "app_owner",
+			Grants:            grants,
+		}),
+		b.BuildRelationNode(schema, model.Relation{
+			Name:           "events_2024",
+			Type:           "table",
+			ParentTable:    stringPtr("events"),
+			PartitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')",
+		}),
+		b.BuildRelationNode(schema, model.Relation{
+			Name:       "author_book_counts",
+			Type:       "matview",
+			Definition: "SELECT author_id, count(*) FROM books GROUP BY author_id",
+		}),
+		b.BuildRelationNode(schema, model.Relation{
+			Name:    "remote_authors",
+			Type:    "foreign_table",
+			Server:  "loopback",
+			Options: []string{"schema_name=public", "table_name=authors"},
+		}),
+	)
+	if _, ok := nodes[len(nodes)-2].(*model.MatviewNode); !ok {
+		t.Fatalf("expected matview relation to build a matview node, got %T", nodes[len(nodes)-2])
+	}
+	if _, ok := nodes[len(nodes)-1].(*model.ForeignTableNode); !ok {
+		t.Fatalf("expected foreign table relation to build a foreign table node, got %T", nodes[len(nodes)-1])
+	}
+
 	if _, err := model.ConvertNodesToDTO(nodes); err != nil {
 		t.Fatalf("expected GraphBuilder output to match contract, got error: %v", err)
 	}
+
+	events, err := nodes[len(nodes)-4].ToDTO()
+	if err != nil {
+		t.Fatalf("convert events table: %v", err)
+	}
+	table, err := events.AsTableNode()
+	if err != nil {
+		t.Fatalf("expected events to convert to a table node: %v", err)
+	}
+	if table.Attributes.Owner == nil || *table.Attributes.Owner != "app_owner" {
+		t.Fatalf("expected events owner app_owner, got %v", table.Attributes.Owner)
+	}
+	if table.Attributes.Privileges == nil || len(*table.Attributes.Privileges) != 2 || !(*table.Attributes.Privileges)[1].GrantOption {
+		t.Fatalf("unexpected events privileges: %+v", table.Attributes.Privileges)
+	}
 }
 
 func stringPtr(v string) *string {
@@ -106,3 +266,7 @@ func intPtr(v int) *int {
 func int64Ptr(v int64) *int64 {
 	return &v
 }
+
+func boolPtr(v bool) *bool {
+	return &v
+}
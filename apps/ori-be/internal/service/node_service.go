@@ -103,7 +103,7 @@ func (ns *NodeService) hydrateNode(ctx context.Context, graph *connectionGraph,
 		nodes, err = ns.hydrateDatabase(ctx, handle, typed)
 	case *model.SchemaNode:
 		nodes, err = ns.hydrateSchema(ctx, handle, typed)
-	case *model.TableNode, *model.ViewNode:
+	case *model.TableNode, *model.ViewNode, *model.MatviewNode, *model.ForeignTableNode:
 		nodes, err = ns.hydrateRelation(ctx, handle, node)
 	default:
 		node.SetHydrated(true)
@@ -126,13 +126,21 @@ func (ns *NodeService) hydrateDatabase(ctx context.Context, handle *ResourceHand
 		return nil, fmt.Errorf("node %s missing scope", node.GetID())
 	}
 
-	nodes, tableIDs, viewIDs, err := ns.getScopeRelations(ctx, handle, node.Scope, node)
+	nodes, ids, err := ns.getScopeRelations(ctx, handle, node.Scope, node)
 	if err != nil {
 		return nil, err
 	}
-	node.Tables = tableIDs
-	node.Views = viewIDs
+	extensions, err := handle.Adapter.GetExtensions(ctx, node.Scope)
+	if err != nil {
+		return nil, err
+	}
+	extensionNodes, extensionIDs := NewGraphBuilder(handle).BuildExtensionNodes(node.Scope, extensions)
+
+	node.Tables = ids.tables
+	node.Views = ids.views
+	node.Extensions = extensionIDs
 	node.SetHydrated(true)
+	nodes = append(nodes, extensionNodes...)
 	return nodes, nil
 }
 
@@ -144,27 +152,73 @@ func (ns *NodeService) hydrateSchema(ctx context.Context, handle *ResourceHandle
 		return nil, fmt.Errorf("node %s missing scope", node.GetID())
 	}
 
-	nodes, tableIDs, viewIDs, err := ns.getScopeRelations(ctx, handle, node.Scope, node)
+	nodes, ids, err := ns.getScopeRelations(ctx, handle, node.Scope, node)
+	if err != nil {
+		return nil, err
+	}
+
+	routines, err := handle.Adapter.GetRoutines(ctx, node.Scope)
 	if err != nil {
 		return nil, err
 	}
-	node.Tables = tableIDs
-	node.Views = viewIDs
+	sequences, err := handle.Adapter.GetSequences(ctx, node.Scope)
+	if err != nil {
+		return nil, err
+	}
+	types, err := handle.Adapter.GetTypes(ctx, node.Scope)
+	if err != nil {
+		return nil, err
+	}
+	extensions, err := handle.Adapter.GetExtensions(ctx, node.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewGraphBuilder(handle)
+	routineNodes, routineIDs := builder.BuildRoutineNodes(node.Scope, routines)
+	sequenceNodes, sequenceIDs := builder.BuildSequenceNodes(node.Scope, sequences)
+	typeNodes, typeIDs := builder.BuildTypeNodes(node.Scope, types)
+	extensionNodes, extensionIDs := builder.BuildExtensionNodes(node.Scope, extensions)
+
+	node.Tables = ids.tables
+	node.Views = ids.views
+	node.Matviews = ids.matviews
+	node.ForeignTables = ids.foreignTables
+	node.Functions = routineIDs
+	node.Sequences = sequenceIDs
+	node.Types = typeIDs
+	node.Extensions = extensionIDs
 	node.SetHydrated(true)
+	nodes = append(nodes, routineNodes...)
+	nodes = append(nodes, sequenceNodes...)
+	nodes = append(nodes, typeNodes...)
+	nodes = append(nodes, extensionNodes...)
 	return nodes, nil
 }
 
-func (ns *NodeService) getScopeRelations(ctx context.Context, handle *ResourceHandle, scope model.Scope, root model.Node) ([]model.Node, []string, []string, error) {
+// scopeRelationIDs groups the top-level relations of a scope by the edge they hang from.
+type scopeRelationIDs struct {
+	tables        []string
+	views         []string
+	matviews      []string
+	foreignTables []string
+}
+
+func (ns *NodeService) getScopeRelations(ctx context.Context, handle *ResourceHandle, scope model.Scope, root model.Node) ([]model.Node, scopeRelationIDs, error) {
 	relations, err := handle.Adapter.GetRelations(ctx, scope)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, scopeRelationIDs{}, err
 	}
 
 	builder := NewGraphBuilder(handle)
 
 	childNodes := []model.Node{root}
-	tableIDs := make([]string, 0)
-	viewIDs := make([]string, 0)
+	ids := scopeRelationIDs{
+		tables:        make([]string, 0),
+		views:         make([]string, 0),
+		matviews:      make([]string, 0),
+		foreignTables: make([]string, 0),
+	}
 	partitionEdges := make(map[string][]string)
 	relationNodes := make(map[string]model.Node, len(relations))
 
@@ -173,17 +227,22 @@ func (ns *NodeService) getScopeRelations(ctx context.Context, handle *ResourceHa
 		relNode := builder.BuildRelationNode(relScope, rel)
 		childNodes = append(childNodes, relNode)
 		relationNodes[relNode.GetID()] = relNode
-		if rel.Type == "table" {
-			if rel.ParentTable != nil {
-				parentScope := scope.WithSchema(rel.ParentSchema)
-				parentRel := model.Relation{Name: *rel.ParentTable, Type: "table"}
-				parentID := builder.BuildRelationNode(parentScope, parentRel).GetID()
-				partitionEdges[parentID] = append(partitionEdges[parentID], relNode.GetID())
-			} else {
-				tableIDs = append(tableIDs, relNode.GetID())
-			}
-		} else {
-			viewIDs = append(viewIDs, relNode.GetID())
+		if rel.ParentTable != nil {
+			parentScope := scope.WithSchema(rel.ParentSchema)
+			parentRel := model.Relation{Name: *rel.ParentTable, Type: "table"}
+			parentID := builder.BuildRelationNode(parentScope, parentRel).GetID()
+			partitionEdges[parentID] = append(partitionEdges[parentID], relNode.GetID())
+			continue
+		}
+		switch rel.Type {
+		case "table":
+			ids.tables = append(ids.tables, relNode.GetID())
+		case "matview":
+			ids.matviews = append(ids.matviews, relNode.GetID())
+		case "foreign_table":
+			ids.foreignTables = append(ids.foreignTables, relNode.GetID())
+		default:
+			ids.views = append(ids.views, relNode.GetID())
 		}
 	}
 
@@ -194,12 +253,12 @@ func (ns *NodeService) getScopeRelations(ctx context.Context, handle *ResourceHa
 		}
 		parentTable, ok := parentNode.(*model.TableNode)
 		if !ok {
-			return nil, nil, nil, fmt.Errorf("partition parent %s is not a table node", parentID)
+			return nil, scopeRelationIDs{}, fmt.Errorf("partition parent %s is not a table node", parentID)
 		}
 		parentTable.Partitions = childIDs
 	}
 
-	return childNodes, tableIDs, viewIDs, nil
+	return childNodes, ids, nil
 }
 
 func (ns *NodeService) hydrateRelation(ctx context.Context, handle *ResourceHandle, node model.Node) ([]model.Node, error) {
@@ -213,6 +272,12 @@ func (ns *NodeService) hydrateRelation(ctx context.Context, handle *ResourceHand
 	case *model.ViewNode:
 		scope = typed.Scope
 		relation = typed.RelationName()
+	case *model.MatviewNode:
+		scope = typed.Scope
+		relation = typed.RelationName()
+	case *model.ForeignTableNode:
+		scope = typed.Scope
+		relation = typed.RelationName()
 	default:
 		return nil, fmt.Errorf("node %s is not a relation node", node.GetID())
 	}
@@ -260,6 +325,18 @@ func (ns *NodeService) hydrateRelation(ctx context.Context, handle *ResourceHand
 		typed.Indexes = indexIDs
 		typed.Triggers = triggerIDs
 		typed.SetHydrated(true)
+	case *model.MatviewNode:
+		typed.Columns = columnIDs
+		typed.Constraints = constraintIDs
+		typed.Indexes = indexIDs
+		typed.Triggers = triggerIDs
+		typed.SetHydrated(true)
+	case *model.ForeignTableNode:
+		typed.Columns = columnIDs
+		typed.Constraints = constraintIDs
+		typed.Indexes = indexIDs
+		typed.Triggers = triggerIDs
+		typed.SetHydrated(true)
 	default:
 		return nil, fmt.Errorf("node %s cannot hold relation child edges", node.GetID())
 	}
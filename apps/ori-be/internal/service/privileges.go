@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+)
+
+var (
+	ErrPrivilegesUnsupported = errors.New("privileges are not reported by this engine")
+	ErrUnknownRole           = errors.New("role does not exist")
+)
+
+// GetRolePrivileges summarizes what a role can do in a resource. An empty role
+// checks the role the resource connects as; a schema limits the summary to it.
+func (ns *NodeService) GetRolePrivileges(ctx context.Context, resourceName, role, schema string) (*model.RolePrivileges, error) {
+	connection, ok := ns.connections.GetConnection(resourceName)
+	if !ok || connection == nil || connection.Adapter == nil {
+		return nil, fmt.Errorf("%w: %s", ErrConnectionUnavailable, resourceName)
+	}
+	return connection.Adapter.GetRolePrivileges(ctx, role, schema)
+}
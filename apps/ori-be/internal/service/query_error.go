@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+)
+
+// DatabaseError carries the structured fields a database reported for a failed
+// statement. Adapters wrap driver errors in it; fields the engine does not report
+// are left empty.
+type DatabaseError struct {
+	Engine string
+	// Code is the SQLSTATE on PostgreSQL, the result code name on SQLite and the error type on DuckDB.
+	Code     string
+	Severity string
+	Message  string
+	Detail   string
+	Hint     string
+	// Position is the 1-based character offset of the error within the statement.
+	Position int
+	// Line and Column locate Position within the submitted query text, both 1-based.
+	Line       int
+	Column     int
+	Schema     string
+	Table      string
+	ColumnName string
+	Constraint string
+
+	Err error
+}
+
+func (e *DatabaseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DatabaseError) Unwrap() error {
+	return e.Err
+}
+
+// locateDatabaseError returns a copy of the database error in err with its position
+// mapped to a line and column of source. The failed statement starts at byte offset
+// boundStart of bound, the text sent to the database, and shifts map offsets of bound
+// back to source, where the bound text starts at byte offset sourceStart.
+// It returns nil when err carries no database error.
+func locateDatabaseError(err error, bound string, boundStart int, shifts []sqlutil.ParamShift, source string, sourceStart int) *DatabaseError {
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) {
+		return nil
+	}
+	located := *dbErr
+	if located.Position <= 0 || boundStart > len(bound) {
+		return &located
+	}
+
+	end := boundStart
+	for chars := 1; chars < located.Position && end < len(bound); chars++ {
+		_, size := utf8.DecodeRuneInString(bound[end:])
+		end += size
+	}
+	end = min(sourceStart+sqlutil.SourceOffset(shifts, end), len(source))
+	before := source[:end]
+	located.Line = strings.Count(before, "\n") + 1
+	located.Column = utf8.RuneCountInString(before[strings.LastIndexByte(before, '\n')+1:]) + 1
+	return &located
+}
+
+// jobDatabaseError describes the database error a job failed with, located within the
+// query as submitted. Scripts report the error of their first failed statement, already
+// located within the script.
+func jobDatabaseError(job *QueryJob, result *QueryResult, err error) *DatabaseError {
+	if len(result.Statements) == 0 {
+		return locateDatabaseError(err, job.Query, 0, job.paramShifts, job.sourceQuery, 0)
+	}
+	for _, statement := range result.Statements {
+		if statement.DatabaseError != nil {
+			return statement.DatabaseError
+		}
+	}
+	return nil
+}
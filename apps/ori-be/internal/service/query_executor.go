@@ -6,9 +6,32 @@ import (
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
 )
 
+// ScriptErrorMode controls what a script job does after a statement fails.
+type ScriptErrorMode string
+
+const (
+	ScriptStopOnError     ScriptErrorMode = "stop"
+	ScriptContinueOnError ScriptErrorMode = "continue"
+)
+
 // QueryExecOptions contains options for query execution
 type QueryExecOptions struct {
-	MaxRows int `json:"maxRows"`
+	MaxRows int             `json:"maxRows"`
+	Script  bool            `json:"script"`
+	OnError ScriptErrorMode `json:"onError"`
+	// SessionID runs the job on the connection pinned by a query session.
+	SessionID string `json:"sessionId"`
+	// Lazy keeps a cursor open and fetches rows only as result pages ask for them.
+	Lazy bool `json:"lazy"`
+	// FetchRows is how many rows a lazy job fetches at a time.
+	FetchRows int `json:"fetchRows"`
+	// TimeoutMs cancels the job once it has run this many milliseconds.
+	TimeoutMs int `json:"timeoutMs"`
+	// ConfirmToken confirms a destructive query on a resource that asks for confirmation.
+	ConfirmToken string         `json:"confirmToken"`
+	Spill        *ResultSpill   `json:"-"`
+	Progress     *QueryProgress `json:"-"`
+	Notices      *QueryNotices  `json:"-"`
 }
 
 // AdapterFactoryParams bundles the information required to construct a connection adapter instance.
@@ -38,6 +61,18 @@ type Introspector interface {
 	GetIndexes(ctx context.Context, scope model.Scope, relation string) ([]model.Index, error)
 	// GetTriggers returns triggers for a relation within a scope.
 	GetTriggers(ctx context.Context, scope model.Scope, relation string) ([]model.Trigger, error)
+	// GetRoutines returns functions and procedures within a scope.
+	GetRoutines(ctx context.Context, scope model.Scope) ([]model.Routine, error)
+	// GetSequences returns sequences within a scope.
+	GetSequences(ctx context.Context, scope model.Scope) ([]model.Sequence, error)
+	// GetTypes returns user-defined enum, domain and composite types within a scope.
+	GetTypes(ctx context.Context, scope model.Scope) ([]model.UserType, error)
+	// GetExtensions returns the extensions installed in a scope.
+	GetExtensions(ctx context.Context, scope model.Scope) ([]model.Extension, error)
+	// GetRolePrivileges returns the objects a role holds privileges on; an empty
+	// role means the connected one and an empty schema means every schema.
+	// Engines without roles return ErrPrivilegesUnsupported.
+	GetRolePrivileges(ctx context.Context, role, schema string) (*model.RolePrivileges, error)
 }
 
 // ConnectionAdapter represents a database-specific implementation capable of metadata discovery and query execution.
@@ -50,6 +85,18 @@ type ConnectionAdapter interface {
 	Ping(ctx context.Context) error
 	// ExecuteQuery runs a query and returns the result.
 	ExecuteQuery(ctx context.Context, query string, params interface{}, options *QueryExecOptions) (*QueryResult, error)
+	// ExplainQuery returns the plan the engine chooses for a single statement.
+	ExplainQuery(ctx context.Context, query string, params interface{}, options *QueryExplainOptions) (*QueryPlan, error)
+	// PinConnection checks out a dedicated connection so consecutive queries share session state.
+	PinConnection(ctx context.Context) (PinnedConnection, error)
 
 	Introspector
 }
+
+// PinnedConnection runs queries on a single driver connection until it is closed.
+type PinnedConnection interface {
+	// ExecuteQuery runs a query on the pinned connection and returns the result.
+	ExecuteQuery(ctx context.Context, query string, params interface{}, options *QueryExecOptions) (*QueryResult, error)
+	// Close returns the connection to the pool.
+	Close() error
+}
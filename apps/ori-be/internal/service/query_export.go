@@ -0,0 +1,448 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+var (
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
+	ErrInvalidExportPath       = errors.New("invalid export path")
+)
+
+// ExportFormat names a result export encoding.
+type ExportFormat string
+
+const (
+	ExportCSV       ExportFormat = "csv"
+	ExportTSV       ExportFormat = "tsv"
+	ExportJSONLines ExportFormat = "jsonl"
+	ExportMarkdown  ExportFormat = "markdown"
+	ExportParquet   ExportFormat = "parquet"
+)
+
+const (
+	// exportChunkRows is how many rows are read at a time while exporting.
+	exportChunkRows  = segmentCheckpointInterval
+	defaultNullText  = ""
+	markdownNullText = "NULL"
+)
+
+// ExportOptions controls how a stored result is exported.
+type ExportOptions struct {
+	Format ExportFormat
+	// Statement selects the statement of a script job; the last statement is used when nil.
+	Statement *int
+	// Header writes the column names first (CSV, TSV and Markdown).
+	Header bool
+	// NullText replaces NULL cells in text formats. Nil uses the format's default.
+	NullText *string
+}
+
+func (o *ExportOptions) nullText() string {
+	if o.NullText != nil {
+		return *o.NullText
+	}
+	if o.Format == ExportMarkdown {
+		return markdownNullText
+	}
+	return defaultNullText
+}
+
+// ResultExporter writes a stored result in one export format.
+type ResultExporter interface {
+	Export(ctx context.Context, result *QueryResult, options *ExportOptions, w io.Writer) error
+}
+
+// ResultExport is a stored result resolved for export. Errors about the job,
+// statement or format surface before anything is written.
+type ResultExport struct {
+	Result   *QueryResult
+	options  *ExportOptions
+	exporter ResultExporter
+}
+
+// WriteTo streams the export to w.
+func (e *ResultExport) WriteTo(ctx context.Context, w io.Writer) error {
+	return e.exporter.Export(ctx, e.Result, e.options, w)
+}
+
+// ExportFileInfo describes a result written to a file.
+type ExportFileInfo struct {
+	Path     string
+	Bytes    int64
+	RowCount int
+}
+
+func defaultExporters() map[ExportFormat]ResultExporter {
+	return map[ExportFormat]ResultExporter{
+		ExportCSV:       delimitedExporter{comma: ','},
+		ExportTSV:       delimitedExporter{comma: '\t'},
+		ExportJSONLines: JSONLinesExporter{},
+		ExportMarkdown:  markdownExporter{},
+	}
+}
+
+// RegisterExporter adds or replaces the exporter for a format. Formats that need
+// a driver, such as Parquet, are registered by the server at startup.
+func (qs *QueryService) RegisterExporter(format ExportFormat, exporter ResultExporter) {
+	if exporter == nil {
+		return
+	}
+	qs.exportersMu.Lock()
+	defer qs.exportersMu.Unlock()
+	qs.exporters[format] = exporter
+}
+
+// ExportResult resolves a stored result for export. A lazy result first fetches its
+// remaining rows, up to the materialization limit.
+func (qs *QueryService) ExportResult(ctx context.Context, jobID string, options *ExportOptions) (*ResultExport, error) {
+	qs.exportersMu.RLock()
+	exporter, ok := qs.exporters[options.Format]
+	qs.exportersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedExportFormat, options.Format)
+	}
+
+	stored, exists := qs.resultStore.Get(jobID)
+	if !exists {
+		return nil, ErrNotFound
+	}
+	result, err := selectStatementResult(stored, options.Statement)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Columns) == 0 {
+		return nil, fmt.Errorf("%w: the statement returned no rows to export", ErrResultUnavailable)
+	}
+	if err := result.fetchRows(ctx, -1); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrResultUnavailable, err)
+	}
+	return &ResultExport{Result: result, options: options, exporter: exporter}, nil
+}
+
+// ExportResultToFile writes a stored result to an absolute path on the server's
+// filesystem. Existing files are only replaced when overwrite is set, and then only
+// once the export succeeded: it is written to a temporary file renamed over the target.
+func (qs *QueryService) ExportResultToFile(ctx context.Context, jobID, path string, overwrite bool, options *ExportOptions) (*ExportFileInfo, error) {
+	if !filepath.IsAbs(path) {
+		return nil, fmt.Errorf("%w: %q is not absolute", ErrInvalidExportPath, path)
+	}
+	export, err := qs.ExportResult(ctx, jobID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *os.File
+	if overwrite {
+		file, err = os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+		if err == nil {
+			err = file.Chmod(0o644)
+		}
+	} else {
+		file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	}
+	if err != nil {
+		if file != nil {
+			_ = file.Close()
+			_ = os.Remove(file.Name())
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExportPath, err)
+	}
+
+	writer := &countingWriter{w: file}
+	if err := export.WriteTo(ctx, writer); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to close export file: %w", err)
+	}
+	if overwrite {
+		if err := os.Rename(file.Name(), path); err != nil {
+			_ = os.Remove(file.Name())
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExportPath, err)
+		}
+	}
+	return &ExportFileInfo{Path: path, Bytes: writer.n, RowCount: export.Result.RowCount}, nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// forEachRow reads the result in chunks and calls fn for every row.
+func forEachRow(ctx context.Context, result *QueryResult, fn func(row []any) error) error {
+	for start := 0; start < result.RowCount; start += exportChunkRows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rows, err := result.ReadRows(start, min(start+exportChunkRows, result.RowCount))
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrResultUnavailable, err)
+		}
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func columnNames(result *QueryResult) []string {
+	names := make([]string, len(result.Columns))
+	for i, column := range result.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// delimitedExporter writes CSV with RFC 4180 quoting, or TSV with the escapes
+// of the Postgres text COPY format, since TSV has no quoting.
+type delimitedExporter struct {
+	comma rune
+}
+
+func (e delimitedExporter) Export(ctx context.Context, result *QueryResult, options *ExportOptions, w io.Writer) error {
+	nullText := options.nullText()
+	if e.comma == '\t' {
+		buffered := bufio.NewWriter(w)
+		writeLine := func(fields []string) error {
+			for i, field := range fields {
+				fields[i] = escapeTSV(field)
+			}
+			_, err := buffered.WriteString(strings.Join(fields, "\t") + "\n")
+			return err
+		}
+		if err := writeDelimited(ctx, result, options, nullText, writeLine); err != nil {
+			return err
+		}
+		return buffered.Flush()
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = e.comma
+	if err := writeDelimited(ctx, result, options, nullText, writer.Write); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeDelimited(ctx context.Context, result *QueryResult, options *ExportOptions, nullText string, writeLine func([]string) error) error {
+	if options.Header {
+		if err := writeLine(columnNames(result)); err != nil {
+			return err
+		}
+	}
+	return forEachRow(ctx, result, func(row []any) error {
+		fields := make([]string, len(row))
+		for i, cell := range row {
+			fields[i] = exportText(cell, nullText)
+		}
+		return writeLine(fields)
+	})
+}
+
+var tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+func escapeTSV(field string) string {
+	return tsvEscaper.Replace(field)
+}
+
+// markdownExporter writes a GitHub-flavored Markdown table.
+type markdownExporter struct{}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func (markdownExporter) Export(ctx context.Context, result *QueryResult, options *ExportOptions, w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	nullText := options.nullText()
+	writeLine := func(fields []string) error {
+		for i, field := range fields {
+			fields[i] = markdownEscaper.Replace(field)
+		}
+		_, err := buffered.WriteString("| " + strings.Join(fields, " | ") + " |\n")
+		return err
+	}
+
+	// A Markdown table needs a header row, so unnamed columns get empty headers.
+	header := columnNames(result)
+	if !options.Header {
+		header = make([]string, len(result.Columns))
+	}
+	if err := writeLine(header); err != nil {
+		return err
+	}
+	separator := make([]string, len(result.Columns))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	if _, err := buffered.WriteString("| " + strings.Join(separator, " | ") + " |\n"); err != nil {
+		return err
+	}
+
+	err := forEachRow(ctx, result, func(row []any) error {
+		fields := make([]string, len(row))
+		for i, cell := range row {
+			fields[i] = exportText(cell, nullText)
+		}
+		return writeLine(fields)
+	})
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// JSONLinesExporter writes one JSON object per row, keyed by column name in
+// column order. Tagged cells are unwrapped: decimals become JSON numbers, JSON
+// documents are embedded and other tagged values become strings.
+type JSONLinesExporter struct{}
+
+func (JSONLinesExporter) Export(ctx context.Context, result *QueryResult, _ *ExportOptions, w io.Writer) error {
+	buffered := bufio.NewWriter(w)
+	keys := make([][]byte, len(result.Columns))
+	for i, name := range uniqueColumnNames(result) {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		keys[i] = key
+	}
+
+	err := forEachRow(ctx, result, func(row []any) error {
+		if err := buffered.WriteByte('{'); err != nil {
+			return err
+		}
+		for i, cell := range row {
+			if i > 0 {
+				_ = buffered.WriteByte(',')
+			}
+			value, err := json.Marshal(exportValue(cell))
+			if err != nil {
+				return fmt.Errorf("failed to encode cell: %w", err)
+			}
+			_, _ = buffered.Write(keys[i])
+			_ = buffered.WriteByte(':')
+			_, _ = buffered.Write(value)
+		}
+		_, err := buffered.WriteString("}\n")
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return buffered.Flush()
+}
+
+// uniqueColumnNames suffixes repeated column names so they can key JSON objects.
+func uniqueColumnNames(result *QueryResult) []string {
+	names := columnNames(result)
+	seen := make(map[string]int, len(names))
+	for i, name := range names {
+		seen[name]++
+		if count := seen[name]; count > 1 {
+			names[i] = name + "_" + strconv.Itoa(count)
+		}
+	}
+	return names
+}
+
+// exportValue unwraps a cell into a plain JSON value. Cells read back from a
+// spill segment hold tagged values as objects and numbers as json.Number.
+func exportValue(cell any) any {
+	tag, value, ok := taggedCell(cell)
+	if !ok {
+		return cell
+	}
+	switch tag {
+	case querycell.TagDecimal:
+		// NaN and infinities have no JSON number form.
+		text, _ := value.(string)
+		if json.Valid([]byte(text)) {
+			return json.Number(text)
+		}
+		return text
+	case querycell.TagJSON:
+		return value
+	case querycell.TagArray:
+		elements, _ := value.([]any)
+		plain := make([]any, len(elements))
+		for i, element := range elements {
+			plain[i] = exportValue(element)
+		}
+		return plain
+	}
+	return value
+}
+
+// exportText renders a cell for the text formats.
+func exportText(cell any, nullText string) string {
+	switch v := exportValue(cell).(type) {
+	case nil:
+		return nullText
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	default:
+		payload, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(payload)
+	}
+}
+
+// ExportCell renders a cell as the text formats do, along with its tag, which is
+// empty for untagged cells. It reports false for NULL.
+func ExportCell(cell any) (string, querycell.Tag, bool) {
+	if cell == nil {
+		return "", "", false
+	}
+	tag, _, _ := taggedCell(cell)
+	return exportText(cell, defaultNullText), tag, true
+}
+
+func taggedCell(cell any) (querycell.Tag, any, bool) {
+	switch v := cell.(type) {
+	case querycell.Tagged:
+		return v.Type, v.Value, true
+	case map[string]any:
+		if tag, ok := v["$type"].(string); ok {
+			return querycell.Tag(tag), v["value"], true
+		}
+	}
+	return "", nil, false
+}
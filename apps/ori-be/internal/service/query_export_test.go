@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+func TestExportersQuoteAndHandleNulls(t *testing.T) {
+	result := &QueryResult{
+		Status:  JobStatusSuccess,
+		Columns: []QueryColumn{{Name: "id"}, {Name: "note"}, {Name: "price"}, {Name: "id"}},
+		Rows: [][]any{
+			{int64(1), "plain", querycell.Decimal("12.50"), true},
+			{int64(2), "comma, \"quote\"\tand|pipe\nline", nil, nil},
+			// Rows read back from a spill segment.
+			{json.Number("3"), nil, map[string]any{"$type": "decimal", "value": "NaN"}, false},
+		},
+		RowCount: 3,
+	}
+	null := "NULL"
+
+	tests := []struct {
+		name    string
+		options ExportOptions
+		want    string
+	}{
+		{
+			name:    "csv",
+			options: ExportOptions{Format: ExportCSV, Header: true},
+			want:    "id,note,price,id\n1,plain,12.50,true\n2,\"comma, \"\"quote\"\"\tand|pipe\nline\",,\n3,,NaN,false\n",
+		},
+		{
+			name:    "tsv with null text and no header",
+			options: ExportOptions{Format: ExportTSV, NullText: &null},
+			want:    "1\tplain\t12.50\ttrue\n2\tcomma, \"quote\"\\tand|pipe\\nline\tNULL\tNULL\n3\tNULL\tNaN\tfalse\n",
+		},
+		{
+			name:    "jsonl",
+			options: ExportOptions{Format: ExportJSONLines},
+			want: `{"id":1,"note":"plain","price":12.50,"id_2":true}` + "\n" +
+				`{"id":2,"note":"comma, \"quote\"\tand|pipe\nline","price":null,"id_2":null}` + "\n" +
+				`{"id":3,"note":null,"price":"NaN","id_2":false}` + "\n",
+		},
+		{
+			name:    "markdown",
+			options: ExportOptions{Format: ExportMarkdown, Header: true},
+			want: "| id | note | price | id |\n| --- | --- | --- | --- |\n| 1 | plain | 12.50 | true |\n" +
+				"| 2 | comma, \"quote\"\tand\\|pipe<br>line | NULL | NULL |\n| 3 | NULL | NaN | false |\n",
+		},
+	}
+	exporters := defaultExporters()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := exporters[tt.options.Format].Export(context.Background(), result, &tt.options, &out); err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Fatalf("export =\n%q\nwant\n%q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestExportResultToFileKeepsTargetUntilExportSucceeds(t *testing.T) {
+	service := &QueryService{exporters: defaultExporters(), resultStore: NewResultStore(DefaultMaxMaterializedRows, 0)}
+	defer service.resultStore.Close()
+	service.resultStore.Add(&QueryResult{
+		JobID:      "export",
+		Status:     JobStatusSuccess,
+		Columns:    []QueryColumn{{Name: "id"}},
+		Rows:       [][]any{{int64(1)}, {int64(2)}},
+		RowCount:   2,
+		FinishedAt: time.Now(),
+	})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.csv")
+	if err := os.WriteFile(path, []byte("previous\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	options := &ExportOptions{Format: ExportCSV, Header: true}
+	assertDir := func(want string) {
+		t.Helper()
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("export dir holds %d entries (%v), want only the target", len(entries), err)
+		}
+		if content, _ := os.ReadFile(path); string(content) != want {
+			t.Fatalf("target = %q, want %q", content, want)
+		}
+	}
+
+	if _, err := service.ExportResultToFile(context.Background(), "export", path, false, options); !errors.Is(err, ErrInvalidExportPath) {
+		t.Fatalf("export without overwrite error = %v, want ErrInvalidExportPath", err)
+	}
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := service.ExportResultToFile(canceled, "export", path, true, options); !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled export error = %v, want context.Canceled", err)
+	}
+	assertDir("previous\n")
+
+	info, err := service.ExportResultToFile(context.Background(), "export", path, true, options)
+	if err != nil {
+		t.Fatalf("ExportResultToFile: %v", err)
+	}
+	if info.Bytes != int64(len("id\n1\n2\n")) || info.RowCount != 2 {
+		t.Fatalf("export info = %+v", info)
+	}
+	assertDir("id\n1\n2\n")
+}
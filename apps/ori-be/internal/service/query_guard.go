@@ -0,0 +1,74 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+)
+
+// ErrConfirmationRequired rejects a destructive query on a resource that asks for confirmation.
+var ErrConfirmationRequired = errors.New("confirmation required")
+
+// ConfirmationRequiredError lists the destructive operations found in a query and the
+// token that confirms them when the query is sent again.
+type ConfirmationRequiredError struct {
+	Risks []string
+	Token string
+}
+
+func (e *ConfirmationRequiredError) Error() string {
+	return fmt.Sprintf("%s: query may destroy data (%s)", ErrConfirmationRequired, strings.Join(e.Risks, ", "))
+}
+
+func (e *ConfirmationRequiredError) Unwrap() error {
+	return ErrConfirmationRequired
+}
+
+// checkDestructive applies the resource's destructive guard to query. In warn mode the
+// detected risks are returned so the caller can report them; in confirm mode the query
+// is refused unless confirmToken matches the token for this resource and query. Every
+// statement is checked, script mode or not, since some drivers run them all.
+func checkDestructive(handle *ResourceHandle, query string, confirmToken string) ([]string, error) {
+	mode := model.DestructiveGuardWarn
+	if handle.Resource != nil {
+		mode = handle.Resource.DestructiveGuardMode()
+	}
+	if mode == model.DestructiveGuardOff {
+		return nil, nil
+	}
+
+	dialect := resourceDialect(handle)
+	var risks []string
+	for _, statement := range sqlutil.SplitStatements(query, dialect) {
+		risks = append(risks, sqlutil.DestructiveRisks(statement.Text, dialect)...)
+	}
+	if len(risks) == 0 {
+		return nil, nil
+	}
+
+	token := confirmationToken(handle.Name, query)
+	switch {
+	case mode == model.DestructiveGuardConfirm && confirmToken != token:
+		return nil, &ConfirmationRequiredError{Risks: risks, Token: token}
+	case mode == model.DestructiveGuardWarn:
+		slog.Warn("running destructive query",
+			slog.String("resource", handle.Name),
+			slog.Any("risks", risks))
+		return risks, nil
+	default:
+		return nil, nil
+	}
+}
+
+// confirmationToken ties a confirmation to one query on one resource, so a token
+// cannot confirm a different statement.
+func confirmationToken(resourceName, query string) string {
+	sum := sha256.Sum256([]byte(resourceName + "\x00" + query))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var ErrHistoryUnavailable = errors.New("query history is not available")
+
+const (
+	DefaultHistoryPageSize = 50
+	MaxHistoryPageSize     = 500
+)
+
+// QueryHistoryEntry records a finished query job. Query and Params hold the text and
+// values as submitted, before named parameters were bound.
+type QueryHistoryEntry struct {
+	JobID        string
+	ResourceName string
+	Query        string
+	Params       any
+	Status       JobStatus
+	Error        string
+	RowCount     *int
+	RowsAffected *int64
+	DurationMs   int64
+	SessionID    string
+	Script       bool
+	CreatedAt    time.Time
+	StartedAt    *time.Time
+	FinishedAt   time.Time
+}
+
+// QueryHistoryFilter selects history entries, newest first. Every whitespace-separated
+// term of Search must appear in the query text or error message, ignoring case.
+type QueryHistoryFilter struct {
+	Search       string
+	ResourceName string
+	Statuses     []JobStatus
+	Since        *time.Time
+	Until        *time.Time
+	Limit        int
+	Offset       int
+}
+
+// QueryHistoryPage is one page of matching entries and the number of matches overall.
+type QueryHistoryPage struct {
+	Entries []*QueryHistoryEntry
+	Total   int
+}
+
+// QueryHistoryStore persists finished jobs beyond the lifetime of stored results.
+type QueryHistoryStore interface {
+	Record(ctx context.Context, entry *QueryHistoryEntry) error
+	Search(ctx context.Context, filter *QueryHistoryFilter) (*QueryHistoryPage, error)
+}
+
+// SetHistoryStore enables recording of finished jobs. A nil store disables history.
+func (qs *QueryService) SetHistoryStore(store QueryHistoryStore) {
+	qs.historyMu.Lock()
+	defer qs.historyMu.Unlock()
+	qs.history = store
+}
+
+func (qs *QueryService) historyStore() QueryHistoryStore {
+	qs.historyMu.RLock()
+	defer qs.historyMu.RUnlock()
+	return qs.history
+}
+
+// SearchHistory returns a page of recorded jobs matching the filter.
+func (qs *QueryService) SearchHistory(ctx context.Context, filter *QueryHistoryFilter) (*QueryHistoryPage, error) {
+	store := qs.historyStore()
+	if store == nil {
+		return nil, ErrHistoryUnavailable
+	}
+	if filter == nil {
+		filter = &QueryHistoryFilter{}
+	}
+	normalized := *filter
+	if normalized.Limit <= 0 {
+		normalized.Limit = DefaultHistoryPageSize
+	}
+	if normalized.Limit > MaxHistoryPageSize {
+		normalized.Limit = MaxHistoryPageSize
+	}
+	if normalized.Offset < 0 {
+		normalized.Offset = 0
+	}
+	return store.Search(ctx, &normalized)
+}
+
+// recordHistory stores the finished job. Failures are logged rather than surfaced
+// because history must never affect the outcome of a query.
+func (qs *QueryService) recordHistory(ctx context.Context, job *QueryJob, result *QueryResult) {
+	store := qs.historyStore()
+	if store == nil {
+		return
+	}
+
+	entry := &QueryHistoryEntry{
+		JobID:        job.ID,
+		ResourceName: job.ResourceName,
+		Query:        job.sourceQuery,
+		Params:       job.sourceParams,
+		Status:       result.Status,
+		Error:        result.Error,
+		DurationMs:   result.DurationMs,
+		SessionID:    job.SessionID,
+		Script:       job.Options != nil && job.Options.Script,
+		CreatedAt:    job.CreatedAt,
+		StartedAt:    job.StartedAt,
+		FinishedAt:   result.FinishedAt,
+	}
+	entry.RowCount, entry.RowsAffected = historyCounts(result)
+
+	// Record even when the job was canceled by shutdown.
+	recordCtx := context.WithoutCancel(ctx)
+	if err := store.Record(recordCtx, entry); err != nil {
+		slog.WarnContext(ctx, "failed to record query history",
+			slog.String("jobId", job.ID),
+			slog.Any("err", err))
+	}
+}
+
+// historyCounts reports the rows returned and affected by a job. Scripts report their
+// last statement that succeeded.
+func historyCounts(result *QueryResult) (*int, *int64) {
+	source := result
+	if len(result.Statements) > 0 {
+		source = nil
+		for i := len(result.Statements) - 1; i >= 0 && source == nil; i-- {
+			source = result.Statements[i].Result
+		}
+	} else if result.Status != JobStatusSuccess {
+		source = nil
+	}
+	if source == nil {
+		return nil, nil
+	}
+	if source.RowsAffected != nil && source.Columns == nil {
+		return nil, source.RowsAffected
+	}
+	rowCount := source.RowCount
+	return &rowCount, source.RowsAffected
+}
@@ -2,17 +2,22 @@ package service
 
 import (
 	"context"
+	"sync"
 	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
 )
 
 // JobStatus represents the status of a query job
 type JobStatus string
 
 const (
+	JobStatusQueued   JobStatus = "queued"
 	JobStatusRunning  JobStatus = "running"
 	JobStatusSuccess  JobStatus = "success"
 	JobStatusFailed   JobStatus = "failed"
 	JobStatusCanceled JobStatus = "canceled"
+	JobStatusTimedOut JobStatus = "timed_out"
 )
 
 // QueryJob represents an asynchronous query execution job
@@ -28,7 +33,22 @@ type QueryJob struct {
 	FinishedAt   *time.Time
 	DurationMs   int64
 	Error        string
-	Cancel       context.CancelFunc
+	Statements   []*StatementResult
+	SessionID    string
+	TxState      TxState
+	// Warnings lists destructive operations the resource's guard let through.
+	Warnings []string
+	Cancel   context.CancelFunc
+
+	session  *QuerySession
+	progress *QueryProgress
+	notices  *QueryNotices
+
+	// sourceQuery and sourceParams are the query as submitted, before named parameters were bound.
+	sourceQuery  string
+	sourceParams any
+	// paramShifts map offsets of Query back to sourceQuery.
+	paramShifts []sqlutil.ParamShift
 }
 
 // QueryColumn represents column metadata for query results
@@ -44,10 +64,30 @@ type QueryResult struct {
 	Status       JobStatus
 	Columns      []QueryColumn
 	Rows         [][]any
+	Segment      *ResultSegment
 	RowCount     int
 	Truncated    bool
 	RowsAffected *int64
+	CommandTag   string // completion tag such as "INSERT 0 5" or "UPDATE 3"
+	Statements   []*StatementResult
+	SessionID    string
+	TxState      TxState
 	Error        string
 	FinishedAt   time.Time
 	DurationMs   int64
+	// DatabaseError holds the structured fields of Error when the database reported it.
+	DatabaseError *DatabaseError
+	// Notices are the messages the database sent while the job ran, in order.
+	Notices []QueryNotice
+	// DroppedNotices counts the notices received after the first maxJobNotices.
+	DroppedNotices int
+
+	viewMu      sync.Mutex
+	viewIndexes []cachedViewIndex
+
+	// rowsMu guards Rows, RowCount, Segment and Truncated while a cursor appends to them.
+	rowsMu   sync.RWMutex
+	lazy     bool
+	cursorMu sync.Mutex
+	cursor   *resultCursor
 }
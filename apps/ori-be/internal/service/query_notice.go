@@ -0,0 +1,118 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/events"
+)
+
+// maxJobNotices caps the notices kept per job; later ones are only counted.
+const maxJobNotices = 100
+
+// QueryNotice is a message the database sent while a job ran, such as RAISE NOTICE
+// output or a warning about a COMMIT outside a transaction.
+type QueryNotice struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	// Statement is the zero-based index of the script statement that raised the notice.
+	Statement *int `json:"statement,omitempty"`
+}
+
+// QueryNotices collects the notices of a running job in arrival order. Adapters add
+// to it from their driver's notice callback; the service publishes them in batches.
+// Only the first maxJobNotices are kept, later ones are counted as dropped.
+// All methods are safe to call on a nil receiver.
+type QueryNotices struct {
+	mu       sync.Mutex
+	notices  []QueryNotice
+	dropped  int
+	progress *QueryProgress
+
+	// published and publishedDropped track what the last batch covered.
+	published        int
+	publishedDropped int
+}
+
+func newQueryNotices(progress *QueryProgress) *QueryNotices {
+	return &QueryNotices{progress: progress}
+}
+
+// Add records a notice against the statement the job is running.
+func (n *QueryNotices) Add(notice QueryNotice) {
+	if n == nil {
+		return
+	}
+	notice.Statement = n.progress.currentStatement()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.notices) >= maxJobNotices {
+		n.dropped++
+		return
+	}
+	n.notices = append(n.notices, notice)
+}
+
+func (n *QueryNotices) list() ([]QueryNotice, int) {
+	if n == nil {
+		return nil, 0
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]QueryNotice(nil), n.notices...), n.dropped
+}
+
+// flush passes the notices kept since the previous flush, and the count dropped so far,
+// to publish when either changed. Flushes are serialized so batches arrive in order.
+func (n *QueryNotices) flush(publish func([]QueryNotice, int)) {
+	if n == nil {
+		return
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.published == len(n.notices) && n.publishedDropped == n.dropped {
+		return
+	}
+	publish(n.notices[n.published:], n.dropped)
+	n.published = len(n.notices)
+	n.publishedDropped = n.dropped
+}
+
+// noticesFor returns the notices raised by statement, or all notices when statement is nil.
+func noticesFor(notices []QueryNotice, statement *int) []QueryNotice {
+	if statement == nil {
+		return notices
+	}
+	var selected []QueryNotice
+	for _, notice := range notices {
+		if notice.Statement != nil && *notice.Statement == *statement {
+			selected = append(selected, notice)
+		}
+	}
+	return selected
+}
+
+// publishNotices emits a query.job.notice event with the notices a running job
+// received since the previous event.
+func (qs *QueryService) publishNotices(job *QueryJob, notices *QueryNotices) {
+	if qs.eventHub == nil {
+		return
+	}
+	notices.flush(func(batch []QueryNotice, dropped int) {
+		payload := events.QueryJobNoticePayload{
+			JobID:          job.ID,
+			ResourceName:   job.ResourceName,
+			Notices:        make([]events.QueryJobNotice, len(batch)),
+			DroppedNotices: dropped,
+		}
+		for i, notice := range batch {
+			payload.Notices[i] = events.QueryJobNotice{
+				Statement: notice.Statement,
+				Severity:  notice.Severity,
+				Code:      notice.Code,
+				Message:   notice.Message,
+			}
+		}
+		qs.eventHub.Publish(events.Event{Name: events.QueryJobNoticeEvent, Payload: payload})
+	})
+}
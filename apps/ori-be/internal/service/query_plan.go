@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+)
+
+var (
+	ErrExplainUnsupported = errors.New("explain option is not supported by this engine")
+	ErrExplainFailed      = errors.New("explain failed")
+)
+
+// QueryExplainOptions contains options for explaining a query
+type QueryExplainOptions struct {
+	// Analyze executes the statement to collect actual rows and timings.
+	// Adapters run it inside a transaction that is rolled back.
+	Analyze bool
+}
+
+// QueryPlan is an engine-neutral query plan
+type QueryPlan struct {
+	Analyzed        bool
+	PlanningTimeMs  *float64
+	ExecutionTimeMs *float64
+	// Nodes holds the top-level plan nodes; most engines produce exactly one.
+	Nodes []*QueryPlanNode
+}
+
+// QueryPlanNode is a single operator of a query plan. Fields the engine does not
+// report are left empty.
+type QueryPlanNode struct {
+	NodeType      string
+	Relation      string
+	Detail        string
+	EstimatedRows *float64
+	ActualRows    *float64
+	StartupCost   *float64
+	TotalCost     *float64
+	ActualTimeMs  *float64
+	Loops         *int64
+	Children      []*QueryPlanNode
+}
+
+// Explain returns the plan the resource's engine chooses for a single statement.
+func (qs *QueryService) Explain(ctx context.Context, resourceName, query string, params any, options *QueryExplainOptions) (*QueryPlan, error) {
+	if options == nil {
+		options = &QueryExplainOptions{}
+	}
+
+	handle, ok := qs.connectionService.GetConnection(resourceName)
+	if !ok || handle == nil || handle.Adapter == nil {
+		return nil, fmt.Errorf("%w: %s", ErrConnectionUnavailable, resourceName)
+	}
+
+	statements := sqlutil.SplitStatements(query, resourceDialect(handle))
+	if len(statements) != 1 {
+		return nil, fmt.Errorf("%w: explain takes exactly one statement, got %d", ErrInvalidOptions, len(statements))
+	}
+	source, start := query, statements[0].Offset
+	query = statements[0].Text
+	if options.Analyze {
+		if err := checkReadOnly(handle, query); err != nil {
+			return nil, err
+		}
+	}
+
+	query, params, shifts, err := bindNamedParams(handle, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := handle.Adapter.ExplainQuery(ctx, query, params, options)
+	if err != nil {
+		if errors.Is(err, ErrExplainUnsupported) {
+			return nil, err
+		}
+		if located := locateDatabaseError(err, query, 0, shifts, source, start); located != nil {
+			err = located
+		}
+		return nil, fmt.Errorf("%w: %w", ErrExplainFailed, err)
+	}
+	return plan, nil
+}
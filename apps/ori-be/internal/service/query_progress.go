@@ -0,0 +1,182 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/events"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+const (
+	// progressInterval throttles query.job.progress events per job.
+	progressInterval = 500 * time.Millisecond
+	// progressPageRows bounds the partial first page kept while a job runs.
+	progressPageRows = 100
+)
+
+// QueryProgress tracks the rows a running job has materialized so far. Adapters
+// feed it through the RowCollector; the service publishes throttled snapshots.
+// All methods are safe to call on a nil receiver.
+type QueryProgress struct {
+	mu        sync.Mutex
+	startedAt time.Time
+	statement *int
+	columns   []QueryColumn
+	page      [][]any
+	rows      int
+	bytes     int64
+}
+
+// QueryProgressSnapshot is a point-in-time copy of a job's progress
+type QueryProgressSnapshot struct {
+	Statement         *int
+	RowsFetched       int
+	BytesMaterialized int64
+	ElapsedMs         int64
+}
+
+func newQueryProgress(startedAt time.Time) *QueryProgress {
+	return &QueryProgress{startedAt: startedAt}
+}
+
+// beginStatement resets the counters when a script moves on to its next statement.
+func (p *QueryProgress) beginStatement(index int) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.statement = &index
+	p.columns = nil
+	p.page = nil
+	p.rows = 0
+	p.bytes = 0
+}
+
+func (p *QueryProgress) setColumns(columns []QueryColumn) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.columns = columns
+}
+
+func (p *QueryProgress) addRow(row []any, size int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.page) < progressPageRows {
+		p.page = append(p.page, row)
+	}
+	p.rows++
+	p.bytes += size
+}
+
+func (p *QueryProgress) snapshot(now time.Time) QueryProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return QueryProgressSnapshot{
+		Statement:         p.statement,
+		RowsFetched:       p.rows,
+		BytesMaterialized: p.bytes,
+		ElapsedMs:         now.Sub(p.startedAt).Milliseconds(),
+	}
+}
+
+// partialView builds a view over the rows fetched so far. It fails until the
+// running statement has produced its columns.
+func (p *QueryProgress) partialView(statement, limit, offset *int) (*QueryResultView, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if statement != nil && (p.statement == nil || *statement != *p.statement) {
+		return nil, fmt.Errorf("%w: statement %d is not running", ErrResultUnavailable, *statement)
+	}
+	if p.columns == nil {
+		return nil, fmt.Errorf("%w: no rows fetched yet", ErrResultUnavailable)
+	}
+
+	start := 0
+	if offset != nil {
+		start = min(*offset, len(p.page))
+	}
+	end := len(p.page)
+	if limit != nil {
+		end = min(start+*limit, end)
+	}
+	rows := make([][]any, end-start)
+	copy(rows, p.page[start:end])
+
+	return &QueryResultView{
+		Columns:  p.columns,
+		Rows:     rows,
+		RowCount: p.rows,
+		Partial:  true,
+	}, nil
+}
+
+// reportProgress publishes query.job.progress events, and the job's notices received
+// since the previous tick, until done is closed.
+func (qs *QueryService) reportProgress(job *QueryJob, progress *QueryProgress, notices *QueryNotices, done <-chan struct{}) {
+	if qs.eventHub == nil {
+		return
+	}
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			snapshot := progress.snapshot(now)
+			qs.eventHub.Publish(events.Event{
+				Name: events.QueryJobProgressEvent,
+				Payload: events.QueryJobProgressPayload{
+					JobID:             job.ID,
+					ResourceName:      job.ResourceName,
+					Statement:         snapshot.Statement,
+					RowsFetched:       snapshot.RowsFetched,
+					BytesMaterialized: snapshot.BytesMaterialized,
+					ElapsedMs:         snapshot.ElapsedMs,
+				},
+			})
+			qs.publishNotices(job, notices)
+		}
+	}
+}
+
+// rowSize estimates the memory held by a materialized row.
+func rowSize(row []any) int64 {
+	var size int64
+	for _, value := range row {
+		switch v := value.(type) {
+		case string:
+			size += int64(len(v))
+		case querycell.Tagged:
+			if text, ok := v.Value.(string); ok {
+				size += int64(len(text))
+			} else {
+				size += 8
+			}
+		case nil:
+		default:
+			size += 8
+		}
+	}
+	return size
+}
+
+// currentStatement returns the index of the script statement being run, if any.
+func (p *QueryProgress) currentStatement() *int {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.statement
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+)
+
+const (
+	// DefaultCursorIdleTimeout is how long a lazy result keeps its cursor open without page requests.
+	DefaultCursorIdleTimeout = 2 * time.Minute
+	// DefaultMaxOpenCursors is how many lazy results of a resource keep their cursor open
+	// at once. Opening another closes the least recently paged one.
+	DefaultMaxOpenCursors = 4
+
+	cursorSweepInterval = 15 * time.Second
+)
+
+// RowCursor yields the remaining rows of a lazily fetched result. Adapters keep the
+// statement and the connection it runs on open until Close.
+type RowCursor interface {
+	// Next returns up to n further rows encoded as cells. Fewer than n rows means
+	// the cursor is exhausted.
+	Next(ctx context.Context, n int) ([][]any, error)
+	// Close releases the cursor and its connection.
+	Close() error
+}
+
+// NewCursorResult returns a result whose rows are fetched from cursor as pages ask for them.
+func NewCursorResult(columns []QueryColumn, cursor RowCursor) *QueryResult {
+	return &QueryResult{
+		Status:  JobStatusSuccess,
+		Columns: columns,
+		lazy:    true,
+		cursor:  &resultCursor{source: cursor},
+	}
+}
+
+// resultCursor is the open cursor of a lazy result and the collector its rows go to.
+type resultCursor struct {
+	source    RowCursor
+	collector *RowCollector
+	fetchRows int
+	lastUsed  time.Time
+}
+
+// startCursor fetches the first page of a lazy result. The cursor is closed on failure.
+func (r *QueryResult) startCursor(ctx context.Context, options *QueryExecOptions) error {
+	r.cursorMu.Lock()
+	defer r.cursorMu.Unlock()
+	cursor := r.cursor
+	if cursor == nil {
+		return nil
+	}
+
+	cursor.collector = NewRowCollector(options)
+	cursor.collector.SetColumns(r.Columns)
+	cursor.fetchRows = options.FetchRows
+	if cursor.fetchRows <= 0 {
+		cursor.fetchRows = model.DefaultAutoLimitRows
+	}
+	err := r.fetchLocked(ctx, cursor.fetchRows)
+	// Later pages are fetched after the job finished and do not report progress.
+	cursor.collector.progress = nil
+	return err
+}
+
+// fetchRows fetches from the open cursor until at least end rows are buffered. A
+// negative end drains the cursor.
+func (r *QueryResult) fetchRows(ctx context.Context, end int) error {
+	r.cursorMu.Lock()
+	defer r.cursorMu.Unlock()
+	if r.cursor == nil {
+		return nil
+	}
+	return r.fetchLocked(ctx, end)
+}
+
+func (r *QueryResult) fetchLocked(ctx context.Context, end int) error {
+	cursor := r.cursor
+	collector := cursor.collector
+	cursor.lastUsed = time.Now()
+
+	for r.cursor != nil && (end < 0 || collector.Len() < end) {
+		// Fetch in fixed batches however far the page asks for, so one request cannot
+		// pull an unbounded number of rows at once. A full collector closes the cursor.
+		want := cursor.fetchRows
+		rows, err := cursor.source.Next(ctx, want)
+		if err != nil {
+			r.closeCursorLocked(true)
+			return fmt.Errorf("failed to fetch rows: %w", err)
+		}
+
+		truncated := false
+		for i, row := range rows {
+			if collector.Full() {
+				truncated = true
+				break
+			}
+			if err := collector.Append(row); err != nil {
+				r.closeCursorLocked(true)
+				return err
+			}
+			if i == len(rows)-1 && collector.Full() && len(rows) == want {
+				// The limit landed on the batch boundary; peek to learn whether rows remain.
+				more, err := cursor.source.Next(ctx, 1)
+				if err != nil {
+					r.closeCursorLocked(true)
+					return fmt.Errorf("failed to fetch rows: %w", err)
+				}
+				truncated = len(more) > 0
+			}
+		}
+
+		switch {
+		case collector.Full() || len(rows) < want:
+			r.closeCursorLocked(truncated)
+		default:
+			r.rowsMu.Lock()
+			err = collector.publish(r)
+			r.rowsMu.Unlock()
+			if err != nil {
+				r.closeCursorLocked(true)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// closeCursor closes an open cursor, keeping the rows fetched so far.
+func (r *QueryResult) closeCursor() {
+	r.cursorMu.Lock()
+	defer r.cursorMu.Unlock()
+	if r.cursor != nil {
+		r.closeCursorLocked(true)
+	}
+}
+
+// closeIdleCursor closes the cursor when no page was requested within timeout.
+func (r *QueryResult) closeIdleCursor(now time.Time, timeout time.Duration) {
+	r.cursorMu.Lock()
+	defer r.cursorMu.Unlock()
+	if r.cursor == nil || now.Sub(r.cursor.lastUsed) < timeout {
+		return
+	}
+	slog.Info("closing idle result cursor",
+		slog.String("jobId", r.JobID),
+		slog.Int("rowCount", r.RowCount))
+	r.closeCursorLocked(true)
+}
+
+// closeCursorLocked seals the fetched rows. truncated reports whether rows may remain unread.
+func (r *QueryResult) closeCursorLocked(truncated bool) {
+	cursor := r.cursor
+	r.cursor = nil
+
+	var err error
+	if cursor.collector != nil {
+		r.rowsMu.Lock()
+		err = cursor.collector.Finish(r)
+		r.Truncated = truncated
+		r.rowsMu.Unlock()
+	}
+	if err != nil {
+		slog.Warn("failed to seal lazily fetched rows",
+			slog.String("jobId", r.JobID),
+			slog.Any("err", err))
+	}
+	if err := cursor.source.Close(); err != nil {
+		slog.Warn("failed to close result cursor",
+			slog.String("jobId", r.JobID),
+			slog.Any("err", err))
+	}
+}
+
+// cursorLastUsed returns when the open cursor last fetched rows. It reports false
+// when the result has no open cursor.
+func (r *QueryResult) cursorLastUsed() (time.Time, bool) {
+	r.cursorMu.Lock()
+	defer r.cursorMu.Unlock()
+	if r.cursor == nil {
+		return time.Time{}, false
+	}
+	return r.cursor.lastUsed, true
+}
+
+// rowState returns the buffered row count, whether the result was truncated and
+// whether more rows can still be fetched.
+func (r *QueryResult) rowState() (int, bool, bool) {
+	r.cursorMu.Lock()
+	open := r.cursor != nil
+	r.cursorMu.Unlock()
+
+	r.rowsMu.RLock()
+	defer r.rowsMu.RUnlock()
+	return r.RowCount, r.Truncated, open
+}
+
+// watchCursors periodically closes cursors of lazy results nobody is paging through.
+func (qs *QueryService) watchCursors(done <-chan struct{}) {
+	ticker := time.NewTicker(cursorSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			if qs.cursorIdleTimeout > 0 {
+				qs.resultStore.closeIdleCursors(now, qs.cursorIdleTimeout)
+			}
+		}
+	}
+}
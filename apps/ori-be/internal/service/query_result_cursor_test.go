@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type fakeRowCursor struct {
+	next  int
+	total int
+	calls int
+	// largest is the largest batch Next was asked for.
+	largest int
+	closed  bool
+}
+
+func (c *fakeRowCursor) Next(_ context.Context, n int) ([][]any, error) {
+	c.calls++
+	c.largest = max(c.largest, n)
+	var rows [][]any
+	for ; len(rows) < n && c.next < c.total; c.next++ {
+		rows = append(rows, []any{int64(c.next)})
+	}
+	return rows, nil
+}
+
+func (c *fakeRowCursor) Close() error {
+	c.closed = true
+	return nil
+}
+
+func newLazyResult(t *testing.T, source *fakeRowCursor, options *QueryExecOptions) (*QueryService, *QueryResult) {
+	t.Helper()
+	result := NewCursorResult([]QueryColumn{{Name: "n"}}, source)
+	result.JobID = "job"
+	result.FinishedAt = time.Now()
+	if err := result.startCursor(context.Background(), options); err != nil {
+		t.Fatalf("startCursor: %v", err)
+	}
+	service := &QueryService{activeJobs: map[string]*QueryJob{}, resultStore: NewResultStore(DefaultMaxMaterializedRows, 0)}
+	service.resultStore.Add(result)
+	t.Cleanup(service.resultStore.Close)
+	return service, result
+}
+
+func TestLazyResultFetchesPagesOnDemand(t *testing.T) {
+	source := &fakeRowCursor{total: 25}
+	service, _ := newLazyResult(t, source, &QueryExecOptions{FetchRows: 10})
+	ctx := context.Background()
+	limit := 5
+
+	offset := 5
+	view, err := service.BuildResultView(ctx, "job", nil, &limit, &offset, nil)
+	if err != nil {
+		t.Fatalf("BuildResultView: %v", err)
+	}
+	if source.calls != 1 || view.RowCount != 10 || !view.HasMore || view.Rows[0][0] != int64(5) {
+		t.Fatalf("first page: calls=%d view=%+v", source.calls, view)
+	}
+
+	sorted := &ResultViewQuery{Sort: []ResultSort{{Column: "n"}}}
+	if _, err := service.BuildResultView(ctx, "job", nil, &limit, nil, sorted); !errors.Is(err, ErrResultUnavailable) {
+		t.Fatalf("sorting an open cursor: err = %v", err)
+	}
+
+	offset = 12
+	view, err = service.BuildResultView(ctx, "job", nil, &limit, &offset, nil)
+	if err != nil {
+		t.Fatalf("BuildResultView: %v", err)
+	}
+	if view.RowCount != 20 || !view.HasMore || view.Rows[0][0] != int64(12) || len(view.Rows) != 5 {
+		t.Fatalf("second page: view=%+v", view)
+	}
+
+	offset = 22
+	view, err = service.BuildResultView(ctx, "job", nil, &limit, &offset, nil)
+	if err != nil {
+		t.Fatalf("BuildResultView: %v", err)
+	}
+	if view.RowCount != 25 || view.HasMore || view.Truncated || len(view.Rows) != 3 || !source.closed {
+		t.Fatalf("last page: closed=%v view=%+v", source.closed, view)
+	}
+}
+
+func TestLazyResultStopsAtMaxRows(t *testing.T) {
+	source := &fakeRowCursor{total: 30}
+	service, _ := newLazyResult(t, source, &QueryExecOptions{FetchRows: 10, MaxRows: 20})
+	limit := 10
+	offset := 15
+
+	view, err := service.BuildResultView(context.Background(), "job", nil, &limit, &offset, nil)
+	if err != nil {
+		t.Fatalf("BuildResultView: %v", err)
+	}
+	if view.RowCount != 20 || !view.Truncated || view.HasMore || len(view.Rows) != 5 || !source.closed {
+		t.Fatalf("closed=%v view=%+v", source.closed, view)
+	}
+}
+
+func TestLazyResultFetchesFarPagesInBatches(t *testing.T) {
+	source := &fakeRowCursor{total: 1000}
+	service, _ := newLazyResult(t, source, &QueryExecOptions{FetchRows: 10, MaxRows: 50})
+	limit := 1
+	offset := 2000000000
+
+	view, err := service.BuildResultView(context.Background(), "job", nil, &limit, &offset, nil)
+	if err != nil {
+		t.Fatalf("BuildResultView: %v", err)
+	}
+	if source.largest != 10 || view.RowCount != 50 || !view.Truncated || len(view.Rows) != 0 || !source.closed {
+		t.Fatalf("largest batch=%d closed=%v view=%+v", source.largest, source.closed, view)
+	}
+
+	limit = math.MaxInt
+	if _, err := service.BuildResultView(context.Background(), "job", nil, &limit, &offset, nil); err == nil {
+		t.Fatal("BuildResultView accepted a limit and offset that overflow")
+	}
+}
+
+func TestLazyResultClosesIdleCursor(t *testing.T) {
+	source := &fakeRowCursor{total: 25}
+	service, result := newLazyResult(t, source, &QueryExecOptions{FetchRows: 10})
+
+	service.resultStore.closeIdleCursors(time.Now(), time.Hour)
+	if source.closed {
+		t.Fatal("cursor closed before its idle timeout")
+	}
+	service.resultStore.closeIdleCursors(time.Now().Add(time.Hour), time.Hour)
+	rowCount, truncated, open := result.rowState()
+	if !source.closed || open || !truncated || rowCount != 10 {
+		t.Fatalf("closed=%v open=%v truncated=%v rowCount=%d", source.closed, open, truncated, rowCount)
+	}
+
+	limit := 5
+	offset := 8
+	view, err := service.BuildResultView(context.Background(), "job", nil, &limit, &offset, nil)
+	if err != nil {
+		t.Fatalf("BuildResultView: %v", err)
+	}
+	if len(view.Rows) != 2 || view.Rows[1][0] != int64(9) {
+		t.Fatalf("view after close = %+v", view)
+	}
+}
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultSpillMemoryRows = 10000
+	DefaultSpillDiskQuota  = int64(2 << 30)
+
+	segmentFileSuffix         = ".rows"
+	segmentCheckpointInterval = 1024
+)
+
+// ResultSpill configures how materialized rows overflow from memory into segment files.
+type ResultSpill struct {
+	Dir        string
+	MemoryRows int
+	DiskQuota  int64
+}
+
+// NewResultSpill prepares the segment directory and removes segments left behind by previous runs.
+func NewResultSpill(dir string, memoryRows int, diskQuota int64) (*ResultSpill, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create result spill directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result spill directory: %w", err)
+	}
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), segmentFileSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) < resultMaxAge {
+			continue
+		}
+		_ = os.Remove(filepath.Join(dir, entry.Name()))
+	}
+	return &ResultSpill{Dir: dir, MemoryRows: memoryRows, DiskQuota: diskQuota}, nil
+}
+
+// ResultSegment is an append-once file holding the rows of a result past its in-memory prefix.
+type ResultSegment struct {
+	path        string
+	file        *os.File
+	size        int64
+	rows        int
+	checkpoints []int64
+
+	// mu makes Remove wait for in-flight reads; readers hold it shared.
+	mu      sync.RWMutex
+	removed bool
+}
+
+// Rows returns the number of rows stored in the segment.
+func (s *ResultSegment) Rows() int {
+	if s == nil {
+		return 0
+	}
+	return s.rows
+}
+
+// Size returns the segment size in bytes.
+func (s *ResultSegment) Size() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.size
+}
+
+// ReadRows decodes rows in the half-open range [start, end).
+func (s *ResultSegment) ReadRows(start, end int) ([][]any, error) {
+	if s == nil {
+		return nil, fmt.Errorf("result segment is missing")
+	}
+	if start < 0 || end > s.rows || start > end {
+		return nil, fmt.Errorf("segment range [%d, %d) out of bounds for %d rows", start, end, s.rows)
+	}
+	if start == end {
+		return [][]any{}, nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.removed {
+		return nil, fmt.Errorf("result segment was removed")
+	}
+	checkpoint := start / segmentCheckpointInterval
+	offset := s.checkpoints[checkpoint]
+	reader := bufio.NewReader(io.NewSectionReader(s.file, offset, s.size-offset))
+
+	rows := make([][]any, 0, end-start)
+	for index := checkpoint * segmentCheckpointInterval; index < end; index++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			return nil, fmt.Errorf("failed to read result segment: %w", err)
+		}
+		if index < start {
+			continue
+		}
+		row, err := decodeSegmentRow(line)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Remove closes and deletes the segment file once in-flight reads finish.
+func (s *ResultSegment) Remove() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.removed {
+		return nil
+	}
+	s.removed = true
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	return os.Remove(s.path)
+}
+
+func decodeSegmentRow(line []byte) ([]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	var row []any
+	if err := decoder.Decode(&row); err != nil {
+		return nil, fmt.Errorf("failed to decode result segment row: %w", err)
+	}
+	return row, nil
+}
+
+// RowCollector accumulates scanned rows for a QueryResult. Rows past the spill
+// threshold are written to a segment file instead of being kept in memory.
+type RowCollector struct {
+	maxRows  int
+	spill    *ResultSpill
+	progress *QueryProgress
+
+	rows  [][]any
+	count int
+
+	file        *os.File
+	writer      *bufio.Writer
+	size        int64
+	checkpoints []int64
+	segmentRows int
+	quotaHit    bool
+}
+
+// NewRowCollector builds a collector honoring the row limit and spill settings in options.
+func NewRowCollector(options *QueryExecOptions) *RowCollector {
+	collector := &RowCollector{}
+	if options != nil {
+		collector.maxRows = options.MaxRows
+		collector.spill = options.Spill
+		collector.progress = options.Progress
+	}
+	return collector
+}
+
+// SetColumns records the columns of the collected rows for progress reporting.
+func (c *RowCollector) SetColumns(columns []QueryColumn) {
+	c.progress.setColumns(columns)
+}
+
+// Len returns the number of collected rows.
+func (c *RowCollector) Len() int {
+	return c.count
+}
+
+// Full reports whether the collector refuses further rows, either because the
+// row limit was reached or because the spill segment exhausted the disk quota.
+func (c *RowCollector) Full() bool {
+	if c.maxRows > 0 && c.count >= c.maxRows {
+		return true
+	}
+	return c.quotaHit
+}
+
+// Append stores a row, spilling it to disk once the in-memory threshold is reached.
+func (c *RowCollector) Append(row []any) error {
+	if c.spill == nil || c.spill.MemoryRows <= 0 || len(c.rows) < c.spill.MemoryRows {
+		c.rows = append(c.rows, row)
+		c.count++
+		c.progress.addRow(row, rowSize(row))
+		return nil
+	}
+
+	if c.file == nil {
+		file, err := os.CreateTemp(c.spill.Dir, "result-*"+segmentFileSuffix)
+		if err != nil {
+			return fmt.Errorf("failed to create result segment: %w", err)
+		}
+		c.file = file
+		c.writer = bufio.NewWriter(file)
+	}
+
+	payload, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("failed to encode result row: %w", err)
+	}
+	if c.segmentRows%segmentCheckpointInterval == 0 {
+		c.checkpoints = append(c.checkpoints, c.size)
+	}
+	payload = append(payload, '\n')
+	if _, err := c.writer.Write(payload); err != nil {
+		return fmt.Errorf("failed to write result segment: %w", err)
+	}
+	c.size += int64(len(payload))
+	c.segmentRows++
+	c.count++
+	c.progress.addRow(row, int64(len(payload)))
+	if c.spill.DiskQuota > 0 && c.size >= c.spill.DiskQuota {
+		c.quotaHit = true
+	}
+	return nil
+}
+
+// Finish moves the collected rows into result and seals the spill segment.
+func (c *RowCollector) Finish(result *QueryResult) error {
+	if err := c.publish(result); err != nil {
+		c.Discard()
+		return err
+	}
+	if c.file == nil {
+		return nil
+	}
+
+	if c.quotaHit {
+		slog.Warn("result segment reached the disk quota",
+			slog.String("path", c.file.Name()),
+			slog.Int64("bytes", c.size))
+	}
+	c.file = nil
+	c.writer = nil
+	return nil
+}
+
+// publish exposes the rows collected so far on result while keeping the segment
+// open for further rows.
+func (c *RowCollector) publish(result *QueryResult) error {
+	if c.writer != nil {
+		if err := c.writer.Flush(); err != nil {
+			return fmt.Errorf("failed to flush result segment: %w", err)
+		}
+	}
+	result.Rows = c.rows
+	result.RowCount = c.count
+	if c.file == nil {
+		return nil
+	}
+
+	if result.Segment == nil {
+		result.Segment = &ResultSegment{path: c.file.Name(), file: c.file}
+	}
+	result.Segment.size = c.size
+	result.Segment.rows = c.segmentRows
+	result.Segment.checkpoints = append([]int64(nil), c.checkpoints...)
+	return nil
+}
+
+// Discard removes a segment that was started but never handed to a result.
+func (c *RowCollector) Discard() {
+	if c.file == nil {
+		return
+	}
+	_ = c.file.Close()
+	_ = os.Remove(c.file.Name())
+	c.file = nil
+	c.writer = nil
+}
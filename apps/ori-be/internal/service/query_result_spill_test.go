@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestRowCollectorSpillsPastMemoryRows(t *testing.T) {
+	dir := t.TempDir()
+	spill := &ResultSpill{Dir: dir, MemoryRows: 3}
+	collector := NewRowCollector(&QueryExecOptions{Spill: spill})
+
+	total := segmentCheckpointInterval + 10
+	for i := 0; i < total; i++ {
+		if err := collector.Append([]any{i, "row"}); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+	result := &QueryResult{JobID: "job"}
+	if err := collector.Finish(result); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	defer func() { _ = result.Segment.Remove() }()
+
+	if result.RowCount != total {
+		t.Fatalf("RowCount = %d, want %d", result.RowCount, total)
+	}
+	if len(result.Rows) != 3 {
+		t.Fatalf("resident rows = %d, want 3", len(result.Rows))
+	}
+	if result.Segment.Rows() != total-3 {
+		t.Fatalf("segment rows = %d, want %d", result.Segment.Rows(), total-3)
+	}
+
+	start := 1
+	end := segmentCheckpointInterval + 8
+	rows, err := result.ReadRows(start, end)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	if len(rows) != end-start {
+		t.Fatalf("ReadRows returned %d rows, want %d", len(rows), end-start)
+	}
+	for i, row := range rows {
+		want := start + i
+		if got := fmt.Sprint(row[0]); got != strconv.Itoa(want) {
+			t.Fatalf("row %d id = %s, want %d", i, got, want)
+		}
+	}
+
+	path := result.Segment.path
+	if err := result.Segment.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("segment should be deleted, stat err = %v", err)
+	}
+}
+
+func TestResultSegmentRemoveWaitsForReads(t *testing.T) {
+	collector := NewRowCollector(&QueryExecOptions{Spill: &ResultSpill{Dir: t.TempDir(), MemoryRows: 1}})
+	for i := 0; i < 2*segmentCheckpointInterval; i++ {
+		if err := collector.Append([]any{i}); err != nil {
+			t.Fatalf("Append(%d): %v", i, err)
+		}
+	}
+	result := &QueryResult{JobID: "job"}
+	if err := collector.Finish(result); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	segment := result.Segment
+
+	errs := make(chan error, 8)
+	var wg sync.WaitGroup
+	for range cap(errs) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if _, err := segment.ReadRows(0, segment.Rows()); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	if err := segment.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err.Error() != "result segment was removed" {
+			t.Fatalf("read during Remove failed with %v, want only reads after it to fail", err)
+		}
+	}
+}
+
+func TestRowCollectorStopsAtDiskQuota(t *testing.T) {
+	spill := &ResultSpill{Dir: t.TempDir(), MemoryRows: 1, DiskQuota: 16}
+	collector := NewRowCollector(&QueryExecOptions{Spill: spill})
+	defer collector.Discard()
+
+	for i := 0; !collector.Full(); i++ {
+		if i > 10 {
+			t.Fatal("collector should report full once the disk quota is reached")
+		}
+		if err := collector.Append([]any{"payload"}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+}
+
+func TestNewResultSpillKeepsFreshSegments(t *testing.T) {
+	dir := t.TempDir()
+	fresh, err := os.CreateTemp(dir, "result-*"+segmentFileSuffix)
+	if err != nil {
+		t.Fatalf("CreateTemp: %v", err)
+	}
+	_ = fresh.Close()
+
+	if _, err := NewResultSpill(dir, DefaultSpillMemoryRows, DefaultSpillDiskQuota); err != nil {
+		t.Fatalf("NewResultSpill: %v", err)
+	}
+	if _, err := os.Stat(fresh.Name()); err != nil {
+		t.Fatalf("fresh segment should survive startup cleanup: %v", err)
+	}
+}
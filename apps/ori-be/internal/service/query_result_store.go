@@ -13,20 +13,23 @@ const (
 )
 
 // ResultStore keeps a bounded set of terminal jobs and their result payloads.
+// In-memory rows are bounded by maxResidentRows; spilled segments are bounded by diskQuota.
 type ResultStore struct {
-	mu                sync.Mutex
-	results           map[string]*QueryResult
-	maxCumulativeRows int
-	maxEntries        int
-	maxAge            time.Duration
+	mu              sync.Mutex
+	results         map[string]*QueryResult
+	maxResidentRows int
+	diskQuota       int64
+	maxEntries      int
+	maxAge          time.Duration
 }
 
-func NewResultStore(maxCumulativeRows int) *ResultStore {
+func NewResultStore(maxResidentRows int, diskQuota int64) *ResultStore {
 	return &ResultStore{
-		results:           make(map[string]*QueryResult),
-		maxCumulativeRows: maxCumulativeRows,
-		maxEntries:        maxResultEntries,
-		maxAge:            resultMaxAge,
+		results:         make(map[string]*QueryResult),
+		maxResidentRows: maxResidentRows,
+		diskQuota:       diskQuota,
+		maxEntries:      maxResultEntries,
+		maxAge:          resultMaxAge,
 	}
 }
 
@@ -34,6 +37,9 @@ func (s *ResultStore) Add(result *QueryResult) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if previous, ok := s.results[result.JobID]; ok && previous != result {
+		s.release(previous)
+	}
 	s.results[result.JobID] = result
 	s.cleanup(time.Now())
 
@@ -41,6 +47,7 @@ func (s *ResultStore) Add(result *QueryResult) {
 		slog.String("jobId", result.JobID),
 		slog.String("resource", result.ResourceName),
 		slog.Int("rowCount", result.RowCount),
+		slog.Int64("spilledBytes", result.spilledBytes()),
 		slog.Bool("truncated", result.Truncated))
 }
 
@@ -53,19 +60,35 @@ func (s *ResultStore) Get(jobID string) (*QueryResult, bool) {
 	return result, ok
 }
 
+// Close drops every stored result and removes their spill segments.
+func (s *ResultStore) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, result := range s.results {
+		s.release(result)
+	}
+}
+
 func (s *ResultStore) cleanup(now time.Time) {
-	totalRows := 0
+	residentRows := 0
+	diskBytes := int64(0)
 	sorted := make([]*QueryResult, 0, len(s.results))
 	for _, result := range s.results {
 		if now.Sub(result.FinishedAt) >= s.maxAge {
-			delete(s.results, result.JobID)
+			s.release(result)
 			continue
 		}
-		totalRows += result.RowCount
+		residentRows += result.residentRows()
+		diskBytes += result.spilledBytes()
 		sorted = append(sorted, result)
 	}
 
-	if totalRows <= s.maxCumulativeRows && len(sorted) <= s.maxEntries {
+	withinLimits := func() bool {
+		return residentRows <= s.maxResidentRows &&
+			(s.diskQuota <= 0 || diskBytes <= s.diskQuota) &&
+			len(s.results) <= s.maxEntries
+	}
+	if withinLimits() {
 		return
 	}
 
@@ -73,15 +96,153 @@ func (s *ResultStore) cleanup(now time.Time) {
 		return sorted[i].FinishedAt.Before(sorted[j].FinishedAt)
 	})
 	for _, result := range sorted {
-		if totalRows <= s.maxCumulativeRows && len(s.results) <= s.maxEntries {
+		if withinLimits() {
 			break
 		}
-		delete(s.results, result.JobID)
-		totalRows -= result.RowCount
+		s.release(result)
+		residentRows -= result.residentRows()
+		diskBytes -= result.spilledBytes()
 		slog.Info("Query result evicted from cache",
 			slog.String("jobId", result.JobID),
 			slog.String("resource", result.ResourceName),
 			slog.Int("rowCount", result.RowCount),
+			slog.Int64("spilledBytes", result.spilledBytes()),
 			slog.Duration("age", now.Sub(result.FinishedAt)))
 	}
 }
+
+func (s *ResultStore) release(result *QueryResult) {
+	delete(s.results, result.JobID)
+	if result.lazy {
+		// Closing waits for an in-flight fetch, so it must not hold up the store.
+		go func() {
+			result.closeCursor()
+			removeSegments(result)
+		}()
+		return
+	}
+	removeSegments(result)
+}
+
+func removeSegments(result *QueryResult) {
+	result.forEach(func(r *QueryResult) {
+		if err := r.Segment.Remove(); err != nil {
+			slog.Warn("failed to remove result segment",
+				slog.String("jobId", result.JobID),
+				slog.Any("err", err))
+		}
+	})
+}
+
+// closeIdleCursors closes the cursors of stored results that went unused for timeout.
+func (s *ResultStore) closeIdleCursors(now time.Time, timeout time.Duration) {
+	s.mu.Lock()
+	results := make([]*QueryResult, 0, len(s.results))
+	for _, result := range s.results {
+		results = append(results, result)
+	}
+	s.mu.Unlock()
+
+	for _, result := range results {
+		if result.lazy {
+			result.closeIdleCursor(now, timeout)
+		}
+	}
+}
+
+// closeExcessCursors keeps at most limit cursors of resourceName open, closing the
+// least recently used ones first.
+func (s *ResultStore) closeExcessCursors(resourceName string, limit int) {
+	s.mu.Lock()
+	results := make([]*QueryResult, 0, len(s.results))
+	for _, result := range s.results {
+		if result.lazy && result.ResourceName == resourceName {
+			results = append(results, result)
+		}
+	}
+	s.mu.Unlock()
+
+	type openCursor struct {
+		result   *QueryResult
+		lastUsed time.Time
+	}
+	var open []openCursor
+	for _, result := range results {
+		if lastUsed, ok := result.cursorLastUsed(); ok {
+			open = append(open, openCursor{result: result, lastUsed: lastUsed})
+		}
+	}
+	if len(open) <= limit {
+		return
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].lastUsed.Before(open[j].lastUsed)
+	})
+	for _, cursor := range open[:len(open)-limit] {
+		slog.Info("closing result cursor over the open cursor limit",
+			slog.String("jobId", cursor.result.JobID),
+			slog.String("resource", resourceName))
+		cursor.result.closeCursor()
+	}
+}
+
+// forEach calls fn for the result and every statement result it holds.
+func (r *QueryResult) forEach(fn func(*QueryResult)) {
+	fn(r)
+	for _, statement := range r.Statements {
+		if statement.Result != nil {
+			fn(statement.Result)
+		}
+	}
+}
+
+func (r *QueryResult) residentRows() int {
+	total := 0
+	r.forEach(func(result *QueryResult) {
+		result.rowsMu.RLock()
+		total += len(result.Rows)
+		result.rowsMu.RUnlock()
+	})
+	return total
+}
+
+func (r *QueryResult) spilledBytes() int64 {
+	var total int64
+	r.forEach(func(result *QueryResult) {
+		result.rowsMu.RLock()
+		total += result.Segment.Size()
+		result.rowsMu.RUnlock()
+	})
+	return total
+}
+
+func (r *QueryResult) hasStatementResults() bool {
+	for _, statement := range r.Statements {
+		if statement.Result != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadRows returns rows in the half-open range [start, end), reading past the
+// in-memory prefix from the spill segment when needed.
+func (r *QueryResult) ReadRows(start, end int) ([][]any, error) {
+	r.rowsMu.RLock()
+	defer r.rowsMu.RUnlock()
+	resident := len(r.Rows)
+	if end <= resident {
+		return r.Rows[start:end], nil
+	}
+
+	rows := make([][]any, 0, end-start)
+	if start < resident {
+		rows = append(rows, r.Rows[start:]...)
+		start = resident
+	}
+	spilled, err := r.Segment.ReadRows(start-resident, end-resident)
+	if err != nil {
+		return nil, err
+	}
+	return append(rows, spilled...), nil
+}
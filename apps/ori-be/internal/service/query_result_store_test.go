@@ -2,13 +2,14 @@ package service
 
 import (
 	"fmt"
+	"os"
 	"testing"
 	"time"
 )
 
 func TestResultStoreEvictsYoungEntriesWhenHardLimitsAreExceeded(t *testing.T) {
 	maxRows := 100000
-	store := NewResultStore(maxRows)
+	store := NewResultStore(maxRows, 0)
 	now := time.Now()
 	for i := 0; i <= maxResultEntries; i++ {
 		store.Add(&QueryResult{
@@ -21,9 +22,9 @@ func TestResultStoreEvictsYoungEntriesWhenHardLimitsAreExceeded(t *testing.T) {
 		t.Fatalf("stored entries = %d, want %d", len(store.results), maxResultEntries)
 	}
 
-	store = NewResultStore(maxRows)
-	store.Add(&QueryResult{JobID: "first", Status: JobStatusSuccess, RowCount: 60000, FinishedAt: now})
-	store.Add(&QueryResult{JobID: "second", Status: JobStatusSuccess, RowCount: 60000, FinishedAt: now.Add(time.Millisecond)})
+	store = NewResultStore(maxRows, 0)
+	store.Add(&QueryResult{JobID: "first", Status: JobStatusSuccess, Rows: make([][]any, 60000), RowCount: 60000, FinishedAt: now})
+	store.Add(&QueryResult{JobID: "second", Status: JobStatusSuccess, Rows: make([][]any, 60000), RowCount: 60000, FinishedAt: now.Add(time.Millisecond)})
 	if _, ok := store.Get("first"); ok {
 		t.Fatal("oldest result should be evicted even while it is young")
 	}
@@ -33,7 +34,7 @@ func TestResultStoreEvictsYoungEntriesWhenHardLimitsAreExceeded(t *testing.T) {
 }
 
 func TestResultStoreExpiresTerminalJobs(t *testing.T) {
-	store := NewResultStore(DefaultMaxMaterializedRows)
+	store := NewResultStore(DefaultMaxMaterializedRows, 0)
 	store.results["expired"] = &QueryResult{
 		JobID:      "expired",
 		Status:     JobStatusSuccess,
@@ -44,3 +45,38 @@ func TestResultStoreExpiresTerminalJobs(t *testing.T) {
 		t.Fatal("expired job should not remain in the store")
 	}
 }
+
+func TestResultStoreEvictsSpilledResultsOverDiskQuota(t *testing.T) {
+	spill := &ResultSpill{Dir: t.TempDir(), MemoryRows: 1, DiskQuota: 0}
+	store := NewResultStore(DefaultMaxMaterializedRows, 64)
+	now := time.Now()
+
+	spilled := func(jobID string, finishedAt time.Time) *QueryResult {
+		collector := NewRowCollector(&QueryExecOptions{Spill: spill})
+		for i := 0; i < 4; i++ {
+			if err := collector.Append([]any{"0123456789"}); err != nil {
+				t.Fatalf("Append: %v", err)
+			}
+		}
+		result := &QueryResult{JobID: jobID, Status: JobStatusSuccess, FinishedAt: finishedAt}
+		if err := collector.Finish(result); err != nil {
+			t.Fatalf("Finish: %v", err)
+		}
+		return result
+	}
+
+	first := spilled("first", now)
+	store.Add(first)
+	store.Add(spilled("second", now.Add(time.Millisecond)))
+
+	if _, ok := store.Get("first"); ok {
+		t.Fatal("oldest spilled result should be evicted once the disk quota is exceeded")
+	}
+	if _, err := os.Stat(first.Segment.path); !os.IsNotExist(err) {
+		t.Fatalf("evicted segment should be removed, stat err = %v", err)
+	}
+	if _, ok := store.Get("second"); !ok {
+		t.Fatal("newest result should remain available")
+	}
+	store.Close()
+}
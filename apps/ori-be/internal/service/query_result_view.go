@@ -0,0 +1,379 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+var ErrInvalidViewQuery = errors.New("invalid result view query")
+
+const (
+	// maxCachedViewIndexes bounds the sorted/filtered row indexes kept per result.
+	maxCachedViewIndexes = 4
+	// viewScanChunkRows is how many rows are read at a time while building an index.
+	viewScanChunkRows = segmentCheckpointInterval
+)
+
+// ResultFilterOp names a filter predicate.
+type ResultFilterOp string
+
+const (
+	FilterEquals         ResultFilterOp = "eq"
+	FilterContains       ResultFilterOp = "contains"
+	FilterIsNull         ResultFilterOp = "isnull"
+	FilterNotNull        ResultFilterOp = "notnull"
+	FilterGreater        ResultFilterOp = "gt"
+	FilterGreaterOrEqual ResultFilterOp = "gte"
+	FilterLess           ResultFilterOp = "lt"
+	FilterLessOrEqual    ResultFilterOp = "lte"
+)
+
+// ResultSort orders a result view by a column.
+type ResultSort struct {
+	Column     string
+	Descending bool
+}
+
+// ResultFilter keeps the rows whose column satisfies the predicate. Value is
+// ignored by the null checks and must be numeric for the range operators.
+type ResultFilter struct {
+	Column string
+	Op     ResultFilterOp
+	Value  string
+}
+
+// ResultViewQuery sorts and filters a stored result before it is paged.
+// Filters are combined with AND; sorts apply in order.
+type ResultViewQuery struct {
+	Sort    []ResultSort
+	Filters []ResultFilter
+}
+
+func (q *ResultViewQuery) empty() bool {
+	return q == nil || (len(q.Sort) == 0 && len(q.Filters) == 0)
+}
+
+// key identifies the row index a query produces.
+func (q *ResultViewQuery) key() string {
+	var builder strings.Builder
+	for _, s := range q.Sort {
+		fmt.Fprintf(&builder, "s%q:%t;", s.Column, s.Descending)
+	}
+	for _, f := range q.Filters {
+		fmt.Fprintf(&builder, "f%q:%s:%q;", f.Column, f.Op, f.Value)
+	}
+	return builder.String()
+}
+
+// ParseResultSort parses a "column[:asc|desc]" sort expression.
+func ParseResultSort(expr string) (ResultSort, error) {
+	column, direction := expr, "asc"
+	if i := strings.LastIndex(expr, ":"); i >= 0 {
+		column, direction = expr[:i], strings.ToLower(expr[i+1:])
+	}
+	if column == "" {
+		return ResultSort{}, fmt.Errorf("%w: sort %q has no column", ErrInvalidViewQuery, expr)
+	}
+	switch direction {
+	case "asc":
+		return ResultSort{Column: column}, nil
+	case "desc":
+		return ResultSort{Column: column, Descending: true}, nil
+	}
+	return ResultSort{}, fmt.Errorf("%w: unknown sort direction %q", ErrInvalidViewQuery, direction)
+}
+
+// ParseResultFilter parses a "column:op[:value]" filter expression.
+func ParseResultFilter(expr string) (ResultFilter, error) {
+	parts := strings.SplitN(expr, ":", 3)
+	if len(parts) < 2 || parts[0] == "" {
+		return ResultFilter{}, fmt.Errorf("%w: filter %q must be column:op[:value]", ErrInvalidViewQuery, expr)
+	}
+	filter := ResultFilter{Column: parts[0], Op: ResultFilterOp(strings.ToLower(parts[1]))}
+	if len(parts) == 3 {
+		filter.Value = parts[2]
+	}
+
+	switch filter.Op {
+	case FilterIsNull, FilterNotNull:
+	case FilterEquals, FilterContains:
+		if len(parts) < 3 {
+			return ResultFilter{}, fmt.Errorf("%w: filter %q needs a value", ErrInvalidViewQuery, expr)
+		}
+	case FilterGreater, FilterGreaterOrEqual, FilterLess, FilterLessOrEqual:
+		if _, err := strconv.ParseFloat(filter.Value, 64); err != nil {
+			return ResultFilter{}, fmt.Errorf("%w: filter %q needs a numeric value", ErrInvalidViewQuery, expr)
+		}
+	default:
+		return ResultFilter{}, fmt.Errorf("%w: unknown filter operator %q", ErrInvalidViewQuery, filter.Op)
+	}
+	return filter, nil
+}
+
+// viewIndex returns the positions of the rows matching query, in view order.
+// Indexes are computed over the whole result once and cached on it.
+func (r *QueryResult) viewIndex(query *ResultViewQuery) ([]int, error) {
+	key := query.key()
+	r.viewMu.Lock()
+	defer r.viewMu.Unlock()
+	for _, cached := range r.viewIndexes {
+		if cached.key == key {
+			return cached.rows, nil
+		}
+	}
+
+	sorts := make([]int, len(query.Sort))
+	for i, s := range query.Sort {
+		column, err := r.columnIndex(s.Column)
+		if err != nil {
+			return nil, err
+		}
+		sorts[i] = column
+	}
+	filters := make([]int, len(query.Filters))
+	for i, f := range query.Filters {
+		column, err := r.columnIndex(f.Column)
+		if err != nil {
+			return nil, err
+		}
+		filters[i] = column
+	}
+
+	// Scan the result in chunks so spilled rows are never all resident at once.
+	// The sort cells of matching rows are decoded once so sorting does not re-parse them.
+	type entry struct {
+		position int
+		keys     []cellKey
+	}
+	entries := make([]entry, 0)
+	for start := 0; start < r.RowCount; start += viewScanChunkRows {
+		rows, err := r.ReadRows(start, min(start+viewScanChunkRows, r.RowCount))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrResultUnavailable, err)
+		}
+		for offset, row := range rows {
+			if !matchFilters(row, query.Filters, filters) {
+				continue
+			}
+			keys := make([]cellKey, len(sorts))
+			for j, column := range sorts {
+				keys[j] = newCellKey(row[column])
+			}
+			entries = append(entries, entry{position: start + offset, keys: keys})
+		}
+	}
+
+	if len(sorts) > 0 {
+		sort.SliceStable(entries, func(a, b int) bool {
+			for j, s := range query.Sort {
+				c := compareCellKeys(entries[a].keys[j], entries[b].keys[j])
+				if s.Descending {
+					c = -c
+				}
+				if c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+	index := make([]int, len(entries))
+	for i, e := range entries {
+		index[i] = e.position
+	}
+
+	if len(r.viewIndexes) >= maxCachedViewIndexes {
+		r.viewIndexes = r.viewIndexes[1:]
+	}
+	r.viewIndexes = append(r.viewIndexes, cachedViewIndex{key: key, rows: index})
+	return index, nil
+}
+
+// readRowsAt returns the rows at the given positions. Positions are visited in
+// order and read as one range per segment checkpoint, then scattered back into place.
+func (r *QueryResult) readRowsAt(positions []int) ([][]any, error) {
+	order := make([]int, len(positions))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		return positions[order[a]] < positions[order[b]]
+	})
+
+	rows := make([][]any, len(positions))
+	for start := 0; start < len(order); {
+		first := positions[order[start]]
+		end := start + 1
+		for end < len(order) && positions[order[end]]/segmentCheckpointInterval == first/segmentCheckpointInterval {
+			end++
+		}
+		block, err := r.ReadRows(first, positions[order[end-1]]+1)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range order[start:end] {
+			rows[i] = block[positions[i]-first]
+		}
+		start = end
+	}
+	return rows, nil
+}
+
+func (r *QueryResult) columnIndex(name string) (int, error) {
+	for i, column := range r.Columns {
+		if column.Name == name {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown column %q", ErrInvalidViewQuery, name)
+}
+
+type cachedViewIndex struct {
+	key  string
+	rows []int
+}
+
+type cellKind int
+
+const (
+	cellKindNumber cellKind = iota
+	cellKindText
+	cellKindNull
+)
+
+// cellKey is the comparable form of a cell. Numbers sort before text and nulls
+// sort last, as in an ascending ORDER BY on Postgres.
+type cellKey struct {
+	kind   cellKind
+	number *big.Float
+	text   string
+}
+
+func newCellKey(value any) cellKey {
+	if value == nil {
+		return cellKey{kind: cellKindNull}
+	}
+	if number, ok := cellNumber(value); ok {
+		return cellKey{kind: cellKindNumber, number: number}
+	}
+	if b, ok := value.(bool); ok {
+		return cellKey{kind: cellKindText, text: strconv.FormatBool(b)}
+	}
+	return cellKey{kind: cellKindText, text: cellText(value)}
+}
+
+// cellNumber converts native and decimal cells to a number.
+func cellNumber(value any) (*big.Float, bool) {
+	switch v := value.(type) {
+	case int64:
+		return new(big.Float).SetInt64(v), true
+	case float64:
+		return big.NewFloat(v), true
+	case json.Number:
+		return parseNumber(v.String())
+	}
+	if tag, tagged, ok := taggedCell(value); ok && tag == querycell.TagDecimal {
+		text, _ := tagged.(string)
+		return parseNumber(text)
+	}
+	return nil, false
+}
+
+// parseNumber parses decimal text with enough precision to compare wide numerics exactly.
+func parseNumber(text string) (*big.Float, bool) {
+	return new(big.Float).SetPrec(256).SetString(text)
+}
+
+// cellText renders a cell, or the value of a tagged cell, for text comparison.
+func cellText(value any) string {
+	if _, tagged, ok := taggedCell(value); ok {
+		value = tagged
+	}
+	if text, ok := value.(string); ok {
+		return text
+	}
+	if payload, err := json.Marshal(value); err == nil {
+		return string(payload)
+	}
+	return fmt.Sprint(value)
+}
+
+func compareCellKeys(a, b cellKey) int {
+	if a.kind != b.kind {
+		return int(a.kind) - int(b.kind)
+	}
+	switch a.kind {
+	case cellKindNumber:
+		return a.number.Cmp(b.number)
+	case cellKindText:
+		return strings.Compare(a.text, b.text)
+	}
+	return 0
+}
+
+func matchFilters(row []any, filters []ResultFilter, columns []int) bool {
+	for i, filter := range filters {
+		if !matchFilter(row[columns[i]], filter) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchFilter(value any, filter ResultFilter) bool {
+	switch filter.Op {
+	case FilterIsNull:
+		return value == nil
+	case FilterNotNull:
+		return value != nil
+	}
+	if value == nil {
+		return false
+	}
+
+	switch filter.Op {
+	case FilterEquals:
+		if number, ok := cellNumber(value); ok {
+			if target, ok := parseNumber(filter.Value); ok {
+				return number.Cmp(target) == 0
+			}
+		}
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b) == strings.ToLower(filter.Value)
+		}
+		return cellText(value) == filter.Value
+	case FilterContains:
+		if _, ok := cellNumber(value); ok {
+			return strings.Contains(cellText(value), filter.Value)
+		}
+		return strings.Contains(strings.ToLower(cellText(value)), strings.ToLower(filter.Value))
+	}
+
+	number, ok := cellNumber(value)
+	if !ok {
+		return false
+	}
+	target, ok := parseNumber(filter.Value)
+	if !ok {
+		return false
+	}
+	c := number.Cmp(target)
+	switch filter.Op {
+	case FilterGreater:
+		return c > 0
+	case FilterGreaterOrEqual:
+		return c >= 0
+	case FilterLess:
+		return c < 0
+	case FilterLessOrEqual:
+		return c <= 0
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/querycell"
+)
+
+func TestBuildResultViewSortsAndFiltersSpilledResult(t *testing.T) {
+	collector := NewRowCollector(&QueryExecOptions{Spill: &ResultSpill{Dir: t.TempDir(), MemoryRows: 2}})
+	rows := [][]any{
+		{int64(1), "Ada", querycell.Decimal("10.5")},
+		{int64(2), "Grace", nil},
+		{int64(3), "Barbara", querycell.Decimal("9.75")},
+		{int64(4), "Alan", querycell.Decimal("100")},
+		{int64(5), "Edsger", querycell.Decimal("9.75")},
+	}
+	for _, row := range rows {
+		if err := collector.Append(row); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	result := &QueryResult{
+		JobID:      "job",
+		Status:     JobStatusSuccess,
+		Columns:    []QueryColumn{{Name: "id"}, {Name: "name"}, {Name: "score"}},
+		FinishedAt: time.Now(),
+	}
+	if err := collector.Finish(result); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	service := &QueryService{activeJobs: map[string]*QueryJob{}, resultStore: NewResultStore(DefaultMaxMaterializedRows, 0)}
+	service.resultStore.Add(result)
+	defer service.resultStore.Close()
+
+	ids := func(view *QueryResultView) string {
+		out := make([]string, len(view.Rows))
+		for i, row := range view.Rows {
+			out[i] = fmt.Sprint(row[0])
+		}
+		return fmt.Sprint(out)
+	}
+
+	tests := []struct {
+		name  string
+		query *ResultViewQuery
+		limit int
+		want  string
+		count int
+	}{
+		{
+			name:  "sort descending puts nulls first",
+			query: &ResultViewQuery{Sort: []ResultSort{{Column: "score", Descending: true}}},
+			limit: 3,
+			want:  "[2 4 1]",
+			count: 5,
+		},
+		{
+			name:  "ties keep the next sort key",
+			query: &ResultViewQuery{Sort: []ResultSort{{Column: "score"}, {Column: "name", Descending: true}}},
+			limit: 5,
+			want:  "[5 3 1 4 2]",
+			count: 5,
+		},
+		{
+			name:  "contains ignores case",
+			query: &ResultViewQuery{Filters: []ResultFilter{{Column: "name", Op: FilterContains, Value: "A"}}},
+			limit: 5,
+			want:  "[1 2 3 4]",
+			count: 4,
+		},
+		{
+			name: "numeric range over decimals",
+			query: &ResultViewQuery{
+				Filters: []ResultFilter{{Column: "score", Op: FilterGreaterOrEqual, Value: "9.75"}, {Column: "score", Op: FilterLess, Value: "100"}},
+				Sort:    []ResultSort{{Column: "id", Descending: true}},
+			},
+			limit: 5,
+			want:  "[5 3 1]",
+			count: 3,
+		},
+		{
+			name:  "is null",
+			query: &ResultViewQuery{Filters: []ResultFilter{{Column: "score", Op: FilterIsNull}}},
+			limit: 5,
+			want:  "[2]",
+			count: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := service.BuildResultView(context.Background(), "job", nil, &tt.limit, nil, tt.query)
+			if err != nil {
+				t.Fatalf("BuildResultView: %v", err)
+			}
+			if got := ids(view); got != tt.want {
+				t.Fatalf("ids = %s, want %s", got, tt.want)
+			}
+			if view.RowCount != tt.count {
+				t.Fatalf("RowCount = %d, want %d", view.RowCount, tt.count)
+			}
+		})
+	}
+
+	limit, offset := 2, 2
+	page, err := service.BuildResultView(context.Background(), "job", nil, &limit, &offset, tests[1].query)
+	if err != nil {
+		t.Fatalf("BuildResultView (second page): %v", err)
+	}
+	if got := ids(page); got != "[1 4]" {
+		t.Fatalf("second page ids = %s, want [1 4]", got)
+	}
+	if len(result.viewIndexes) != maxCachedViewIndexes {
+		t.Fatalf("cached indexes = %d, want %d", len(result.viewIndexes), maxCachedViewIndexes)
+	}
+
+	_, err = service.BuildResultView(context.Background(), "job", nil, nil, nil, &ResultViewQuery{Sort: []ResultSort{{Column: "missing"}}})
+	if !errors.Is(err, ErrInvalidViewQuery) {
+		t.Fatalf("unknown column error = %v, want ErrInvalidViewQuery", err)
+	}
+}
+
+func TestReadRowsAtScattersSpilledRows(t *testing.T) {
+	collector := NewRowCollector(&QueryExecOptions{Spill: &ResultSpill{Dir: t.TempDir(), MemoryRows: 3}})
+	total := 2*segmentCheckpointInterval + 10
+	for i := range total {
+		if err := collector.Append([]any{int64(i)}); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	result := &QueryResult{Columns: []QueryColumn{{Name: "n"}}}
+	if err := collector.Finish(result); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+	defer result.Segment.Remove()
+
+	positions := []int{total - 1, 1, segmentCheckpointInterval + 7, 0, 5, total - 1, segmentCheckpointInterval}
+	rows, err := result.readRowsAt(positions)
+	if err != nil {
+		t.Fatalf("readRowsAt: %v", err)
+	}
+	for i, position := range positions {
+		if fmt.Sprint(rows[i][0]) != fmt.Sprint(position) {
+			t.Fatalf("row %d = %v, want %d", i, rows[i][0], position)
+		}
+	}
+}
+
+func TestParseResultFilter(t *testing.T) {
+	filter, err := ParseResultFilter("note:contains:a:b")
+	if err != nil || filter != (ResultFilter{Column: "note", Op: FilterContains, Value: "a:b"}) {
+		t.Fatalf("ParseResultFilter = %+v, %v", filter, err)
+	}
+	if _, err := ParseResultFilter("id:gt:ten"); !errors.Is(err, ErrInvalidViewQuery) {
+		t.Fatalf("non-numeric range error = %v", err)
+	}
+	if _, err := ParseResultFilter("id:like:x"); !errors.Is(err, ErrInvalidViewQuery) {
+		t.Fatalf("unknown operator error = %v", err)
+	}
+	sort, err := ParseResultSort("created:at:DESC")
+	if err != nil || sort != (ResultSort{Column: "created:at", Descending: true}) {
+		t.Fatalf("ParseResultSort = %+v, %v", sort, err)
+	}
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/model"
+)
+
+// DefaultMaxConcurrentQueries is how many jobs run at once on a resource that does not set maxConcurrentQueries.
+const DefaultMaxConcurrentQueries = 4
+
+// resourceQueue tracks the running jobs of a resource and the jobs waiting for a slot,
+// in arrival order. It is guarded by QueryService.mu.
+type resourceQueue struct {
+	limit   int
+	running int
+	waiting []*queuedJob
+}
+
+type queuedJob struct {
+	ctx    context.Context
+	job    *QueryJob
+	handle *ResourceHandle
+}
+
+// maxConcurrentQueries returns the concurrency limit of resource. DuckDB defaults to
+// one job because its adapter holds a single connection.
+func maxConcurrentQueries(resource *model.Resource) int {
+	switch {
+	case resource == nil:
+		return DefaultMaxConcurrentQueries
+	case resource.MaxConcurrentQueries != nil:
+		return *resource.MaxConcurrentQueries
+	case resource.Type == "duckdb":
+		return 1
+	default:
+		return DefaultMaxConcurrentQueries
+	}
+}
+
+// scheduleLocked starts job when its resource has a free slot and queues it otherwise.
+// Session jobs run on their own pinned connection and start right away. Must be called
+// with qs.mu held.
+func (qs *QueryService) scheduleLocked(ctx context.Context, job *QueryJob, handle *ResourceHandle) {
+	if job.session != nil {
+		go qs.runJob(ctx, job, handle)
+		return
+	}
+
+	queue, ok := qs.queues[job.ResourceName]
+	if !ok {
+		queue = &resourceQueue{}
+		qs.queues[job.ResourceName] = queue
+	}
+	queue.limit = maxConcurrentQueries(handle.Resource)
+	if queue.running < queue.limit {
+		queue.running++
+		go qs.runScheduledJob(ctx, job, handle)
+		return
+	}
+	job.Status = JobStatusQueued
+	queue.waiting = append(queue.waiting, &queuedJob{ctx: ctx, job: job, handle: handle})
+}
+
+// runScheduledJob runs a job holding a resource slot and hands the slot to the next queued job.
+// A lazy result gives up its slot once the first page is delivered; the connection its
+// cursor keeps open counts against the resource's open cursor limit instead.
+func (qs *QueryService) runScheduledJob(ctx context.Context, job *QueryJob, handle *ResourceHandle) {
+	result := qs.runJob(ctx, job, handle)
+	qs.releaseSlot(job.ResourceName)
+	if result.lazy {
+		qs.resultStore.closeExcessCursors(job.ResourceName, qs.maxOpenCursors)
+	}
+}
+
+func (qs *QueryService) releaseSlot(resourceName string) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	queue, ok := qs.queues[resourceName]
+	if !ok {
+		return
+	}
+	queue.running--
+	for queue.running < queue.limit && len(queue.waiting) > 0 {
+		next := queue.waiting[0]
+		queue.waiting = queue.waiting[1:]
+		queue.running++
+		next.job.Status = JobStatusRunning
+		go qs.runScheduledJob(next.ctx, next.job, next.handle)
+	}
+	if queue.running == 0 && len(queue.waiting) == 0 {
+		delete(qs.queues, resourceName)
+	}
+}
+
+// queuePositionLocked returns the 1-based position of a queued job, or 0 when it is not queued.
+func (qs *QueryService) queuePositionLocked(job *QueryJob) int {
+	queue, ok := qs.queues[job.ResourceName]
+	if !ok {
+		return 0
+	}
+	index := slices.IndexFunc(queue.waiting, func(queued *queuedJob) bool { return queued.job == job })
+	return index + 1
+}
+
+// dequeueLocked removes a queued job so it never starts. Must be called with qs.mu held.
+func (qs *QueryService) dequeueLocked(job *QueryJob) {
+	queue, ok := qs.queues[job.ResourceName]
+	if !ok {
+		return
+	}
+	queue.waiting = slices.DeleteFunc(queue.waiting, func(queued *queuedJob) bool { return queued.job == job })
+	if queue.running == 0 && len(queue.waiting) == 0 {
+		delete(qs.queues, job.ResourceName)
+	}
+}
+
+// finishQueuedJob completes a job that was canceled before it started.
+func (qs *QueryService) finishQueuedJob(job *QueryJob) {
+	qs.finishJob(context.Background(), job, &QueryResult{
+		JobID:        job.ID,
+		ResourceName: job.ResourceName,
+		Status:       JobStatusCanceled,
+		Error:        "query canceled before it started",
+		FinishedAt:   time.Now(),
+	})
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+)
+
+const scriptRollbackTimeout = 5 * time.Second
+
+// StatementStatus represents the outcome of a single statement within a script job
+type StatementStatus string
+
+const (
+	StatementStatusPending  StatementStatus = "pending"
+	StatementStatusRunning  StatementStatus = "running"
+	StatementStatusSuccess  StatementStatus = "success"
+	StatementStatusFailed   StatementStatus = "failed"
+	StatementStatusCanceled StatementStatus = "canceled"
+	StatementStatusSkipped  StatementStatus = "skipped"
+)
+
+// StatementResult holds the outcome of one statement of a script job.
+// Result is set only when the statement succeeded.
+type StatementResult struct {
+	Index      int
+	Line       int
+	Status     StatementStatus
+	Error      string
+	DurationMs int64
+	Result     *QueryResult
+	// DatabaseError is set when the database rejected the statement.
+	DatabaseError *DatabaseError
+}
+
+// StatementSummary describes a statement of a script job without its rows
+type StatementSummary struct {
+	Index        int
+	Line         int
+	Status       StatementStatus
+	DurationMs   int64
+	RowCount     *int
+	RowsAffected *int64
+	CommandTag   string
+	Error        string
+	// DatabaseError is set when the database rejected the statement.
+	DatabaseError *DatabaseError
+}
+
+func (s *StatementResult) summary() StatementSummary {
+	summary := StatementSummary{
+		Index:         s.Index,
+		Line:          s.Line,
+		Status:        s.Status,
+		DurationMs:    s.DurationMs,
+		Error:         s.Error,
+		DatabaseError: s.DatabaseError,
+	}
+	if s.Result != nil {
+		summary.RowsAffected = s.Result.RowsAffected
+		summary.CommandTag = s.Result.CommandTag
+		// Statements with RETURNING report the rows they returned as well as those they affected.
+		if s.Result.RowsAffected == nil || s.Result.Columns != nil {
+			rowCount := s.Result.RowCount
+			summary.RowCount = &rowCount
+		}
+	}
+	return summary
+}
+
+func summarizeStatements(statements []*StatementResult) []StatementSummary {
+	if len(statements) == 0 {
+		return nil
+	}
+	summaries := make([]StatementSummary, len(statements))
+	for i, statement := range statements {
+		summaries[i] = statement.summary()
+	}
+	return summaries
+}
+
+// runScript splits the job query into statements and runs them in order on a pinned connection.
+// Jobs bound to a session use the session's connection and leave its transaction open;
+// otherwise a transaction the script leaves open is rolled back.
+// The returned result carries one StatementResult per statement even when an error is returned.
+func (qs *QueryService) runScript(ctx context.Context, job *QueryJob, handle *ResourceHandle) (*QueryResult, error) {
+	dialect := resourceDialect(handle)
+	statements := sqlutil.SplitStatements(job.Query, dialect)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("script contains no statements")
+	}
+
+	results := make([]*StatementResult, len(statements))
+	for i, statement := range statements {
+		results[i] = &StatementResult{Index: i, Line: statement.Line, Status: StatementStatusPending}
+	}
+	qs.mu.Lock()
+	job.Statements = results
+	qs.mu.Unlock()
+	result := &QueryResult{Statements: results}
+
+	txState := TxStateIdle
+	var conn PinnedConnection
+	if job.session != nil {
+		conn = job.session.conn
+	} else {
+		pinned, err := handle.Adapter.PinConnection(ctx)
+		if err != nil {
+			qs.finishPendingStatements(results, StatementStatusSkipped)
+			return result, err
+		}
+		conn = pinned
+		defer func() {
+			if txState != TxStateIdle {
+				qs.rollbackScript(ctx, conn, job)
+			}
+			_ = conn.Close()
+		}()
+	}
+
+	var scriptErr error
+	for i, statement := range statements {
+		if ctx.Err() != nil {
+			break
+		}
+		qs.mu.Lock()
+		results[i].Status = StatementStatusRunning
+		qs.mu.Unlock()
+		job.progress.beginStatement(i)
+
+		startTime := time.Now()
+		statementResult, err := conn.ExecuteQuery(ctx, statement.Text, nil, job.Options)
+		duration := time.Since(startTime).Milliseconds()
+
+		qs.mu.Lock()
+		results[i].DurationMs = duration
+		switch {
+		case err != nil && ctx.Err() != nil:
+			results[i].Status = StatementStatusCanceled
+			results[i].Error = ctx.Err().Error()
+		case err != nil:
+			results[i].Status = StatementStatusFailed
+			results[i].Error = err.Error()
+			results[i].DatabaseError = locateDatabaseError(err, job.Query, statement.Offset, job.paramShifts, job.sourceQuery, 0)
+		default:
+			results[i].Status = StatementStatusSuccess
+			results[i].Result = statementResult
+		}
+		qs.mu.Unlock()
+
+		if job.session != nil {
+			qs.observeSessionStatement(job.session, statement.Text, err)
+		} else {
+			txState = nextTxState(txState, statement.Text, err, dialect, conn)
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				scriptErr = err
+				break
+			}
+			if scriptErr == nil {
+				scriptErr = fmt.Errorf("statement %d (line %d): %w", i+1, statement.Line, err)
+			}
+			if job.Options.OnError != ScriptContinueOnError {
+				break
+			}
+			continue
+		}
+	}
+
+	if ctx.Err() != nil {
+		qs.finishPendingStatements(results, StatementStatusCanceled)
+		if scriptErr == nil {
+			scriptErr = ctx.Err()
+		}
+	} else {
+		qs.finishPendingStatements(results, StatementStatusSkipped)
+	}
+	return result, scriptErr
+}
+
+func (qs *QueryService) finishPendingStatements(results []*StatementResult, status StatementStatus) {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	for _, result := range results {
+		if result.Status == StatementStatusPending {
+			result.Status = status
+		}
+	}
+}
+
+// rollbackScript discards a transaction the script left open so the pooled connection is clean.
+func (qs *QueryService) rollbackScript(ctx context.Context, conn PinnedConnection, job *QueryJob) {
+	rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), scriptRollbackTimeout)
+	defer cancel()
+	if _, err := conn.ExecuteQuery(rollbackCtx, "ROLLBACK", nil, nil); err != nil {
+		slog.WarnContext(ctx, "failed to roll back transaction left open by script",
+			slog.String("jobId", job.ID),
+			slog.Any("err", err))
+		return
+	}
+	slog.InfoContext(ctx, "rolled back transaction left open by script", slog.String("jobId", job.ID))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/crueladdict/ori/apps/ori-server/internal/events"
 	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/logctx"
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
 )
 
 var (
@@ -20,6 +22,14 @@ var (
 	ErrJobAlreadyExists  = errors.New("query job already exists")
 	ErrJobNotFound       = errors.New("query job not found")
 	ErrMaxRowsExceeded   = errors.New("query max rows exceeds the current materialization limit")
+	ErrInvalidOptions    = errors.New("invalid query options")
+	ErrStatementNotFound = errors.New("statement not found in query result")
+	ErrInvalidParams     = errors.New("invalid query parameters")
+	// ErrQueryTimeout marks a job that ran past its timeout. Adapters wrap it around
+	// errors the database raises for its own statement timeout.
+	ErrQueryTimeout = errors.New("query timed out")
+	// ErrReadOnlyResource rejects a write to a resource configured as read-only.
+	ErrReadOnlyResource = errors.New("resource is read-only")
 )
 
 const DefaultMaxMaterializedRows = 100000
@@ -31,6 +41,10 @@ type QueryResultView struct {
 	RowCount     int           `json:"rowCount"`
 	Truncated    bool          `json:"truncated"`
 	RowsAffected *int64        `json:"rowsAffected,omitempty"`
+	CommandTag   string        `json:"commandTag,omitempty"`
+	Partial      bool          `json:"partial,omitempty"`
+	HasMore      bool          `json:"hasMore,omitempty"`
+	Notices      []QueryNotice `json:"notices,omitempty"`
 }
 
 type QueryJobStatus struct {
@@ -41,6 +55,16 @@ type QueryJobStatus struct {
 	DurationMs   *int64
 	Error        string
 	Stored       bool
+	Statements   []StatementSummary
+	SessionID    string
+	TxState      TxState
+	Notices      []QueryNotice
+	// DroppedNotices counts the notices received after the first maxJobNotices.
+	DroppedNotices int
+	// DatabaseError holds the structured fields of Error when the database reported it.
+	DatabaseError *DatabaseError
+	// QueuePosition is the 1-based position of a queued job in its resource's queue.
+	QueuePosition int
 }
 
 // QueryService manages query job execution
@@ -50,20 +74,52 @@ type QueryService struct {
 	resultStore         *ResultStore
 	mu                  sync.RWMutex
 	activeJobs          map[string]*QueryJob
+	queues              map[string]*resourceQueue
 	rootCtx             context.Context
 	maxMaterializedRows int
+	spill               *ResultSpill
+
+	sessions             map[string]*QuerySession
+	sessionIdleTxTimeout time.Duration
+	sessionIdleTimeout   time.Duration
+	sessionsDone         chan struct{}
+	stopOnce             sync.Once
+	cursorIdleTimeout    time.Duration
+	maxOpenCursors       int
+
+	exportersMu sync.RWMutex
+	exporters   map[ExportFormat]ResultExporter
+
+	historyMu sync.RWMutex
+	history   QueryHistoryStore
 }
 
-// NewQueryService creates a new query service
-func NewQueryService(connectionService *ResourceSessionService, eventHub *events.Hub, rootCtx context.Context, maxMaterializedRows int) *QueryService {
-	return &QueryService{
-		connectionService:   connectionService,
-		eventHub:            eventHub,
-		resultStore:         NewResultStore(maxMaterializedRows),
-		activeJobs:          make(map[string]*QueryJob),
-		rootCtx:             rootCtx,
-		maxMaterializedRows: maxMaterializedRows,
-	}
+// NewQueryService creates a new query service. When spill is nil, results are kept entirely in memory.
+func NewQueryService(connectionService *ResourceSessionService, eventHub *events.Hub, rootCtx context.Context, maxMaterializedRows int, spill *ResultSpill) *QueryService {
+	diskQuota := int64(0)
+	if spill != nil {
+		diskQuota = spill.DiskQuota
+	}
+	qs := &QueryService{
+		connectionService:    connectionService,
+		eventHub:             eventHub,
+		resultStore:          NewResultStore(maxMaterializedRows, diskQuota),
+		activeJobs:           make(map[string]*QueryJob),
+		queues:               make(map[string]*resourceQueue),
+		rootCtx:              rootCtx,
+		maxMaterializedRows:  maxMaterializedRows,
+		spill:                spill,
+		sessions:             make(map[string]*QuerySession),
+		sessionIdleTxTimeout: DefaultSessionIdleTxTimeout,
+		sessionIdleTimeout:   DefaultSessionIdleTimeout,
+		sessionsDone:         make(chan struct{}),
+		cursorIdleTimeout:    DefaultCursorIdleTimeout,
+		maxOpenCursors:       DefaultMaxOpenCursors,
+		exporters:            defaultExporters(),
+	}
+	go qs.watchSessions(qs.sessionsDone)
+	go qs.watchCursors(qs.sessionsDone)
+	return qs
 }
 
 func (qs *QueryService) newJobContext(ctx context.Context) context.Context {
@@ -91,12 +147,36 @@ func (qs *QueryService) Exec(ctx context.Context, resourceName, jobID, query str
 	if options == nil {
 		options = &QueryExecOptions{}
 	}
-	if options.MaxRows <= 0 {
-		options.MaxRows = qs.maxMaterializedRows
+	switch options.OnError {
+	case "":
+		options.OnError = ScriptStopOnError
+	case ScriptStopOnError, ScriptContinueOnError:
+	default:
+		return nil, fmt.Errorf("%w: unknown onError mode %q", ErrInvalidOptions, options.OnError)
 	}
-
-	if options.MaxRows > qs.maxMaterializedRows {
-		return nil, fmt.Errorf("%w: requested %d, maximum %d", ErrMaxRowsExceeded, options.MaxRows, qs.maxMaterializedRows)
+	if options.Script && params != nil {
+		return nil, fmt.Errorf("%w: params are not supported in script mode", ErrInvalidOptions)
+	}
+	// A cursor holds its connection open, which scripts and sessions need for the next statement.
+	if options.Lazy && (options.Script || options.SessionID != "") {
+		return nil, fmt.Errorf("%w: lazy fetching is not supported for scripts or sessions", ErrInvalidOptions)
+	}
+	if options.FetchRows < 0 {
+		return nil, fmt.Errorf("%w: fetchRows must be positive", ErrInvalidOptions)
+	}
+	if options.TimeoutMs < 0 {
+		return nil, fmt.Errorf("%w: timeoutMs must be positive", ErrInvalidOptions)
+	}
+	// With a spill directory the row cap only bounds memory; the disk quota bounds the rest.
+	if qs.spill != nil {
+		options.Spill = qs.spill
+	} else {
+		if options.MaxRows <= 0 {
+			options.MaxRows = qs.maxMaterializedRows
+		}
+		if options.MaxRows > qs.maxMaterializedRows {
+			return nil, fmt.Errorf("%w: requested %d, maximum %d", ErrMaxRowsExceeded, options.MaxRows, qs.maxMaterializedRows)
+		}
 	}
 
 	// Check if connection is available
@@ -105,15 +185,40 @@ func (qs *QueryService) Exec(ctx context.Context, resourceName, jobID, query str
 		return nil, fmt.Errorf("%w: %s", ErrConnectionUnavailable, resourceName)
 	}
 
+	if options.Lazy && options.FetchRows == 0 && handle.Resource != nil && handle.Resource.AutoLimitRows != nil {
+		options.FetchRows = *handle.Resource.AutoLimitRows
+	}
+	if options.TimeoutMs == 0 && handle.Resource != nil && handle.Resource.QueryTimeout != nil {
+		options.TimeoutMs = *handle.Resource.QueryTimeout
+	}
+
+	if err := checkReadOnly(handle, query); err != nil {
+		return nil, err
+	}
+	warnings, err := checkDestructive(handle, query, options.ConfirmToken)
+	if err != nil {
+		return nil, err
+	}
+
+	boundQuery, boundParams, shifts, err := bindNamedParams(handle, query, params)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create job
 	job := &QueryJob{
 		ID:           jobID,
 		ResourceName: resourceName,
-		Query:        query,
-		Params:       params,
+		Query:        boundQuery,
+		Params:       boundParams,
 		Options:      options,
 		Status:       JobStatusRunning,
 		CreatedAt:    time.Now(),
+		SessionID:    options.SessionID,
+		Warnings:     warnings,
+		sourceQuery:  query,
+		sourceParams: params,
+		paramShifts:  shifts,
 	}
 
 	// Create cancellable context for this job, independent of request lifecycle
@@ -125,14 +230,31 @@ func (qs *QueryService) Exec(ctx context.Context, resourceName, jobID, query str
 	qs.mu.Lock()
 	if _, exists := qs.activeJobs[jobID]; exists {
 		qs.mu.Unlock()
+		cancel()
 		return nil, ErrJobAlreadyExists
 	}
+	if job.SessionID != "" {
+		session, err := qs.lookupSessionLocked(resourceName, job.SessionID)
+		if err != nil {
+			qs.mu.Unlock()
+			cancel()
+			return nil, err
+		}
+		if session.busy {
+			qs.mu.Unlock()
+			cancel()
+			return nil, fmt.Errorf("%w: %s", ErrSessionBusy, session.ID)
+		}
+		session.busy = true
+		session.ActiveJobID = jobID
+		job.session = session
+		job.TxState = session.TxState
+	}
 	qs.activeJobs[jobID] = job
+	// Start execution once the resource has a free slot
+	qs.scheduleLocked(jobCtx, job, handle)
 	qs.mu.Unlock()
 
-	// Start execution in goroutine
-	go qs.runJob(jobCtx, job, handle)
-
 	return job, nil
 }
 
@@ -146,6 +268,13 @@ func (qs *QueryService) GetStatus(jobID string) (*QueryJobStatus, error) {
 			Status:       job.Status,
 			FinishedAt:   job.FinishedAt,
 			Error:        job.Error,
+			Statements:   summarizeStatements(job.Statements),
+			SessionID:    job.SessionID,
+			TxState:      job.TxState,
+		}
+		status.Notices, status.DroppedNotices = job.notices.list()
+		if job.Status == JobStatusQueued {
+			status.QueuePosition = qs.queuePositionLocked(job)
 		}
 		if job.FinishedAt != nil {
 			duration := job.DurationMs
@@ -163,55 +292,121 @@ func (qs *QueryService) GetStatus(jobID string) (*QueryJobStatus, error) {
 	duration := result.DurationMs
 	finishedAt := result.FinishedAt
 	return &QueryJobStatus{
-		JobID:        result.JobID,
-		ResourceName: result.ResourceName,
-		Status:       result.Status,
-		FinishedAt:   &finishedAt,
-		DurationMs:   &duration,
-		Error:        result.Error,
-		Stored:       result.Status == JobStatusSuccess,
+		JobID:          result.JobID,
+		ResourceName:   result.ResourceName,
+		Status:         result.Status,
+		FinishedAt:     &finishedAt,
+		DurationMs:     &duration,
+		Error:          result.Error,
+		DatabaseError:  result.DatabaseError,
+		Stored:         result.Status == JobStatusSuccess || result.hasStatementResults(),
+		Statements:     summarizeStatements(result.Statements),
+		SessionID:      result.SessionID,
+		TxState:        result.TxState,
+		Notices:        result.Notices,
+		DroppedNotices: result.DroppedNotices,
 	}, nil
 }
 
-// BuildResultView builds a paginated view of a stored query result
-func (qs *QueryService) BuildResultView(ctx context.Context, jobID string, limit, offset *int) (*QueryResultView, error) {
-	result, exists := qs.resultStore.Get(jobID)
-	if !exists {
-		return nil, ErrNotFound
-	}
-	if result.Status != JobStatusSuccess {
-		return nil, ErrResultUnavailable
-	}
-
+// BuildResultView builds a paginated view of a stored query result.
+// For script jobs, statement selects the statement to read; without it the last statement is used.
+// While a job runs, the view covers the first rows fetched so far and is marked partial.
+// A non-empty query sorts and filters the whole result before paging; RowCount then counts matching rows.
+func (qs *QueryService) BuildResultView(ctx context.Context, jobID string, statement, limit, offset *int, query *ResultViewQuery) (*QueryResultView, error) {
 	if offset != nil && *offset < 0 {
 		return nil, fmt.Errorf("offset cannot be negative")
 	}
 	if limit != nil && *limit <= 0 {
 		return nil, fmt.Errorf("limit must be positive")
 	}
+	if limit != nil && offset != nil && *limit > math.MaxInt-*offset {
+		return nil, fmt.Errorf("limit plus offset is too large")
+	}
+
+	stored, exists := qs.resultStore.Get(jobID)
+	if !exists {
+		qs.mu.RLock()
+		var progress *QueryProgress
+		var jobNotices *QueryNotices
+		if job, running := qs.activeJobs[jobID]; running {
+			progress, jobNotices = job.progress, job.notices
+		}
+		qs.mu.RUnlock()
+		if progress == nil {
+			return nil, ErrNotFound
+		}
+		if !query.empty() {
+			return nil, fmt.Errorf("%w: sorting and filtering need a finished result", ErrResultUnavailable)
+		}
+		view, err := progress.partialView(statement, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		notices, _ := jobNotices.list()
+		view.Notices = noticesFor(notices, statement)
+		return view, nil
+	}
+	result, err := selectStatementResult(stored, statement)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lazy results fetch from their cursor until the requested page is buffered
+	if result.lazy && limit != nil && query.empty() {
+		end := *limit
+		if offset != nil {
+			end += *offset
+		}
+		if err := result.fetchRows(ctx, end); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrResultUnavailable, err)
+		}
+	}
+	rowCount, truncated, hasMore := result.rowState()
+
+	// Sorting and filtering page through a cached index of row positions
+	var index []int
+	if !query.empty() {
+		if hasMore {
+			return nil, fmt.Errorf("%w: sorting and filtering need every row, but the result's cursor is still open", ErrResultUnavailable)
+		}
+		index, err = result.viewIndex(query)
+		if err != nil {
+			return nil, err
+		}
+		rowCount = len(index)
+	}
 
 	// Apply defaults
 	actualOffset := 0
 	if offset != nil {
 		actualOffset = *offset
 	}
-	actualLimit := result.RowCount
+	actualLimit := rowCount
 	if limit != nil {
 		actualLimit = *limit
 	}
 
 	start := actualOffset
 	end := actualOffset + actualLimit
-	if start > result.RowCount {
-		start = result.RowCount
+	if start > rowCount {
+		start = rowCount
 	}
-	if end > result.RowCount {
-		end = result.RowCount
+	if end > rowCount {
+		end = rowCount
 	}
 
 	paginatedRows := make([][]any, 0)
-	if start < result.RowCount {
-		paginatedRows = result.Rows[start:end]
+	if start < rowCount {
+		var rows [][]any
+		if index != nil {
+			rows, err = result.readRowsAt(index[start:end])
+		} else {
+			rows, err = result.ReadRows(start, end)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrResultUnavailable, err)
+		}
+		paginatedRows = rows
 	}
 
 	columns := result.Columns
@@ -222,15 +417,82 @@ func (qs *QueryService) BuildResultView(ctx context.Context, jobID string, limit
 	view := &QueryResultView{
 		Columns:      columns,
 		Rows:         paginatedRows,
-		RowCount:     result.RowCount,
-		Truncated:    result.Truncated,
+		RowCount:     rowCount,
+		Truncated:    truncated,
 		RowsAffected: result.RowsAffected,
+		CommandTag:   result.CommandTag,
+		HasMore:      hasMore,
+		Notices:      noticesFor(stored.Notices, statement),
 	}
 
 	return view, nil
 }
 
-// Cancel cancels a running job.
+// selectStatementResult picks the result a view is built from.
+func selectStatementResult(result *QueryResult, statement *int) (*QueryResult, error) {
+	if statement == nil {
+		if result.Status != JobStatusSuccess {
+			return nil, ErrResultUnavailable
+		}
+		if len(result.Statements) == 0 {
+			return result, nil
+		}
+		return result.Statements[len(result.Statements)-1].Result, nil
+	}
+
+	if *statement < 0 || *statement >= len(result.Statements) {
+		return nil, fmt.Errorf("%w: statement %d", ErrStatementNotFound, *statement)
+	}
+	selected := result.Statements[*statement]
+	if selected.Result == nil {
+		return nil, fmt.Errorf("%w: statement %d is %s", ErrResultUnavailable, *statement, selected.Status)
+	}
+	return selected.Result, nil
+}
+
+// resourceDialect returns the SQL dialect spoken by the handle's resource.
+func resourceDialect(handle *ResourceHandle) sqlutil.Dialect {
+	if handle == nil || handle.Resource == nil {
+		return ""
+	}
+	return sqlutil.DialectForResourceType(handle.Resource.Type)
+}
+
+// checkReadOnly refuses writes to a read-only resource before they are sent. The
+// adapters open such resources read-only as well, so this only reports early and
+// clearly what the driver would reject. Every statement is checked, script mode or
+// not, since some drivers run them all.
+func checkReadOnly(handle *ResourceHandle, query string) error {
+	if handle.Resource == nil || !handle.Resource.ReadOnly {
+		return nil
+	}
+	dialect := resourceDialect(handle)
+	for _, statement := range sqlutil.SplitStatements(query, dialect) {
+		if sqlutil.IsWriteQuery(statement.Text, dialect) {
+			return fmt.Errorf("%w: %s does not accept writes", ErrReadOnlyResource, handle.Name)
+		}
+	}
+	return nil
+}
+
+// bindNamedParams rewrites :name and @name parameters into the resource's positional syntax.
+// The returned shifts map offsets of the bound query back to query.
+func bindNamedParams(handle *ResourceHandle, query string, params any) (string, any, []sqlutil.ParamShift, error) {
+	named, ok := params.(map[string]any)
+	if !ok {
+		return query, params, nil, nil
+	}
+	bound, args, shifts, err := sqlutil.BindNamed(query, named, resourceDialect(handle))
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%w: %w", ErrInvalidParams, err)
+	}
+	if len(args) == 0 {
+		return bound, nil, shifts, nil
+	}
+	return bound, args, shifts, nil
+}
+
+// Cancel cancels a running job. A queued job is removed from its queue and never starts.
 func (qs *QueryService) Cancel(jobID string) error {
 	qs.mu.Lock()
 	job, ok := qs.activeJobs[jobID]
@@ -238,45 +500,123 @@ func (qs *QueryService) Cancel(jobID string) error {
 		qs.mu.Unlock()
 		return ErrJobNotFound
 	}
-	if job.Status != JobStatusRunning {
+	switch job.Status {
+	case JobStatusRunning:
+	case JobStatusQueued:
+		qs.dequeueLocked(job)
+		// Mark the job so a second cancel does not finish it again.
+		job.Status = JobStatusCanceled
+	default:
 		qs.mu.Unlock()
 		return nil
 	}
+	status := job.Status
 	cancel := job.Cancel
 	qs.mu.Unlock()
 
 	cancel()
+	if status == JobStatusCanceled {
+		qs.finishQueuedJob(job)
+	}
 	return nil
 }
 
-// Stop cancels all running jobs
+// Stop cancels all running and queued jobs, closes sessions and drops stored results
 func (qs *QueryService) Stop() {
 	qs.mu.Lock()
-	defer qs.mu.Unlock()
-
 	for _, job := range qs.activeJobs {
-		if job.Status == JobStatusRunning {
+		if job.Status == JobStatusRunning || job.Status == JobStatusQueued {
 			job.Cancel()
 		}
 	}
+	var queued []*QueryJob
+	for _, queue := range qs.queues {
+		for _, waiting := range queue.waiting {
+			waiting.job.Status = JobStatusCanceled
+			queued = append(queued, waiting.job)
+		}
+		queue.waiting = nil
+	}
+	qs.mu.Unlock()
+
+	// Queued jobs never started, so nothing else reports their end.
+	for _, job := range queued {
+		qs.finishQueuedJob(job)
+	}
+
+	qs.stopOnce.Do(func() {
+		if qs.sessionsDone != nil {
+			close(qs.sessionsDone)
+		}
+	})
+	qs.closeAllSessions()
+	qs.resultStore.Close()
 }
 
-// runJob executes a query job
-func (qs *QueryService) runJob(ctx context.Context, job *QueryJob, handle *ResourceHandle) {
+// runJob runs a job to completion and returns its stored result.
+func (qs *QueryService) runJob(ctx context.Context, job *QueryJob, handle *ResourceHandle) *QueryResult {
+	if job.Options != nil && job.Options.TimeoutMs > 0 {
+		// The timeout counts from the start of the job, not from when it was queued.
+		timeout := time.Duration(job.Options.TimeoutMs) * time.Millisecond
+		var stopTimer context.CancelFunc
+		ctx, stopTimer = context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w after %s", ErrQueryTimeout, timeout))
+		defer stopTimer()
+	}
+
 	startTime := time.Now()
+	progress := newQueryProgress(startTime)
+	notices := newQueryNotices(progress)
 	qs.mu.Lock()
 	job.StartedAt = &startTime
+	job.progress = progress
+	job.notices = notices
+	if job.Options != nil {
+		job.Options.Progress = progress
+		job.Options.Notices = notices
+	}
 	qs.mu.Unlock()
 
-	result, err := handle.Adapter.ExecuteQuery(ctx, job.Query, job.Params, job.Options)
+	progressDone := make(chan struct{})
+	go qs.reportProgress(job, progress, notices, progressDone)
+
+	var (
+		result *QueryResult
+		err    error
+	)
+	switch {
+	case job.Options != nil && job.Options.Script:
+		result, err = qs.runScript(ctx, job, handle)
+	case job.session != nil:
+		result, err = job.session.conn.ExecuteQuery(ctx, job.Query, job.Params, job.Options)
+		qs.observeSessionStatement(job.session, job.Query, err)
+	default:
+		result, err = handle.Adapter.ExecuteQuery(ctx, job.Query, job.Params, job.Options)
+		if err == nil && result.lazy {
+			err = result.startCursor(ctx, job.Options)
+		}
+	}
+	txState := job.TxState
+	if job.session != nil {
+		txState = qs.releaseSessionJob(job.session)
+	}
+	close(progressDone)
+	// Publish the last notices before the completion event.
+	qs.publishNotices(job, notices)
 	finishTime := time.Now()
 	status := JobStatusSuccess
 	errorMessage := ""
 	if err != nil {
-		if ctx.Err() != nil {
+		switch cause := context.Cause(ctx); {
+		case errors.Is(cause, ErrQueryTimeout):
+			status = JobStatusTimedOut
+			errorMessage = cause.Error()
+		case errors.Is(err, ErrQueryTimeout):
+			status = JobStatusTimedOut
+			errorMessage = err.Error()
+		case ctx.Err() != nil:
 			status = JobStatusCanceled
 			errorMessage = ctx.Err().Error()
-		} else {
+		default:
 			status = JobStatusFailed
 			errorMessage = err.Error()
 		}
@@ -290,15 +630,31 @@ func (qs *QueryService) runJob(ctx context.Context, job *QueryJob, handle *Resou
 	result.ResourceName = job.ResourceName
 	result.Status = status
 	result.Error = errorMessage
+	if err != nil {
+		result.DatabaseError = jobDatabaseError(job, result, err)
+	}
 	result.FinishedAt = finishTime
 	result.DurationMs = duration
+	result.SessionID = job.SessionID
+	result.TxState = txState
+	result.Notices, result.DroppedNotices = notices.list()
+	qs.finishJob(ctx, job, result)
+	return result
+}
+
+// finishJob stores the result of a job, records it in the history and publishes its completion.
+func (qs *QueryService) finishJob(ctx context.Context, job *QueryJob, result *QueryResult) {
+	// Record before the result becomes visible so clients that saw it completed can find it.
+	qs.recordHistory(ctx, job, result)
 	qs.resultStore.Add(result)
 
+	finishTime := result.FinishedAt
 	qs.mu.Lock()
-	job.Status = status
-	job.Error = errorMessage
+	job.Status = result.Status
+	job.Error = result.Error
 	job.FinishedAt = &finishTime
-	job.DurationMs = duration
+	job.DurationMs = result.DurationMs
+	job.TxState = result.TxState
 	delete(qs.activeJobs, job.ID)
 	qs.mu.Unlock()
 	qs.emitJobCompletion(job)
@@ -316,6 +672,8 @@ func (qs *QueryService) emitJobCompletion(job *QueryJob) {
 		Status:       string(job.Status),
 		FinishedAt:   job.FinishedAt.Format(time.RFC3339),
 		DurationMs:   job.DurationMs,
+		SessionID:    job.SessionID,
+		TxState:      string(job.TxState),
 	}
 
 	if job.Error != "" {
@@ -3,22 +3,39 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
+	"github.com/crueladdict/ori/apps/ori-server/internal/events"
 	"github.com/crueladdict/ori/apps/ori-server/internal/model"
 	"github.com/google/uuid"
 )
 
 type testQueryAdapter struct {
-	execute func(context.Context) (*QueryResult, error)
+	execute            func(context.Context, string) (*QueryResult, error)
+	executeWithOptions func(context.Context, string, *QueryExecOptions) (*QueryResult, error)
+	explain            func(context.Context, string, interface{}, *QueryExplainOptions) (*QueryPlan, error)
+	closePinned        func()
 }
 
 func (a testQueryAdapter) Connect(context.Context) error { return nil }
 func (a testQueryAdapter) Close() error                  { return nil }
 func (a testQueryAdapter) Ping(context.Context) error    { return nil }
-func (a testQueryAdapter) ExecuteQuery(ctx context.Context, _ string, _ interface{}, _ *QueryExecOptions) (*QueryResult, error) {
-	return a.execute(ctx)
+func (a testQueryAdapter) ExecuteQuery(ctx context.Context, query string, _ interface{}, options *QueryExecOptions) (*QueryResult, error) {
+	if a.executeWithOptions != nil {
+		return a.executeWithOptions(ctx, query, options)
+	}
+	return a.execute(ctx, query)
+}
+func (a testQueryAdapter) ExplainQuery(ctx context.Context, query string, params interface{}, options *QueryExplainOptions) (*QueryPlan, error) {
+	return a.explain(ctx, query, params, options)
+}
+func (a testQueryAdapter) PinConnection(context.Context) (PinnedConnection, error) {
+	return testPinnedConnection{adapter: a}, nil
 }
 func (a testQueryAdapter) GetScopes(context.Context) ([]model.Scope, error) { return nil, nil }
 func (a testQueryAdapter) GetRelations(context.Context, model.Scope) ([]model.Relation, error) {
@@ -36,11 +53,43 @@ func (a testQueryAdapter) GetIndexes(context.Context, model.Scope, string) ([]mo
 func (a testQueryAdapter) GetTriggers(context.Context, model.Scope, string) ([]model.Trigger, error) {
 	return nil, nil
 }
+func (a testQueryAdapter) GetRoutines(context.Context, model.Scope) ([]model.Routine, error) {
+	return nil, nil
+}
+func (a testQueryAdapter) GetSequences(context.Context, model.Scope) ([]model.Sequence, error) {
+	return nil, nil
+}
+
+func (a testQueryAdapter) GetTypes(context.Context, model.Scope) ([]model.UserType, error) {
+	return nil, nil
+}
+
+func (a testQueryAdapter) GetExtensions(context.Context, model.Scope) ([]model.Extension, error) {
+	return nil, nil
+}
+
+func (a testQueryAdapter) GetRolePrivileges(context.Context, string, string) (*model.RolePrivileges, error) {
+	return nil, ErrPrivilegesUnsupported
+}
+
+type testPinnedConnection struct {
+	adapter testQueryAdapter
+}
+
+func (c testPinnedConnection) ExecuteQuery(ctx context.Context, query string, params interface{}, options *QueryExecOptions) (*QueryResult, error) {
+	return c.adapter.ExecuteQuery(ctx, query, params, options)
+}
+func (c testPinnedConnection) Close() error {
+	if c.adapter.closePinned != nil {
+		c.adapter.closePinned()
+	}
+	return nil
+}
 
 func TestQueryServiceGetStatusReadsActiveAndTerminalJobs(t *testing.T) {
 	service := &QueryService{
 		activeJobs:  map[string]*QueryJob{},
-		resultStore: NewResultStore(DefaultMaxMaterializedRows),
+		resultStore: NewResultStore(DefaultMaxMaterializedRows, 0),
 	}
 	service.activeJobs["running"] = &QueryJob{
 		ID:           "running",
@@ -77,7 +126,7 @@ func TestQueryServiceRespectsConfiguredMaterializationLimit(t *testing.T) {
 	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{}}
 	jobID := uuid.NewString()
 
-	limited := NewQueryService(connectionService, nil, context.Background(), maxRows)
+	limited := NewQueryService(connectionService, nil, context.Background(), maxRows, nil)
 	_, err := limited.Exec(context.Background(), "local", jobID, "SELECT 1", nil, &QueryExecOptions{MaxRows: 11})
 	if !errors.Is(err, ErrMaxRowsExceeded) {
 		t.Fatalf("limited query error = %v, want ErrMaxRowsExceeded", err)
@@ -88,11 +137,11 @@ func TestQueryServiceKeepsSuccessfulResultWhenCancellationRacesWithCompletion(t
 	ctx, cancel := context.WithCancel(context.Background())
 	service := &QueryService{
 		activeJobs:  map[string]*QueryJob{},
-		resultStore: NewResultStore(DefaultMaxMaterializedRows),
+		resultStore: NewResultStore(DefaultMaxMaterializedRows, 0),
 	}
 	job := &QueryJob{ID: "job", ResourceName: "local", Status: JobStatusRunning}
 	handle := &ResourceHandle{Adapter: testQueryAdapter{
-		execute: func(context.Context) (*QueryResult, error) {
+		execute: func(context.Context, string) (*QueryResult, error) {
 			cancel()
 			return &QueryResult{}, nil
 		},
@@ -108,3 +157,856 @@ func TestQueryServiceKeepsSuccessfulResultWhenCancellationRacesWithCompletion(t
 		t.Fatalf("stored result = %#v, want successful result", result)
 	}
 }
+
+func TestQueryServiceRunsScriptStatementsInOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		onError      ScriptErrorMode
+		wantExecuted []string
+		wantStatus   []StatementStatus
+	}{
+		{
+			name:         "stops at first failure",
+			onError:      ScriptStopOnError,
+			wantExecuted: []string{"BEGIN", "INSERT INTO t VALUES (';')", "bad", "ROLLBACK"},
+			wantStatus:   []StatementStatus{StatementStatusSuccess, StatementStatusSuccess, StatementStatusFailed, StatementStatusSkipped},
+		},
+		{
+			name:         "continues after failure",
+			onError:      ScriptContinueOnError,
+			wantExecuted: []string{"BEGIN", "INSERT INTO t VALUES (';')", "bad", "SELECT 1", "ROLLBACK"},
+			wantStatus:   []StatementStatus{StatementStatusSuccess, StatementStatusSuccess, StatementStatusFailed, StatementStatusSuccess},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var executed []string
+			service := &QueryService{
+				activeJobs:  map[string]*QueryJob{},
+				resultStore: NewResultStore(DefaultMaxMaterializedRows, 0),
+			}
+			job := &QueryJob{
+				ID:           "script",
+				ResourceName: "local",
+				Query:        "BEGIN;\nINSERT INTO t VALUES (';');\nbad;\nSELECT 1",
+				Options:      &QueryExecOptions{Script: true, OnError: tt.onError},
+				Status:       JobStatusRunning,
+			}
+			handle := &ResourceHandle{
+				Resource: &model.Resource{Type: "postgres"},
+				Adapter: testQueryAdapter{
+					execute: func(_ context.Context, query string) (*QueryResult, error) {
+						executed = append(executed, query)
+						if query == "bad" {
+							return nil, errors.New("syntax error")
+						}
+						return &QueryResult{Columns: []QueryColumn{{Name: "n", Type: "int"}}, Rows: [][]any{{query}}, RowCount: 1}, nil
+					},
+				},
+			}
+
+			service.runJob(context.Background(), job, handle)
+
+			if !reflect.DeepEqual(executed, tt.wantExecuted) {
+				t.Fatalf("executed = %q, want %q", executed, tt.wantExecuted)
+			}
+			if job.Status != JobStatusFailed {
+				t.Fatalf("job status = %s, want %s", job.Status, JobStatusFailed)
+			}
+			status, err := service.GetStatus(job.ID)
+			if err != nil {
+				t.Fatalf("get status: %v", err)
+			}
+			if !status.Stored || len(status.Statements) != len(tt.wantStatus) {
+				t.Fatalf("status = %#v", status)
+			}
+			for i, want := range tt.wantStatus {
+				if status.Statements[i].Status != want {
+					t.Fatalf("statement %d status = %s, want %s", i, status.Statements[i].Status, want)
+				}
+			}
+
+			second := 1
+			view, err := service.BuildResultView(context.Background(), job.ID, &second, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("build statement view: %v", err)
+			}
+			if len(view.Rows) != 1 || view.Rows[0][0] != "INSERT INTO t VALUES (';')" {
+				t.Fatalf("statement view rows = %#v", view.Rows)
+			}
+			failed := 2
+			if _, err := service.BuildResultView(context.Background(), job.ID, &failed, nil, nil, nil); !errors.Is(err, ErrResultUnavailable) {
+				t.Fatalf("failed statement view error = %v, want ErrResultUnavailable", err)
+			}
+			missing := 4
+			if _, err := service.BuildResultView(context.Background(), job.ID, &missing, nil, nil, nil); !errors.Is(err, ErrStatementNotFound) {
+				t.Fatalf("missing statement view error = %v, want ErrStatementNotFound", err)
+			}
+		})
+	}
+}
+
+func TestQueryServiceBindsNamedParams(t *testing.T) {
+	executed := make(chan string, 1)
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite"},
+			Adapter: testQueryAdapter{
+				execute: func(_ context.Context, query string) (*QueryResult, error) {
+					executed <- query
+					return &QueryResult{}, nil
+				},
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+
+	job, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT :id, @name, :id", map[string]any{"id": 1, "name": "x"}, nil)
+	if err != nil {
+		t.Fatalf("exec with named params: %v", err)
+	}
+	if query := <-executed; query != "SELECT ?1, ?2, ?1" {
+		t.Fatalf("executed query = %q", query)
+	}
+	if !reflect.DeepEqual(job.Params, []any{1, "x"}) {
+		t.Fatalf("bound params = %#v", job.Params)
+	}
+
+	_, err = service.Exec(context.Background(), "local", uuid.NewString(), "SELECT :id", map[string]any{"other": 1}, nil)
+	if !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("exec with mismatched params error = %v, want ErrInvalidParams", err)
+	}
+}
+
+func TestQueryServiceSessionKeepsTransactionAcrossJobs(t *testing.T) {
+	var executed []string
+	release := make(chan struct{})
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "postgres"},
+			Adapter: testQueryAdapter{
+				execute: func(_ context.Context, query string) (*QueryResult, error) {
+					executed = append(executed, query)
+					if query == "SELECT pg_sleep(1)" {
+						<-release
+					}
+					return &QueryResult{}, nil
+				},
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	session, err := service.OpenSession(context.Background(), "local")
+	if err != nil {
+		t.Fatalf("open session: %v", err)
+	}
+	run := func(query string) *QueryJob {
+		t.Helper()
+		job, err := service.Exec(context.Background(), "local", uuid.NewString(), query, nil, &QueryExecOptions{SessionID: session.ID})
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		return job
+	}
+	waitForJob := func(jobID string) *QueryJobStatus {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			status, err := service.GetStatus(jobID)
+			if err == nil && status.Status != JobStatusRunning {
+				return status
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		t.Fatalf("job %s did not finish", jobID)
+		return nil
+	}
+
+	begin := waitForJob(run("BEGIN").ID)
+	if begin.SessionID != session.ID || begin.TxState != TxStateInTransaction {
+		t.Fatalf("begin status = %#v, want session in transaction", begin)
+	}
+
+	sleeping := run("SELECT pg_sleep(1)")
+	if _, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT 1", nil, &QueryExecOptions{SessionID: session.ID}); !errors.Is(err, ErrSessionBusy) {
+		t.Fatalf("exec on busy session error = %v, want ErrSessionBusy", err)
+	}
+	if err := service.CloseSession(context.Background(), "local", session.ID); !errors.Is(err, ErrSessionBusy) {
+		t.Fatalf("close busy session error = %v, want ErrSessionBusy", err)
+	}
+	close(release)
+	waitForJob(sleeping.ID)
+
+	service.rollbackIdleSessions(time.Now().Add(DefaultSessionIdleTxTimeout))
+	info, err := service.GetSession("local", session.ID)
+	if err != nil {
+		t.Fatalf("get session: %v", err)
+	}
+	if info.TxState != TxStateIdle {
+		t.Fatalf("session state after idle rollback = %s, want %s", info.TxState, TxStateIdle)
+	}
+	want := []string{"BEGIN", "SELECT pg_sleep(1)", "ROLLBACK"}
+	if !reflect.DeepEqual(executed, want) {
+		t.Fatalf("executed = %q, want %q", executed, want)
+	}
+
+	if err := service.CloseSession(context.Background(), "local", session.ID); err != nil {
+		t.Fatalf("close session: %v", err)
+	}
+	if _, err := service.GetSession("local", session.ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("get closed session error = %v, want ErrSessionNotFound", err)
+	}
+}
+
+func TestQueryServiceExpiresIdleSessions(t *testing.T) {
+	var executed []string
+	closed := 0
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "postgres"},
+			Adapter: testQueryAdapter{
+				execute: func(_ context.Context, query string) (*QueryResult, error) {
+					executed = append(executed, query)
+					return &QueryResult{}, nil
+				},
+				closePinned: func() { closed++ },
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	idle, err := service.OpenSession(context.Background(), "local")
+	if err != nil {
+		t.Fatalf("open idle session: %v", err)
+	}
+	inTx, err := service.OpenSession(context.Background(), "local")
+	if err != nil {
+		t.Fatalf("open session: %v", err)
+	}
+	service.mu.Lock()
+	service.sessions[inTx.ID].TxState = TxStateInTransaction
+	service.mu.Unlock()
+
+	service.expireIdleSessions(time.Now().Add(DefaultSessionIdleTimeout - time.Second))
+	if _, err := service.GetSession("local", idle.ID); err != nil {
+		t.Fatalf("session expired before the idle timeout: %v", err)
+	}
+
+	service.expireIdleSessions(time.Now().Add(DefaultSessionIdleTimeout))
+	if _, err := service.GetSession("local", idle.ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Fatalf("get expired session error = %v, want ErrSessionNotFound", err)
+	}
+	if closed != 1 || len(executed) != 0 {
+		t.Fatalf("closed = %d, executed = %q; want the idle connection released without a rollback", closed, executed)
+	}
+	if _, err := service.GetSession("local", inTx.ID); err != nil {
+		t.Fatalf("session inside a transaction was expired: %v", err)
+	}
+}
+
+func TestQueryServiceReportsProgressOfRunningJob(t *testing.T) {
+	fetched := make(chan struct{})
+	release := make(chan struct{})
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite"},
+			Adapter: testQueryAdapter{
+				executeWithOptions: func(_ context.Context, _ string, options *QueryExecOptions) (*QueryResult, error) {
+					collector := NewRowCollector(options)
+					defer collector.Discard()
+					collector.SetColumns([]QueryColumn{{Name: "n", Type: "text"}})
+					for _, value := range []string{"a", "b", "c"} {
+						if err := collector.Append([]any{value}); err != nil {
+							return nil, err
+						}
+					}
+					close(fetched)
+					<-release
+					result := &QueryResult{Columns: []QueryColumn{{Name: "n", Type: "text"}}}
+					return result, collector.Finish(result)
+				},
+			},
+		},
+	}}
+	hub := events.NewHub()
+	subscription, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	service := NewQueryService(connectionService, hub, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	job, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT n FROM t", nil, nil)
+	if err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	<-fetched
+
+	limit := 2
+	view, err := service.BuildResultView(context.Background(), job.ID, nil, &limit, nil, nil)
+	if err != nil {
+		t.Fatalf("build partial view: %v", err)
+	}
+	if !view.Partial || view.RowCount != 3 || !reflect.DeepEqual(view.Rows, [][]any{{"a"}, {"b"}}) {
+		t.Fatalf("partial view = %#v", view)
+	}
+
+	select {
+	case event := <-subscription:
+		payload, ok := event.Payload.(events.QueryJobProgressPayload)
+		if event.Name != events.QueryJobProgressEvent || !ok {
+			t.Fatalf("event = %#v, want progress event", event)
+		}
+		if payload.JobID != job.ID || payload.RowsFetched != 3 || payload.BytesMaterialized != 3 {
+			t.Fatalf("progress payload = %#v", payload)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no progress event published")
+	}
+	close(release)
+}
+
+func TestQueryServiceCollectsNotices(t *testing.T) {
+	hub := events.NewHub()
+	subscription, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	service := &QueryService{
+		eventHub:    hub,
+		activeJobs:  map[string]*QueryJob{},
+		resultStore: NewResultStore(DefaultMaxMaterializedRows, 0),
+	}
+	job := &QueryJob{
+		ID:           "notices",
+		ResourceName: "local",
+		Query:        "SELECT 1;\nDO $$ BEGIN RAISE NOTICE 'hello'; END $$",
+		Options:      &QueryExecOptions{Script: true},
+		Status:       JobStatusRunning,
+	}
+	handle := &ResourceHandle{
+		Resource: &model.Resource{Type: "postgres"},
+		Adapter: testQueryAdapter{
+			executeWithOptions: func(_ context.Context, query string, options *QueryExecOptions) (*QueryResult, error) {
+				if strings.HasPrefix(query, "DO") {
+					options.Notices.Add(QueryNotice{Severity: "NOTICE", Code: "00000", Message: "hello"})
+					for range maxJobNotices {
+						options.Notices.Add(QueryNotice{Severity: "NOTICE", Code: "00000", Message: "again"})
+					}
+				}
+				return &QueryResult{Columns: []QueryColumn{{Name: "n", Type: "int"}}, Rows: [][]any{{1}}, RowCount: 1}, nil
+			},
+		},
+	}
+
+	service.runJob(context.Background(), job, handle)
+
+	statement := 1
+	want := []QueryNotice{{Severity: "NOTICE", Code: "00000", Message: "hello", Statement: &statement}}
+	for range maxJobNotices - 1 {
+		want = append(want, QueryNotice{Severity: "NOTICE", Code: "00000", Message: "again", Statement: &statement})
+	}
+	status, err := service.GetStatus(job.ID)
+	if err != nil {
+		t.Fatalf("get status: %v", err)
+	}
+	if !reflect.DeepEqual(status.Notices, want) || status.DroppedNotices != 1 {
+		t.Fatalf("status notices = %d, dropped %d, want %d, dropped 1", len(status.Notices), status.DroppedNotices, len(want))
+	}
+	view, err := service.BuildResultView(context.Background(), job.ID, &statement, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("build view: %v", err)
+	}
+	if !reflect.DeepEqual(view.Notices, want) {
+		t.Fatalf("view notices = %#v, want %#v", view.Notices, want)
+	}
+	first := 0
+	view, err = service.BuildResultView(context.Background(), job.ID, &first, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("build view: %v", err)
+	}
+	if len(view.Notices) != 0 {
+		t.Fatalf("first statement notices = %#v, want none", view.Notices)
+	}
+
+	for {
+		select {
+		case event := <-subscription:
+			payload, ok := event.Payload.(events.QueryJobNoticePayload)
+			if !ok {
+				continue
+			}
+			if payload.JobID != job.ID || len(payload.Notices) != maxJobNotices || payload.DroppedNotices != 1 {
+				t.Fatalf("notice payload = %#v", payload)
+			}
+			if first := payload.Notices[0]; first.Message != "hello" || first.Statement == nil || *first.Statement != 1 {
+				t.Fatalf("first notice = %#v", first)
+			}
+			return
+		default:
+			t.Fatal("no notice event published")
+		}
+	}
+}
+
+func TestQueryServiceLocatesDatabaseErrors(t *testing.T) {
+	service := &QueryService{
+		activeJobs:  map[string]*QueryJob{},
+		resultStore: NewResultStore(DefaultMaxMaterializedRows, 0),
+	}
+	handle := &ResourceHandle{
+		Resource: &model.Resource{Type: "postgres"},
+		Adapter: testQueryAdapter{
+			execute: func(_ context.Context, query string) (*QueryResult, error) {
+				if position := strings.Index(query, "nope"); position >= 0 {
+					return nil, &DatabaseError{Engine: "postgres", Code: "42703", Message: "column does not exist", Position: utf8.RuneCountInString(query[:position]) + 1, Err: errors.New("column does not exist")}
+				}
+				return &QueryResult{}, nil
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		params     map[string]any
+		script     bool
+		line, col  int
+		statements int
+	}{
+		{name: "single query", query: "SELECT 'é',\n  nope FROM t", line: 2, col: 3},
+		{name: "script statement", query: "SELECT 1;\nSELECT 2; SELECT nope", script: true, line: 2, col: 18, statements: 3},
+		{name: "named params", query: "SELECT :long_name,\n  :b, nope FROM t", params: map[string]any{"long_name": 1, "b": 2}, line: 2, col: 7},
+		{name: "script with named params", query: "SELECT :a;\nSELECT 2; SELECT :value, nope", params: map[string]any{"a": 1, "value": 2}, script: true, line: 2, col: 26, statements: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, params, shifts, err := bindNamedParams(handle, tt.query, tt.params)
+			if err != nil {
+				t.Fatalf("bind params: %v", err)
+			}
+			job := &QueryJob{
+				ID:           tt.name,
+				ResourceName: "local",
+				Query:        query,
+				Params:       params,
+				Options:      &QueryExecOptions{Script: tt.script},
+				Status:       JobStatusRunning,
+				sourceQuery:  tt.query,
+				paramShifts:  shifts,
+			}
+			service.runJob(context.Background(), job, handle)
+
+			status, err := service.GetStatus(job.ID)
+			if err != nil {
+				t.Fatalf("get status: %v", err)
+			}
+			dbErr := status.DatabaseError
+			if dbErr == nil || dbErr.Code != "42703" || dbErr.Line != tt.line || dbErr.Column != tt.col {
+				t.Fatalf("database error = %+v, want line %d column %d", dbErr, tt.line, tt.col)
+			}
+			if len(status.Statements) != tt.statements {
+				t.Fatalf("statements = %d, want %d", len(status.Statements), tt.statements)
+			}
+			if tt.script && status.Statements[2].DatabaseError != dbErr {
+				t.Fatalf("failed statement error = %+v", status.Statements[2].DatabaseError)
+			}
+		})
+	}
+}
+
+func TestQueryServiceTimesOutJobs(t *testing.T) {
+	queryTimeout := 20
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite", QueryTimeout: &queryTimeout},
+			Adapter: testQueryAdapter{
+				executeWithOptions: func(ctx context.Context, query string, _ *QueryExecOptions) (*QueryResult, error) {
+					if query == "server timeout" {
+						return nil, fmt.Errorf("%w: canceling statement due to statement timeout", ErrQueryTimeout)
+					}
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(200 * time.Millisecond):
+						return &QueryResult{}, nil
+					}
+				},
+			},
+		},
+	}}
+	hub := events.NewHub()
+	subscription, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	service := NewQueryService(connectionService, hub, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	tests := []struct {
+		name    string
+		query   string
+		options *QueryExecOptions
+		want    JobStatus
+		message string
+	}{
+		{name: "resource timeout", query: "SELECT slow()", want: JobStatusTimedOut, message: "query timed out after 20ms"},
+		{name: "job timeout overrides resource", query: "SELECT slow()", options: &QueryExecOptions{TimeoutMs: 1000}, want: JobStatusSuccess},
+		{name: "database timeout", query: "server timeout", options: &QueryExecOptions{TimeoutMs: 1000}, want: JobStatusTimedOut, message: "query timed out: canceling statement due to statement timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job, err := service.Exec(context.Background(), "local", uuid.NewString(), tt.query, nil, tt.options)
+			if err != nil {
+				t.Fatalf("exec: %v", err)
+			}
+			select {
+			case event := <-subscription:
+				payload, ok := event.Payload.(events.QueryJobCompletedPayload)
+				if !ok || payload.JobID != job.ID {
+					t.Fatalf("event = %#v, want completion of %s", event, job.ID)
+				}
+				if payload.Status != string(tt.want) || payload.Error != tt.message {
+					t.Fatalf("completion = %s %q, want %s %q", payload.Status, payload.Error, tt.want, tt.message)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("job did not complete")
+			}
+		})
+	}
+
+	_, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT 1", nil, &QueryExecOptions{TimeoutMs: -1})
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("negative timeout error = %v, want ErrInvalidOptions", err)
+	}
+}
+
+func TestQueryServiceQueuesJobsPerResource(t *testing.T) {
+	started := make(chan string, 4)
+	release := make(chan struct{})
+	maxConcurrent := 1
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite", MaxConcurrentQueries: &maxConcurrent},
+			Adapter: testQueryAdapter{
+				execute: func(_ context.Context, query string) (*QueryResult, error) {
+					started <- query
+					<-release
+					return &QueryResult{}, nil
+				},
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	jobs := make([]*QueryJob, 4)
+	for i := range jobs {
+		job, err := service.Exec(context.Background(), "local", uuid.NewString(), fmt.Sprintf("SELECT %d", i), nil, nil)
+		if err != nil {
+			t.Fatalf("exec %d: %v", i, err)
+		}
+		jobs[i] = job
+	}
+	if query := <-started; query != "SELECT 0" {
+		t.Fatalf("first started query = %q", query)
+	}
+	for i, want := range []struct {
+		status   JobStatus
+		position int
+	}{{JobStatusRunning, 0}, {JobStatusQueued, 1}, {JobStatusQueued, 2}, {JobStatusQueued, 3}} {
+		status, err := service.GetStatus(jobs[i].ID)
+		if err != nil || status.Status != want.status || status.QueuePosition != want.position {
+			t.Fatalf("job %d status = %+v, %v; want %s at %d", i, status, err, want.status, want.position)
+		}
+	}
+
+	if err := service.Cancel(jobs[2].ID); err != nil {
+		t.Fatalf("cancel queued job: %v", err)
+	}
+	canceled, err := service.GetStatus(jobs[2].ID)
+	if err != nil || canceled.Status != JobStatusCanceled {
+		t.Fatalf("canceled job status = %+v, %v", canceled, err)
+	}
+	if status, _ := service.GetStatus(jobs[3].ID); status.QueuePosition != 2 {
+		t.Fatalf("last job position after cancel = %d, want 2", status.QueuePosition)
+	}
+
+	close(release)
+	for _, want := range []string{"SELECT 1", "SELECT 3"} {
+		select {
+		case query := <-started:
+			if query != want {
+				t.Fatalf("started query = %q, want %q", query, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%q did not start", want)
+		}
+	}
+}
+
+func TestQueryServiceStopCancelsQueuedJobs(t *testing.T) {
+	started := make(chan struct{}, 1)
+	maxConcurrent := 1
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite", MaxConcurrentQueries: &maxConcurrent},
+			Adapter: testQueryAdapter{
+				execute: func(ctx context.Context, _ string) (*QueryResult, error) {
+					started <- struct{}{}
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			},
+		},
+	}}
+	hub := events.NewHub()
+	subscription, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+	service := NewQueryService(connectionService, hub, context.Background(), DefaultMaxMaterializedRows, nil)
+
+	if _, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT 1", nil, nil); err != nil {
+		t.Fatalf("exec running: %v", err)
+	}
+	<-started
+	queued, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT 2", nil, nil)
+	if err != nil {
+		t.Fatalf("exec queued: %v", err)
+	}
+
+	service.Stop()
+	deadline := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-subscription:
+			payload, ok := event.Payload.(events.QueryJobCompletedPayload)
+			if !ok || payload.JobID != queued.ID {
+				continue
+			}
+			if payload.Status != string(JobStatusCanceled) {
+				t.Fatalf("queued job completion = %s, want canceled", payload.Status)
+			}
+			service.mu.Lock()
+			_, active := service.activeJobs[queued.ID]
+			service.mu.Unlock()
+			if active {
+				t.Fatal("queued job still active after stop")
+			}
+			return
+		case <-deadline:
+			t.Fatal("queued job did not complete on stop")
+		}
+	}
+}
+
+func TestQueryServiceReleasesSlotOnceCursorDeliversFirstPage(t *testing.T) {
+	started := make(chan string, 3)
+	maxConcurrent := 1
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite", MaxConcurrentQueries: &maxConcurrent},
+			Adapter: testQueryAdapter{
+				executeWithOptions: func(_ context.Context, query string, options *QueryExecOptions) (*QueryResult, error) {
+					started <- query
+					if options.Lazy {
+						return NewCursorResult([]QueryColumn{{Name: "n"}}, &fakeRowCursor{total: 25}), nil
+					}
+					return &QueryResult{}, nil
+				},
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	service.maxOpenCursors = 1
+	defer service.Stop()
+
+	runLazy := func(query string) *QueryResult {
+		t.Helper()
+		job, err := service.Exec(context.Background(), "local", uuid.NewString(), query, nil, &QueryExecOptions{Lazy: true, FetchRows: 10})
+		if err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+		<-started
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			if result, ok := service.resultStore.Get(job.ID); ok {
+				return result
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("lazy job %q did not finish", query)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	first := runLazy("SELECT lazy")
+	if _, open := first.cursorLastUsed(); !open {
+		t.Fatal("first page closed the cursor")
+	}
+
+	if _, err := service.Exec(context.Background(), "local", uuid.NewString(), "SELECT next", nil, nil); err != nil {
+		t.Fatalf("exec next: %v", err)
+	}
+	select {
+	case query := <-started:
+		if query != "SELECT next" {
+			t.Fatalf("started query = %q", query)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job behind an open cursor did not start")
+	}
+
+	second := runLazy("SELECT lazy again")
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, open := first.cursorLastUsed(); !open {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("cursor over the open cursor limit was not closed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if _, open := second.cursorLastUsed(); !open {
+		t.Fatal("newest cursor was closed instead of the least recently used one")
+	}
+	if rowCount, truncated, _ := first.rowState(); rowCount != 10 || !truncated {
+		t.Fatalf("closed cursor rows = %d, truncated = %v; want the first page kept", rowCount, truncated)
+	}
+}
+
+func TestQueryServiceRefusesWritesToReadOnlyResource(t *testing.T) {
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"prod": {
+			Name:     "prod",
+			Resource: &model.Resource{Type: "postgresql", ReadOnly: true},
+			Adapter: testQueryAdapter{
+				execute: func(context.Context, string) (*QueryResult, error) {
+					return &QueryResult{}, nil
+				},
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	tests := []struct {
+		query   string
+		options *QueryExecOptions
+		wantErr bool
+	}{
+		{query: "SELECT * FROM orders"},
+		{query: "DELETE FROM orders", wantErr: true},
+		{query: "SELECT 1; UPDATE orders SET total = 0", options: &QueryExecOptions{Script: true}, wantErr: true},
+		{query: "SELECT 1; UPDATE orders SET total = 0", wantErr: true},
+		{query: "BEGIN; SELECT 1; COMMIT", options: &QueryExecOptions{Script: true}},
+	}
+	for _, tt := range tests {
+		_, err := service.Exec(context.Background(), "prod", uuid.NewString(), tt.query, nil, tt.options)
+		if got := errors.Is(err, ErrReadOnlyResource); got != tt.wantErr {
+			t.Fatalf("exec %q error = %v, want read-only rejection %v", tt.query, err, tt.wantErr)
+		}
+	}
+
+	_, err := service.Explain(context.Background(), "prod", "DELETE FROM orders", nil, &QueryExplainOptions{Analyze: true})
+	if !errors.Is(err, ErrReadOnlyResource) {
+		t.Fatalf("explain analyze error = %v, want ErrReadOnlyResource", err)
+	}
+}
+
+func TestQueryServiceGuardsDestructiveQueries(t *testing.T) {
+	adapter := testQueryAdapter{
+		execute: func(context.Context, string) (*QueryResult, error) {
+			return &QueryResult{}, nil
+		},
+	}
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"prod": {Name: "prod", Resource: &model.Resource{Type: "postgresql", DestructiveGuard: model.DestructiveGuardConfirm}, Adapter: adapter},
+		"dev":  {Name: "dev", Resource: &model.Resource{Type: "postgresql"}, Adapter: adapter},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+	ctx := context.Background()
+	query := "SELECT 1; DELETE FROM orders"
+	script := &QueryExecOptions{Script: true}
+
+	_, err := service.Exec(ctx, "prod", uuid.NewString(), query, nil, script)
+	var confirmErr *ConfirmationRequiredError
+	if !errors.As(err, &confirmErr) || !errors.Is(err, ErrConfirmationRequired) {
+		t.Fatalf("unconfirmed exec error = %v, want ConfirmationRequiredError", err)
+	}
+	if len(confirmErr.Risks) != 1 || confirmErr.Risks[0] != "DELETE without WHERE" || confirmErr.Token == "" {
+		t.Fatalf("confirmation = %+v", confirmErr)
+	}
+
+	other := &QueryExecOptions{Script: true, ConfirmToken: confirmErr.Token}
+	if _, err := service.Exec(ctx, "prod", uuid.NewString(), "DROP TABLE orders", nil, other); !errors.Is(err, ErrConfirmationRequired) {
+		t.Fatalf("token reused for another query: err = %v", err)
+	}
+	confirmed := &QueryExecOptions{Script: true, ConfirmToken: confirmErr.Token}
+	if _, err := service.Exec(ctx, "prod", uuid.NewString(), query, nil, confirmed); err != nil {
+		t.Fatalf("confirmed exec: %v", err)
+	}
+
+	job, err := service.Exec(ctx, "dev", uuid.NewString(), "TRUNCATE orders", nil, nil)
+	if err != nil {
+		t.Fatalf("exec on warn resource: %v", err)
+	}
+	if len(job.Warnings) != 1 || job.Warnings[0] != "TRUNCATE" {
+		t.Fatalf("warnings = %v, want [TRUNCATE]", job.Warnings)
+	}
+
+	// Drivers such as DuckDB run every statement of a query, so the guard must not
+	// stop at the first one outside script mode.
+	if _, err := service.Exec(ctx, "prod", uuid.NewString(), query, nil, nil); !errors.Is(err, ErrConfirmationRequired) {
+		t.Fatalf("multi-statement exec without script mode: err = %v, want ErrConfirmationRequired", err)
+	}
+}
+
+func TestQueryServiceExplainsSingleStatement(t *testing.T) {
+	var explained string
+	var explainedParams any
+	connectionService := &ResourceSessionService{connections: map[string]*ResourceHandle{
+		"local": {
+			Name:     "local",
+			Resource: &model.Resource{Type: "sqlite"},
+			Adapter: testQueryAdapter{
+				explain: func(_ context.Context, query string, params interface{}, options *QueryExplainOptions) (*QueryPlan, error) {
+					if options.Analyze {
+						return nil, ErrExplainUnsupported
+					}
+					explained, explainedParams = query, params
+					return &QueryPlan{Nodes: []*QueryPlanNode{{NodeType: "SCAN", Relation: "t"}}}, nil
+				},
+			},
+		},
+	}}
+	service := NewQueryService(connectionService, nil, context.Background(), DefaultMaxMaterializedRows, nil)
+	defer service.Stop()
+
+	plan, err := service.Explain(context.Background(), "local", "SELECT * FROM t WHERE id = :id;", map[string]any{"id": 1}, nil)
+	if err != nil {
+		t.Fatalf("explain: %v", err)
+	}
+	if explained != "SELECT * FROM t WHERE id = ?1" || !reflect.DeepEqual(explainedParams, []any{1}) {
+		t.Fatalf("explained %q with %#v", explained, explainedParams)
+	}
+	if len(plan.Nodes) != 1 || plan.Nodes[0].Relation != "t" {
+		t.Fatalf("plan = %#v", plan)
+	}
+
+	_, err = service.Explain(context.Background(), "local", "SELECT 1; SELECT 2", nil, nil)
+	if !errors.Is(err, ErrInvalidOptions) {
+		t.Fatalf("explain of two statements error = %v, want ErrInvalidOptions", err)
+	}
+	_, err = service.Explain(context.Background(), "local", "SELECT 1", nil, &QueryExplainOptions{Analyze: true})
+	if !errors.Is(err, ErrExplainUnsupported) {
+		t.Fatalf("explain analyze error = %v, want ErrExplainUnsupported", err)
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/pkg/sqlutil"
+)
+
+const (
+	// DefaultSessionIdleTxTimeout is how long a session may sit idle inside a transaction before it is rolled back.
+	DefaultSessionIdleTxTimeout = 5 * time.Minute
+	// DefaultSessionIdleTimeout is how long a session may sit unused outside a transaction before it is closed.
+	DefaultSessionIdleTimeout = 30 * time.Minute
+
+	sessionSweepInterval = 15 * time.Second
+	sessionCloseTimeout  = 5 * time.Second
+)
+
+var (
+	ErrSessionNotFound = errors.New("query session not found")
+	ErrSessionBusy     = errors.New("query session is running another job")
+	// ErrSessionsUnsupported is returned when a resource cannot spare a connection for a session.
+	ErrSessionsUnsupported = errors.New("query sessions are not supported by this resource")
+)
+
+// TxState describes the transaction state of a pinned session connection
+type TxState string
+
+const (
+	TxStateIdle          TxState = "idle"
+	TxStateInTransaction TxState = "in_transaction"
+	TxStateFailed        TxState = "failed"
+)
+
+// TransactionStateReporter is implemented by pinned connections that can read the
+// transaction state from the driver instead of inferring it from statements.
+type TransactionStateReporter interface {
+	TransactionState() (TxState, error)
+}
+
+// SingleConnectionAdapter is implemented by adapters whose pool holds one connection,
+// which a session would keep from every other query for its whole lifetime.
+type SingleConnectionAdapter interface {
+	SingleConnection() bool
+}
+
+// QuerySession pins a dedicated connection so consecutive jobs share transactions and temp objects.
+type QuerySession struct {
+	ID           string
+	ResourceName string
+	CreatedAt    time.Time
+	LastUsedAt   time.Time
+	TxState      TxState
+	ActiveJobID  string
+
+	conn    PinnedConnection
+	dialect sqlutil.Dialect
+	busy    bool
+}
+
+// QuerySessionInfo is a snapshot of a session's state
+type QuerySessionInfo struct {
+	ID           string
+	ResourceName string
+	CreatedAt    time.Time
+	LastUsedAt   time.Time
+	TxState      TxState
+	ActiveJobID  string
+}
+
+func (s *QuerySession) info() QuerySessionInfo {
+	return QuerySessionInfo{
+		ID:           s.ID,
+		ResourceName: s.ResourceName,
+		CreatedAt:    s.CreatedAt,
+		LastUsedAt:   s.LastUsedAt,
+		TxState:      s.TxState,
+		ActiveJobID:  s.ActiveJobID,
+	}
+}
+
+// OpenSession pins a dedicated connection of the resource for use by later jobs.
+func (qs *QueryService) OpenSession(ctx context.Context, resourceName string) (*QuerySessionInfo, error) {
+	handle, ok := qs.connectionService.GetConnection(resourceName)
+	if !ok || handle == nil || handle.Adapter == nil {
+		return nil, fmt.Errorf("%w: %s", ErrConnectionUnavailable, resourceName)
+	}
+
+	if single, ok := handle.Adapter.(SingleConnectionAdapter); ok && single.SingleConnection() {
+		return nil, fmt.Errorf("%w: %s has a single connection", ErrSessionsUnsupported, resourceName)
+	}
+
+	conn, err := handle.Adapter.PinConnection(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open session: %w", err)
+	}
+
+	now := time.Now()
+	session := &QuerySession{
+		ID:           uuid.NewString(),
+		ResourceName: resourceName,
+		CreatedAt:    now,
+		LastUsedAt:   now,
+		TxState:      TxStateIdle,
+		conn:         conn,
+		dialect:      resourceDialect(handle),
+	}
+
+	qs.mu.Lock()
+	qs.sessions[session.ID] = session
+	info := session.info()
+	qs.mu.Unlock()
+
+	slog.InfoContext(ctx, "query session opened",
+		slog.String("sessionId", session.ID),
+		slog.String("resource", resourceName))
+	return &info, nil
+}
+
+// GetSession returns the current state of a session.
+func (qs *QueryService) GetSession(resourceName, sessionID string) (*QuerySessionInfo, error) {
+	qs.mu.RLock()
+	defer qs.mu.RUnlock()
+	session, err := qs.lookupSessionLocked(resourceName, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	info := session.info()
+	return &info, nil
+}
+
+// CloseSession rolls back any open transaction and releases the session's connection.
+func (qs *QueryService) CloseSession(ctx context.Context, resourceName, sessionID string) error {
+	qs.mu.Lock()
+	session, err := qs.lookupSessionLocked(resourceName, sessionID)
+	if err != nil {
+		qs.mu.Unlock()
+		return err
+	}
+	if session.busy {
+		qs.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrSessionBusy, session.ID)
+	}
+	delete(qs.sessions, sessionID)
+	txState := session.TxState
+	qs.mu.Unlock()
+
+	qs.releaseSession(ctx, session, txState)
+	return nil
+}
+
+func (qs *QueryService) lookupSessionLocked(resourceName, sessionID string) (*QuerySession, error) {
+	session, ok := qs.sessions[sessionID]
+	if !ok || session.ResourceName != resourceName {
+		return nil, fmt.Errorf("%w: %s", ErrSessionNotFound, sessionID)
+	}
+	return session, nil
+}
+
+// releaseSessionJob frees the session once a job finished with it.
+func (qs *QueryService) releaseSessionJob(session *QuerySession) TxState {
+	qs.mu.Lock()
+	defer qs.mu.Unlock()
+	session.busy = false
+	session.ActiveJobID = ""
+	session.LastUsedAt = time.Now()
+	return session.TxState
+}
+
+// observeSessionStatement updates the session transaction state after a statement ran on its connection.
+func (qs *QueryService) observeSessionStatement(session *QuerySession, query string, err error) TxState {
+	qs.mu.RLock()
+	state := session.TxState
+	qs.mu.RUnlock()
+
+	state = nextTxState(state, query, err, session.dialect, session.conn)
+
+	qs.mu.Lock()
+	session.TxState = state
+	qs.mu.Unlock()
+	return state
+}
+
+// nextTxState infers the transaction state after a statement. Connections that can
+// report the driver's view of the transaction take precedence over the inference.
+func nextTxState(state TxState, query string, err error, dialect sqlutil.Dialect, conn PinnedConnection) TxState {
+	if reporter, ok := conn.(TransactionStateReporter); ok {
+		if reported, reportErr := reporter.TransactionState(); reportErr == nil {
+			return reported
+		}
+	}
+
+	if err != nil {
+		if state == TxStateInTransaction && dialect.AbortsTransactionOnError() {
+			return TxStateFailed
+		}
+		return state
+	}
+	switch sqlutil.ClassifyTransactionControl(query) {
+	case sqlutil.TransactionOpen:
+		return TxStateInTransaction
+	case sqlutil.TransactionClose:
+		return TxStateIdle
+	default:
+		return state
+	}
+}
+
+// watchSessions periodically rolls back sessions left idle inside a transaction and
+// closes sessions the client abandoned, so they do not keep pool connections pinned.
+func (qs *QueryService) watchSessions(done <-chan struct{}) {
+	ticker := time.NewTicker(sessionSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			qs.rollbackIdleSessions(now)
+			qs.expireIdleSessions(now)
+		}
+	}
+}
+
+func (qs *QueryService) rollbackIdleSessions(now time.Time) {
+	if qs.sessionIdleTxTimeout <= 0 {
+		return
+	}
+
+	var idle []*QuerySession
+	qs.mu.Lock()
+	for _, session := range qs.sessions {
+		if session.busy || session.TxState == TxStateIdle {
+			continue
+		}
+		if now.Sub(session.LastUsedAt) < qs.sessionIdleTxTimeout {
+			continue
+		}
+		// Hold the session so no job can start while the rollback runs.
+		session.busy = true
+		idle = append(idle, session)
+	}
+	qs.mu.Unlock()
+
+	for _, session := range idle {
+		ctx, cancel := context.WithTimeout(context.Background(), sessionCloseTimeout)
+		_, err := session.conn.ExecuteQuery(ctx, "ROLLBACK", nil, nil)
+		cancel()
+
+		state := TxStateIdle
+		if err != nil {
+			state = nextTxState(TxStateFailed, "", err, session.dialect, session.conn)
+			slog.Warn("failed to roll back idle session",
+				slog.String("sessionId", session.ID),
+				slog.String("resource", session.ResourceName),
+				slog.Any("err", err))
+		} else {
+			slog.Info("rolled back idle session transaction",
+				slog.String("sessionId", session.ID),
+				slog.String("resource", session.ResourceName))
+		}
+		qs.mu.Lock()
+		session.TxState = state
+		session.busy = false
+		session.LastUsedAt = time.Now()
+		qs.mu.Unlock()
+	}
+}
+
+// expireIdleSessions closes sessions left unused outside a transaction for longer than
+// the idle timeout and returns their connections to the pool.
+func (qs *QueryService) expireIdleSessions(now time.Time) {
+	if qs.sessionIdleTimeout <= 0 {
+		return
+	}
+
+	var expired []*QuerySession
+	qs.mu.Lock()
+	for id, session := range qs.sessions {
+		if session.busy || session.TxState != TxStateIdle {
+			continue
+		}
+		if now.Sub(session.LastUsedAt) < qs.sessionIdleTimeout {
+			continue
+		}
+		delete(qs.sessions, id)
+		expired = append(expired, session)
+	}
+	qs.mu.Unlock()
+
+	for _, session := range expired {
+		slog.Info("closing idle session",
+			slog.String("sessionId", session.ID),
+			slog.String("resource", session.ResourceName))
+		qs.releaseSession(context.Background(), session, TxStateIdle)
+	}
+}
+
+// releaseSession rolls back an open transaction and returns the connection to the pool.
+func (qs *QueryService) releaseSession(ctx context.Context, session *QuerySession, txState TxState) {
+	if txState != TxStateIdle {
+		rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sessionCloseTimeout)
+		if _, err := session.conn.ExecuteQuery(rollbackCtx, "ROLLBACK", nil, nil); err != nil {
+			slog.WarnContext(ctx, "failed to roll back session transaction",
+				slog.String("sessionId", session.ID),
+				slog.Any("err", err))
+		}
+		cancel()
+	}
+	if err := session.conn.Close(); err != nil {
+		slog.WarnContext(ctx, "failed to release session connection",
+			slog.String("sessionId", session.ID),
+			slog.Any("err", err))
+	}
+	slog.InfoContext(ctx, "query session closed",
+		slog.String("sessionId", session.ID),
+		slog.String("resource", session.ResourceName))
+}
+
+// closeAllSessions releases every session; used on shutdown.
+func (qs *QueryService) closeAllSessions() {
+	qs.mu.Lock()
+	sessions := make([]*QuerySession, 0, len(qs.sessions))
+	states := make([]TxState, 0, len(qs.sessions))
+	for id, session := range qs.sessions {
+		sessions = append(sessions, session)
+		states = append(states, session.TxState)
+		delete(qs.sessions, id)
+	}
+	qs.mu.Unlock()
+
+	for i, session := range sessions {
+		qs.releaseSession(context.Background(), session, states[i])
+	}
+}
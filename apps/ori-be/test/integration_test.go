@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	httpapi "github.com/crueladdict/ori/apps/ori-server/internal/httpapi"
 	duckdbadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/duckdb"
 	sqliteadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/sqlite"
+	"github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/storage/history"
 	"github.com/crueladdict/ori/apps/ori-server/internal/service"
 )
 
@@ -55,7 +58,7 @@ func TestListResourcesAndConnectSQLiteOverUDS(t *testing.T) {
 	connectionService := service.NewResourceSessionService(configService, eventHub)
 	connectionService.RegisterAdapter("sqlite", sqliteadapter.NewAdapter)
 	nodeService := service.NewNodeService(configService, connectionService)
-	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows)
+	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows, nil)
 	handler := httpapi.NewHandler(configService, connectionService, nodeService, queryService)
 
 	sockPath := unixSocketPath("ori-be")
@@ -157,6 +160,14 @@ func TestListResourcesAndConnectSQLiteOverUDS(t *testing.T) {
 	} else if edge.Items == nil {
 		t.Fatalf("expected triggers edge items")
 	}
+
+	privilegesResp, err := client.GetPrivilegesWithResponse(ctx, "local-sqlite", nil)
+	if err != nil {
+		t.Fatalf("getPrivileges failed: %v", err)
+	}
+	if privilegesResp.JSON400 == nil || privilegesResp.JSON400.Code != "privileges_unsupported" {
+		t.Fatalf("expected privileges_unsupported for sqlite, got status %d", privilegesResp.StatusCode())
+	}
 }
 
 func TestQueryExecAndGetResult(t *testing.T) {
@@ -192,7 +203,15 @@ func TestQueryExecAndGetResult(t *testing.T) {
 	connectionService := service.NewResourceSessionService(configService, eventHub)
 	connectionService.RegisterAdapter("sqlite", sqliteadapter.NewAdapter)
 	nodeService := service.NewNodeService(configService, connectionService)
-	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows)
+	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows, nil)
+	historyStore, err := history.Open(ctx, filepath.Join(tempRoot, "history.db"))
+	if err != nil {
+		t.Fatalf("failed to open history: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = historyStore.Close()
+	})
+	queryService.SetHistoryStore(historyStore)
 	handler := httpapi.NewHandler(configService, connectionService, nodeService, queryService)
 
 	sockPath := unixSocketPath("ori-be-query")
@@ -266,6 +285,252 @@ func TestQueryExecAndGetResult(t *testing.T) {
 		t.Fatalf("expected RowCount=10, got %d", paginated.RowCount)
 	}
 
+	var namedParams dto.QueryExecRequest_Params
+	if err := namedParams.FromQueryExecRequestParams0(dto.QueryExecRequestParams0{"id": 1}); err != nil {
+		t.Fatalf("failed to build named params: %v", err)
+	}
+	namedResp, err := client.ExecQueryWithResponse(ctx, dto.ExecQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		JobId:        uuid.New(),
+		Query:        "SELECT id, name FROM authors WHERE id = :id OR id = @id",
+		Params:       &namedParams,
+	})
+	if err != nil {
+		t.Fatalf("QueryExec (named params) failed: %v", err)
+	}
+	if namedResp.JSON202 == nil {
+		t.Fatalf("expected job id for named params query, got status %d", namedResp.StatusCode())
+	}
+	named := waitForQueryResult(t, ctx, client, namedResp.JSON202.JobId, nil, nil)
+	if named.RowCount != 1 {
+		t.Fatalf("expected 1 row for named params query, got %d", named.RowCount)
+	}
+
+	var unusedParams dto.QueryExecRequest_Params
+	if err := unusedParams.FromQueryExecRequestParams0(dto.QueryExecRequestParams0{"id": 1, "extra": 2}); err != nil {
+		t.Fatalf("failed to build named params: %v", err)
+	}
+	unusedResp, err := client.ExecQueryWithResponse(ctx, dto.ExecQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		JobId:        uuid.New(),
+		Query:        "SELECT id FROM authors WHERE id = :id",
+		Params:       &unusedParams,
+	})
+	if err != nil {
+		t.Fatalf("QueryExec (unused params) failed: %v", err)
+	}
+	if unusedResp.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unused params, got %d", unusedResp.StatusCode())
+	}
+
+	script := true
+	scriptReq := dto.ExecQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		JobId:        uuid.New(),
+		Query: `CREATE TEMP TABLE script_notes (body TEXT);
+INSERT INTO script_notes (body) VALUES ('a;b'), ('c');
+-- trailing select reads from the same connection
+SELECT body FROM script_notes ORDER BY body;`,
+		Options: &dto.QueryExecOptions{Script: &script},
+	}
+	scriptResp, err := client.ExecQueryWithResponse(ctx, scriptReq)
+	if err != nil {
+		t.Fatalf("QueryExec (script) failed: %v", err)
+	}
+	if scriptResp.JSON202 == nil {
+		t.Fatalf("expected job id for script, got status %d", scriptResp.StatusCode())
+	}
+	scriptResult := waitForQueryResult(t, ctx, client, scriptResp.JSON202.JobId, nil, nil)
+	if scriptResult.RowCount != 2 || scriptResult.Rows[0][0] != "a;b" {
+		t.Fatalf("unexpected script result: %#v", scriptResult)
+	}
+	insertIndex := 1
+	insertResp, err := client.GetQueryResultWithResponse(ctx, scriptResp.JSON202.JobId, &dto.GetQueryResultParams{Statement: &insertIndex})
+	if err != nil {
+		t.Fatalf("QueryGetResult (statement) failed: %v", err)
+	}
+	if insertResp.JSON200 == nil || insertResp.JSON200.RowsAffected == nil || *insertResp.JSON200.RowsAffected != 2 {
+		t.Fatalf("expected insert statement to affect 2 rows, got status %d", insertResp.StatusCode())
+	}
+	statusResp, err := client.GetQueryStatusWithResponse(ctx, scriptResp.JSON202.JobId)
+	if err != nil {
+		t.Fatalf("QueryGetStatus (script) failed: %v", err)
+	}
+	if statusResp.JSON200 == nil || statusResp.JSON200.Statements == nil || len(*statusResp.JSON200.Statements) != 3 {
+		t.Fatalf("expected 3 statement statuses for script job")
+	}
+
+	sessionResp, err := client.OpenQuerySessionWithResponse(ctx, "local-sqlite")
+	if err != nil {
+		t.Fatalf("OpenQuerySession failed: %v", err)
+	}
+	if sessionResp.JSON201 == nil || sessionResp.JSON201.TxState != dto.TxIdle {
+		t.Fatalf("expected idle session, got status %d", sessionResp.StatusCode())
+	}
+	sessionID, err := uuid.Parse(sessionResp.JSON201.SessionId)
+	if err != nil {
+		t.Fatalf("invalid session id: %v", err)
+	}
+	var sessionJobID string
+	for _, query := range []string{
+		"BEGIN",
+		"CREATE TEMP TABLE session_notes (body TEXT)",
+		"INSERT INTO session_notes (body) VALUES ('kept')",
+		"SELECT body FROM session_notes",
+	} {
+		resp, err := client.ExecQueryWithResponse(ctx, dto.ExecQueryJSONRequestBody{
+			ResourceName: "local-sqlite",
+			JobId:        uuid.New(),
+			Query:        query,
+			SessionId:    &sessionID,
+		})
+		if err != nil {
+			t.Fatalf("QueryExec (session) failed: %v", err)
+		}
+		if resp.JSON202 == nil {
+			t.Fatalf("expected job id for session query %q, got status %d", query, resp.StatusCode())
+		}
+		sessionJobID = resp.JSON202.JobId
+		waitForQueryResult(t, ctx, client, sessionJobID, nil, nil)
+	}
+	sessionResult := waitForQueryResult(t, ctx, client, sessionJobID, nil, nil)
+	if sessionResult.RowCount != 1 || sessionResult.Rows[0][0] != "kept" {
+		t.Fatalf("unexpected session result: %#v", sessionResult)
+	}
+	sessionStatus, err := client.GetQueryStatusWithResponse(ctx, sessionJobID)
+	if err != nil {
+		t.Fatalf("QueryGetStatus (session) failed: %v", err)
+	}
+	if sessionStatus.JSON200 == nil || sessionStatus.JSON200.TxState == nil || *sessionStatus.JSON200.TxState != dto.TxInTransaction {
+		t.Fatalf("expected session job to report an open transaction")
+	}
+	closeResp, err := client.CloseQuerySessionWithResponse(ctx, "local-sqlite", sessionID.String())
+	if err != nil {
+		t.Fatalf("CloseQuerySession failed: %v", err)
+	}
+	if closeResp.StatusCode() != http.StatusNoContent {
+		t.Fatalf("expected 204 when closing session, got %d", closeResp.StatusCode())
+	}
+	closedResp, err := client.GetQuerySessionWithResponse(ctx, "local-sqlite", sessionID.String())
+	if err != nil {
+		t.Fatalf("GetQuerySession failed: %v", err)
+	}
+	if closedResp.StatusCode() != http.StatusNotFound {
+		t.Fatalf("expected 404 for closed session, got %d", closedResp.StatusCode())
+	}
+
+	sortResp, err := client.ExecQueryWithResponse(ctx, dto.ExecQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		JobId:        uuid.New(),
+		Query:        "SELECT 1 AS id, 'b' AS name UNION ALL SELECT 2, 'a' UNION ALL SELECT 3, NULL",
+	})
+	if err != nil {
+		t.Fatalf("QueryExec (sort) failed: %v", err)
+	}
+	if sortResp.JSON202 == nil {
+		t.Fatalf("expected job id for sort query, got status %d", sortResp.StatusCode())
+	}
+	waitForQueryResult(t, ctx, client, sortResp.JSON202.JobId, nil, nil)
+	sortedResp, err := client.GetQueryResultWithResponse(ctx, sortResp.JSON202.JobId, &dto.GetQueryResultParams{
+		Sort:   &[]string{"name:desc"},
+		Filter: &[]string{"name:notnull"},
+	})
+	if err != nil {
+		t.Fatalf("QueryGetResult (sorted) failed: %v", err)
+	}
+	if sortedResp.JSON200 == nil || sortedResp.JSON200.RowCount != 2 {
+		t.Fatalf("expected 2 filtered rows, got status %d", sortedResp.StatusCode())
+	}
+	if got := fmt.Sprintf("%v %v", sortedResp.JSON200.Rows[0][1], sortedResp.JSON200.Rows[1][1]); got != "b a" {
+		t.Fatalf("expected rows sorted by name descending, got %q", got)
+	}
+	badSortResp, err := client.GetQueryResultWithResponse(ctx, sortResp.JSON202.JobId, &dto.GetQueryResultParams{
+		Sort: &[]string{"missing"},
+	})
+	if err != nil {
+		t.Fatalf("QueryGetResult (bad sort) failed: %v", err)
+	}
+	if badSortResp.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown sort column, got %d", badSortResp.StatusCode())
+	}
+
+	exportResp, err := client.ExportQueryResultWithResponse(ctx, sortResp.JSON202.JobId, &dto.ExportQueryResultParams{
+		Format: dto.ExportCsv,
+	})
+	if err != nil {
+		t.Fatalf("ExportQueryResult failed: %v", err)
+	}
+	if exportResp.StatusCode() != http.StatusOK || string(exportResp.Body) != "id,name\n1,b\n2,a\n3,\n" {
+		t.Fatalf("unexpected csv export (status %d): %q", exportResp.StatusCode(), exportResp.Body)
+	}
+
+	explainResp, err := client.ExplainQueryWithResponse(ctx, dto.ExplainQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		Query:        "SELECT title FROM books WHERE author_id = 1",
+	})
+	if err != nil {
+		t.Fatalf("ExplainQuery failed: %v", err)
+	}
+	if explainResp.JSON200 == nil || len(explainResp.JSON200.Nodes) == 0 {
+		t.Fatalf("expected sqlite plan nodes, got status %d", explainResp.StatusCode())
+	}
+	if scan := explainResp.JSON200.Nodes[0]; scan.Relation == nil || *scan.Relation != "books" {
+		t.Fatalf("expected plan to read books, got %#v", scan)
+	}
+	analyze := true
+	analyzeResp, err := client.ExplainQueryWithResponse(ctx, dto.ExplainQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		Query:        "SELECT 1",
+		Analyze:      &analyze,
+	})
+	if err != nil {
+		t.Fatalf("ExplainQuery (analyze) failed: %v", err)
+	}
+	if analyzeResp.StatusCode() != http.StatusBadRequest {
+		t.Fatalf("expected 400 for sqlite explain analyze, got %d", analyzeResp.StatusCode())
+	}
+
+	lazy := true
+	fetchRows := 10
+	lazyResp, err := client.ExecQueryWithResponse(ctx, dto.ExecQueryJSONRequestBody{
+		ResourceName: "local-sqlite",
+		JobId:        uuid.New(),
+		Query:        "WITH RECURSIVE n(x) AS (SELECT 1 UNION SELECT x + 1 FROM n WHERE x < 50) SELECT x FROM n",
+		Options:      &dto.QueryExecOptions{Lazy: &lazy, FetchRows: &fetchRows},
+	})
+	if err != nil {
+		t.Fatalf("QueryExec (lazy) failed: %v", err)
+	}
+	if lazyResp.JSON202 == nil {
+		t.Fatalf("expected job id for lazy query, got status %d", lazyResp.StatusCode())
+	}
+	pageLimit := 5
+	lazyResult := waitForQueryResult(t, ctx, client, lazyResp.JSON202.JobId, &pageLimit, nil)
+	if lazyResult.RowCount != 10 || lazyResult.HasMore == nil || !*lazyResult.HasMore {
+		t.Fatalf("expected first lazy page to buffer 10 rows, got %#v", lazyResult)
+	}
+	pageLimit, pageOffset := 10, 45
+	lazyResult = waitForQueryResult(t, ctx, client, lazyResp.JSON202.JobId, &pageLimit, &pageOffset)
+	if lazyResult.RowCount != 50 || lazyResult.HasMore != nil || len(lazyResult.Rows) != 5 || lazyResult.Rows[4][0] != float64(50) {
+		t.Fatalf("expected last lazy page to drain the cursor, got %#v", lazyResult)
+	}
+
+	search := "union all"
+	historyResp, err := client.GetQueryHistoryWithResponse(ctx, &dto.GetQueryHistoryParams{
+		Q:        &search,
+		Resource: &execReq.ResourceName,
+		Status:   &[]dto.QueryHistoryStatus{dto.HistorySuccess},
+	})
+	if err != nil {
+		t.Fatalf("GetQueryHistory failed: %v", err)
+	}
+	if historyResp.JSON200 == nil || historyResp.JSON200.Total != 1 {
+		t.Fatalf("expected one history entry, got status %d: %s", historyResp.StatusCode(), historyResp.Body)
+	}
+	if entry := historyResp.JSON200.Entries[0]; entry.JobId != sortResp.JSON202.JobId || entry.RowCount == nil || *entry.RowCount != 3 {
+		t.Fatalf("unexpected history entry %#v", entry)
+	}
+
 	badResp, err := client.GetQueryResultWithResponse(ctx, "invalid-job-id", nil)
 	if err != nil {
 		t.Fatalf("QueryGetResult invalid job request failed: %v", err)
@@ -307,7 +572,8 @@ func TestDuckDBIntrospectionAndCSVQuery(t *testing.T) {
 	connectionService := service.NewResourceSessionService(configService, eventHub)
 	connectionService.RegisterAdapter("duckdb", duckdbadapter.NewAdapter)
 	nodeService := service.NewNodeService(configService, connectionService)
-	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows)
+	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows, nil)
+	queryService.RegisterExporter(service.ExportParquet, duckdbadapter.ParquetExporter{})
 	handler := httpapi.NewHandler(configService, connectionService, nodeService, queryService)
 
 	sockPath := unixSocketPath("ori-be-duckdb")
@@ -335,6 +601,15 @@ func TestDuckDBIntrospectionAndCSVQuery(t *testing.T) {
 		time.Sleep(100 * time.Millisecond)
 	}
 
+	sessionResp, err := client.OpenQuerySessionWithResponse(ctx, "local-duckdb")
+	if err != nil {
+		t.Fatalf("open session failed: %v", err)
+	}
+	if sessionResp.StatusCode() != http.StatusBadRequest || sessionResp.JSON400 == nil ||
+		sessionResp.JSON400.Code != "sessions_unsupported" {
+		t.Fatalf("expected sessions_unsupported on duckdb, got status %d", sessionResp.StatusCode())
+	}
+
 	rootResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", nil)
 	if err != nil {
 		t.Fatalf("getNodes root failed: %v", err)
@@ -376,6 +651,43 @@ func TestDuckDBIntrospectionAndCSVQuery(t *testing.T) {
 	if !ok || len(viewsEdge.Items) == 0 {
 		t.Fatalf("expected views edge on analytics schema")
 	}
+	functionsEdge, ok := hydratedSchema.Edges["functions"]
+	if !ok || len(functionsEdge.Items) != 1 {
+		t.Fatalf("expected book_label macro in functions edge on analytics schema")
+	}
+	functionIDs := append([]string(nil), functionsEdge.Items...)
+	functionResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", &dto.GetNodesParams{NodeId: &functionIDs})
+	if err != nil {
+		t.Fatalf("getNodes function failed: %v", err)
+	}
+	if functionResp.JSON200 == nil || len(functionResp.JSON200.Nodes) != 1 {
+		t.Fatalf("expected single function node")
+	}
+	bookLabel := mustFunctionNode(t, functionResp.JSON200.Nodes[0])
+	if bookLabel.Attributes.Signature != "book_label(title_value, price_value)" || bookLabel.Attributes.Definition == nil {
+		t.Fatalf("unexpected book_label function attributes: %+v", bookLabel.Attributes)
+	}
+	sequencesEdge, ok := hydratedSchema.Edges["sequences"]
+	if !ok || len(sequencesEdge.Items) != 1 {
+		t.Fatalf("expected event_seq in sequences edge on analytics schema")
+	}
+	sequenceIDs := append([]string(nil), sequencesEdge.Items...)
+	sequenceResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", &dto.GetNodesParams{NodeId: &sequenceIDs})
+	if err != nil {
+		t.Fatalf("getNodes sequence failed: %v", err)
+	}
+	if sequenceResp.JSON200 == nil || len(sequenceResp.JSON200.Nodes) != 1 {
+		t.Fatalf("expected single sequence node")
+	}
+	eventSeq, err := sequenceResp.JSON200.Nodes[0].AsSequenceNode()
+	if err != nil {
+		t.Fatalf("failed to decode sequence node: %v", err)
+	}
+	if eventSeq.Attributes.Increment != 5 || eventSeq.Attributes.CurrentValue == nil ||
+		eventSeq.Attributes.OwnedByTable == nil || *eventSeq.Attributes.OwnedByTable != "book_editions" ||
+		eventSeq.Attributes.OwnedByColumn == nil || *eventSeq.Attributes.OwnedByColumn != "seq_value" {
+		t.Fatalf("unexpected event_seq attributes: %+v", eventSeq.Attributes)
+	}
 
 	tableIDs := append([]string(nil), tablesEdge.Items...)
 	tableParams := &dto.GetNodesParams{NodeId: &tableIDs}
@@ -424,6 +736,81 @@ func TestDuckDBIntrospectionAndCSVQuery(t *testing.T) {
 		t.Fatalf("expected backing indexes on book_editions table")
 	}
 
+	// authors.status is declared with the mood enum of the main schema.
+	var mainSchema dto.SchemaNode
+	for _, node := range rootResp.JSON200.Nodes {
+		if schemaNode := mustSchemaNode(t, node); schemaNode.Name == "main" {
+			mainSchema = schemaNode
+		}
+	}
+	mainIDs := []string{mainSchema.Id}
+	mainResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", &dto.GetNodesParams{NodeId: &mainIDs})
+	if err != nil || mainResp.JSON200 == nil || len(mainResp.JSON200.Nodes) != 1 {
+		t.Fatalf("expected hydrated main schema node, err %v", err)
+	}
+	hydratedMain := mustSchemaNode(t, mainResp.JSON200.Nodes[0])
+	extensionIDs := append([]string(nil), hydratedMain.Edges["extensions"].Items...)
+	extensionResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", &dto.GetNodesParams{NodeId: &extensionIDs})
+	if err != nil || extensionResp.JSON200 == nil {
+		t.Fatalf("getNodes extensions failed: %v", err)
+	}
+	loadedExtensions := map[string]bool{}
+	for _, node := range extensionResp.JSON200.Nodes {
+		extension, err := node.AsExtensionNode()
+		if err != nil {
+			t.Fatalf("failed to decode extension node: %v", err)
+		}
+		loadedExtensions[extension.Attributes.ExtensionName] = extension.Attributes.Loaded != nil && *extension.Attributes.Loaded
+	}
+	if !loadedExtensions["json"] || !loadedExtensions["parquet"] {
+		t.Fatalf("expected json and parquet among loaded extensions, got %v", loadedExtensions)
+	}
+	if edge := hydratedSchema.Edges["extensions"]; len(edge.Items) != 0 {
+		t.Fatalf("expected extensions only under the default schema, got %v", edge.Items)
+	}
+	typesEdge, ok := hydratedMain.Edges["types"]
+	if !ok || len(typesEdge.Items) != 1 {
+		t.Fatalf("expected mood in types edge on main schema")
+	}
+	typeIDs := append([]string(nil), typesEdge.Items...)
+	typeResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", &dto.GetNodesParams{NodeId: &typeIDs})
+	if err != nil || typeResp.JSON200 == nil || len(typeResp.JSON200.Nodes) != 1 {
+		t.Fatalf("expected single type node, err %v", err)
+	}
+	mood, err := typeResp.JSON200.Nodes[0].AsTypeNode()
+	if err != nil {
+		t.Fatalf("failed to decode type node: %v", err)
+	}
+	if mood.Attributes.Kind != dto.TypeKindEnum || mood.Attributes.Labels == nil ||
+		!reflect.DeepEqual(*mood.Attributes.Labels, []string{"sad", "ok", "happy"}) {
+		t.Fatalf("unexpected mood type attributes: %+v", mood.Attributes)
+	}
+
+	var authorsTable dto.TableNode
+	for _, node := range tableResp.JSON200.Nodes {
+		if tableNode := mustTableNode(t, node); tableNode.Name == "authors" {
+			authorsTable = tableNode
+		}
+	}
+	columnIDs := append([]string(nil), authorsTable.Edges["columns"].Items...)
+	columnResp, err := client.GetNodesWithResponse(ctx, "local-duckdb", &dto.GetNodesParams{NodeId: &columnIDs})
+	if err != nil || columnResp.JSON200 == nil {
+		t.Fatalf("getNodes authors columns failed: %v", err)
+	}
+	var statusColumn dto.ColumnNode
+	for _, node := range columnResp.JSON200.Nodes {
+		column, err := node.AsColumnNode()
+		if err != nil {
+			t.Fatalf("failed to decode column node: %v", err)
+		}
+		if column.Name == "status" {
+			statusColumn = column
+		}
+	}
+	if edge, ok := statusColumn.Edges["type"]; !ok || len(edge.Items) != 1 || edge.Items[0] != mood.Id {
+		t.Fatalf("expected status column to link to mood type %q, got %+v", mood.Id, statusColumn.Edges)
+	}
+
 	csvPath := filepath.Join(tempRoot, "people.csv")
 	csvContent := "id,name\n1,Ada\n2,Grace\n"
 	if err := os.WriteFile(csvPath, []byte(csvContent), 0o644); err != nil {
@@ -453,6 +840,30 @@ func TestDuckDBIntrospectionAndCSVQuery(t *testing.T) {
 	if got := fmt.Sprint(result.Rows[0][1]); got != "Ada" {
 		t.Fatalf("expected first csv row to contain Ada, got %q", got)
 	}
+	if id, ok := result.Rows[0][0].(float64); !ok || id != 1 {
+		t.Fatalf("expected csv id as a JSON number, got %#v", result.Rows[0][0])
+	}
+
+	// DuckDB has a single connection, so lazy results are drained up front
+	// rather than holding it open for the queries below.
+	lazy := true
+	fetchRows := 1
+	lazyResp, err := client.ExecQueryWithResponse(ctx, dto.ExecQueryJSONRequestBody{
+		ResourceName: "local-duckdb",
+		JobId:        uuid.New(),
+		Query:        execReq.Query,
+		Options:      &dto.QueryExecOptions{Lazy: &lazy, FetchRows: &fetchRows},
+	})
+	if err != nil {
+		t.Fatalf("DuckDB lazy query exec failed: %v", err)
+	}
+	if lazyResp.JSON202 == nil {
+		t.Fatalf("expected job id from DuckDB lazy query, got status %d", lazyResp.StatusCode())
+	}
+	lazyResult := waitForQueryResult(t, ctx, client, lazyResp.JSON202.JobId, nil, nil)
+	if lazyResult.RowCount != 2 || lazyResult.HasMore != nil {
+		t.Fatalf("expected DuckDB lazy result to be drained, got %#v", lazyResult)
+	}
 
 	jsonReq := dto.ExecQueryJSONRequestBody{
 		ResourceName: "local-duckdb",
@@ -468,11 +879,57 @@ func TestDuckDBIntrospectionAndCSVQuery(t *testing.T) {
 	}
 
 	jsonResult := waitForQueryResult(t, ctx, client, jsonResp.JSON202.JobId, nil, nil)
-	if got := fmt.Sprint(jsonResult.Rows[0][0]); got != `{"awards":["Royal Society"]}` {
-		t.Fatalf("expected JSON string result, got %q", got)
+	wantProfile := map[string]any{"$type": "json", "value": map[string]any{"awards": []any{"Royal Society"}}}
+	if !reflect.DeepEqual(jsonResult.Rows[0][0], wantProfile) {
+		t.Fatalf("expected tagged JSON result, got %#v", jsonResult.Rows[0][0])
+	}
+	wantRating := map[string]any{"$type": "decimal", "value": "9.75"}
+	if !reflect.DeepEqual(jsonResult.Rows[0][1], wantRating) {
+		t.Fatalf("expected tagged decimal result, got %#v", jsonResult.Rows[0][1])
+	}
+
+	parquetPath := filepath.Join(tempRoot, "authors.parquet")
+	exportFileReq := dto.ExportQueryResultToFileJSONRequestBody{Format: dto.ExportParquet, Path: parquetPath}
+	exportFileResp, err := client.ExportQueryResultToFileWithResponse(ctx, jsonResp.JSON202.JobId, exportFileReq)
+	if err != nil {
+		t.Fatalf("DuckDB parquet export failed: %v", err)
+	}
+	if exportFileResp.JSON201 == nil || exportFileResp.JSON201.RowCount != 1 {
+		t.Fatalf("expected parquet export of 1 row, got status %d: %s", exportFileResp.StatusCode(), exportFileResp.Body)
 	}
-	if got := fmt.Sprint(jsonResult.Rows[0][1]); got != "9.75" {
-		t.Fatalf("expected decimal string result, got %q", got)
+	parquet, err := os.ReadFile(parquetPath)
+	if err != nil {
+		t.Fatalf("failed to read parquet export: %v", err)
+	}
+	if !bytes.HasPrefix(parquet, []byte("PAR1")) || int64(len(parquet)) != exportFileResp.JSON201.Bytes {
+		t.Fatalf("expected a parquet file of %d bytes", exportFileResp.JSON201.Bytes)
+	}
+	existingResp, err := client.ExportQueryResultToFileWithResponse(ctx, jsonResp.JSON202.JobId, exportFileReq)
+	if err != nil {
+		t.Fatalf("DuckDB parquet export (existing file) failed: %v", err)
+	}
+	if existingResp.StatusCode() != http.StatusConflict {
+		t.Fatalf("expected 409 when the export file exists, got %d", existingResp.StatusCode())
+	}
+
+	analyze := true
+	explainResp, err := client.ExplainQueryWithResponse(ctx, dto.ExplainQueryJSONRequestBody{
+		ResourceName: "local-duckdb",
+		Query:        "SELECT name FROM analytics.authors WHERE id > 1",
+		Analyze:      &analyze,
+	})
+	if err != nil {
+		t.Fatalf("DuckDB explain failed: %v", err)
+	}
+	if explainResp.JSON200 == nil || !explainResp.JSON200.Analyzed || len(explainResp.JSON200.Nodes) != 1 {
+		t.Fatalf("expected analyzed DuckDB plan, got status %d", explainResp.StatusCode())
+	}
+	scan := explainResp.JSON200.Nodes[0]
+	for len(scan.Children) > 0 {
+		scan = scan.Children[0]
+	}
+	if scan.Relation == nil || *scan.Relation != "authors" || scan.ActualRows == nil {
+		t.Fatalf("expected analyzed scan of authors, got %#v", scan)
 	}
 }
 
@@ -485,7 +942,7 @@ func waitForQueryResult(t *testing.T, ctx context.Context, client *dto.ClientWit
 		if err != nil {
 			t.Fatalf("QueryGetResult failed: %v", err)
 		}
-		if resp.JSON200 != nil {
+		if resp.JSON200 != nil && (resp.JSON200.Partial == nil || !*resp.JSON200.Partial) {
 			return resp.JSON200
 		}
 		time.Sleep(100 * time.Millisecond)
@@ -526,6 +983,22 @@ func mustSchemaNode(t *testing.T, node dto.Node) dto.SchemaNode {
 	return schemaNode
 }
 
+func mustFunctionNode(t *testing.T, node dto.Node) dto.FunctionNode {
+	t.Helper()
+	discriminator, err := node.Discriminator()
+	if err != nil {
+		t.Fatalf("failed to read node discriminator: %v", err)
+	}
+	if discriminator != string(dto.Function) {
+		t.Fatalf("expected function node discriminator, got %q", discriminator)
+	}
+	functionNode, err := node.AsFunctionNode()
+	if err != nil {
+		t.Fatalf("failed to decode function node: %v", err)
+	}
+	return functionNode
+}
+
 func mustTableNode(t *testing.T, node dto.Node) dto.TableNode {
 	t.Helper()
 	discriminator, err := node.Discriminator()
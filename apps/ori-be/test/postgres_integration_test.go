@@ -0,0 +1,271 @@
+package server_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	dto "github.com/crueladdict/ori/libs/contract/go"
+
+	"github.com/crueladdict/ori/apps/ori-server/internal/events"
+	httpapi "github.com/crueladdict/ori/apps/ori-server/internal/httpapi"
+	postgresadapter "github.com/crueladdict/ori/apps/ori-server/internal/infrastructure/database/postgres"
+	"github.com/crueladdict/ori/apps/ori-server/internal/service"
+)
+
+// postgresTestAddress is where testdata/docker-compose.yaml publishes the test server.
+const postgresTestAddress = "localhost:5433"
+
+func TestPostgresExtensions(t *testing.T) {
+	ctx := context.Background()
+	client := newPostgresClient(t, ctx, "local-postgres")
+
+	schemas := postgresSchemas(t, ctx, client, "local-postgres")
+	public := hydratePostgresSchema(t, ctx, client, "local-postgres", schemas["public"])
+	extensionSchemas := map[string]string{}
+	for _, node := range getPostgresNodes(t, ctx, client, "local-postgres", public.Edges["extensions"].Items) {
+		extension, err := node.AsExtensionNode()
+		if err != nil {
+			t.Fatalf("failed to decode extension node: %v", err)
+		}
+		if extension.Attributes.Schema != nil {
+			extensionSchemas[extension.Attributes.ExtensionName] = *extension.Attributes.Schema
+		}
+	}
+	for name, schema := range map[string]string{"plpgsql": "pg_catalog", "postgres_fdw": "public", "pg_trgm": "public"} {
+		if extensionSchemas[name] != schema {
+			t.Fatalf("extension %s schema = %q, want %q (got %v)", name, extensionSchemas[name], schema, extensionSchemas)
+		}
+	}
+
+	archive := hydratePostgresSchema(t, ctx, client, "local-postgres", schemas["archive"])
+	if edge := archive.Edges["extensions"]; len(edge.Items) != 0 {
+		t.Fatalf("expected extensions only on the default schema, archive lists %v", edge.Items)
+	}
+}
+
+func TestPostgresExtensionsWithoutCurrentSchema(t *testing.T) {
+	ctx := context.Background()
+	// The role's search_path names only a missing schema, so current_schema() is NULL.
+	const resource = "pg-missing-search-path"
+	client := newPostgresClient(t, ctx, resource)
+
+	schemas := postgresSchemas(t, ctx, client, resource)
+	for name, schema := range schemas {
+		if schema.Attributes.IsDefault {
+			t.Fatalf("expected no default schema, %s is marked default", name)
+		}
+	}
+	public := hydratePostgresSchema(t, ctx, client, resource, schemas["public"])
+	names := map[string]bool{}
+	for _, node := range getPostgresNodes(t, ctx, client, resource, public.Edges["extensions"].Items) {
+		extension, err := node.AsExtensionNode()
+		if err != nil {
+			t.Fatalf("failed to decode extension node: %v", err)
+		}
+		names[extension.Attributes.ExtensionName] = true
+	}
+	if !names["plpgsql"] || !names["pg_trgm"] {
+		t.Fatalf("expected public to list every extension, got %v", names)
+	}
+
+	archive := hydratePostgresSchema(t, ctx, client, resource, schemas["archive"])
+	if edge := archive.Edges["extensions"]; len(edge.Items) != 0 {
+		t.Fatalf("expected extensions only on public, archive lists %v", edge.Items)
+	}
+}
+
+func TestPostgresMatviewsForeignTablesAndPartitions(t *testing.T) {
+	ctx := context.Background()
+	client := newPostgresClient(t, ctx, "local-postgres")
+	public := hydratePostgresSchema(t, ctx, client, "local-postgres", postgresSchemas(t, ctx, client, "local-postgres")["public"])
+
+	populated := map[string]bool{}
+	for _, node := range getPostgresNodes(t, ctx, client, "local-postgres", public.Edges["materialized_views"].Items) {
+		matview, err := node.AsMatviewNode()
+		if err != nil {
+			t.Fatalf("failed to decode matview node: %v", err)
+		}
+		populated[matview.Name] = matview.Attributes.Populated
+	}
+	if want := map[string]bool{"author_book_counts": true, "pending_book_titles": false}; !reflect.DeepEqual(populated, want) {
+		t.Fatalf("matviews populated = %v, want %v", populated, want)
+	}
+
+	foreignTables := getPostgresNodes(t, ctx, client, "local-postgres", public.Edges["foreign_tables"].Items)
+	if len(foreignTables) != 1 {
+		t.Fatalf("expected remote_authors as the only foreign table, got %d", len(foreignTables))
+	}
+	remoteAuthors, err := foreignTables[0].AsForeignTableNode()
+	if err != nil {
+		t.Fatalf("failed to decode foreign table node: %v", err)
+	}
+	if remoteAuthors.Name != "remote_authors" || remoteAuthors.Attributes.Server != "loopback" || remoteAuthors.Attributes.Options == nil ||
+		!reflect.DeepEqual(*remoteAuthors.Attributes.Options, []string{"schema_name=public", "table_name=authors"}) {
+		t.Fatalf("unexpected remote_authors: %+v", remoteAuthors.Attributes)
+	}
+
+	var events dto.TableNode
+	for _, node := range getPostgresNodes(t, ctx, client, "local-postgres", public.Edges["tables"].Items) {
+		if table := mustTableNode(t, node); table.Name == "events" {
+			events = table
+		}
+	}
+	if events.Attributes.PartitionStrategy == nil || *events.Attributes.PartitionStrategy != dto.PartitionRange ||
+		events.Attributes.PartitionKey == nil || *events.Attributes.PartitionKey != "happened_on" {
+		t.Fatalf("unexpected events partitioning: %+v", events.Attributes)
+	}
+	bounds := map[string]string{}
+	for _, node := range getPostgresNodes(t, ctx, client, "local-postgres", events.Edges["partitions"].Items) {
+		partition := mustTableNode(t, node)
+		if partition.Attributes.PartitionBound != nil {
+			bounds[partition.Name] = *partition.Attributes.PartitionBound
+		}
+	}
+	wantBounds := map[string]string{
+		"events_2024": "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')",
+		"events_2025": "FOR VALUES FROM ('2025-01-01') TO ('2026-01-01')",
+	}
+	if !reflect.DeepEqual(bounds, wantBounds) {
+		t.Fatalf("partition bounds = %v, want %v", bounds, wantBounds)
+	}
+}
+
+func TestPostgresRolePrivileges(t *testing.T) {
+	ctx := context.Background()
+	client := newPostgresClient(t, ctx, "local-postgres")
+
+	role, schema := "kc_pg_user", "public"
+	resp, err := client.GetPrivilegesWithResponse(ctx, "local-postgres", &dto.GetPrivilegesParams{Role: &role, Schema: &schema})
+	if err != nil || resp.JSON200 == nil {
+		t.Fatalf("getPrivileges failed: %v (status %d)", err, resp.StatusCode())
+	}
+	if resp.JSON200.Role != role || resp.JSON200.Superuser {
+		t.Fatalf("unexpected role summary: %+v", resp.JSON200)
+	}
+	granted := map[string][]string{}
+	for _, object := range resp.JSON200.Objects {
+		if object.Schema != schema {
+			t.Fatalf("schema filter let through %s.%s", object.Schema, object.Name)
+		}
+		for _, grant := range object.Privileges {
+			key := string(object.ObjectType) + " " + object.Name
+			granted[key] = append(granted[key], grant.Privilege)
+		}
+	}
+	if got := granted["schema public"]; !reflect.DeepEqual(got, []string{"USAGE"}) {
+		t.Fatalf("public schema privileges = %v, want [USAGE]", got)
+	}
+	if got := granted["table authors"]; !reflect.DeepEqual(got, []string{"SELECT", "INSERT", "UPDATE", "DELETE"}) {
+		t.Fatalf("authors privileges = %v, want the granted SELECT, INSERT, UPDATE, DELETE", got)
+	}
+
+	unknown := "no_such_role"
+	missing, err := client.GetPrivilegesWithResponse(ctx, "local-postgres", &dto.GetPrivilegesParams{Role: &unknown})
+	if err != nil {
+		t.Fatalf("getPrivileges for unknown role failed: %v", err)
+	}
+	if missing.StatusCode() != http.StatusNotFound || missing.JSON404 == nil || missing.JSON404.Code != "role_not_found" {
+		t.Fatalf("expected role_not_found for an unknown role, got status %d", missing.StatusCode())
+	}
+}
+
+// newPostgresClient serves the fixture resources over a unix socket and connects the
+// named postgres resource. The test is skipped when the docker-compose server is not running.
+func newPostgresClient(t *testing.T, ctx context.Context, resource string) *dto.ClientWithResponses {
+	t.Helper()
+	conn, err := net.DialTimeout("tcp", postgresTestAddress, time.Second)
+	if err != nil {
+		t.Skipf("postgres test server not reachable at %s; start testdata/docker-compose.yaml", postgresTestAddress)
+	}
+	_ = conn.Close()
+
+	configPath, err := filepath.Abs("../../../testdata/resources.json")
+	if err != nil {
+		t.Fatalf("Failed to resolve test config path: %v", err)
+	}
+	configService := service.NewResourceCatalogService(configPath)
+	if err := configService.LoadResources(); err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+
+	eventHub := events.NewHub()
+	connectionService := service.NewResourceSessionService(configService, eventHub)
+	connectionService.RegisterAdapter("postgresql", postgresadapter.NewAdapter)
+	nodeService := service.NewNodeService(configService, connectionService)
+	queryService := service.NewQueryService(connectionService, eventHub, ctx, service.DefaultMaxMaterializedRows, nil)
+	t.Cleanup(queryService.Stop)
+	handler := httpapi.NewHandler(configService, connectionService, nodeService, queryService)
+
+	sockPath := unixSocketPath("ori-be-postgres")
+	_ = os.Remove(sockPath)
+	srv, err := httpapi.NewUnixServer(ctx, handler, eventHub, sockPath)
+	if err != nil {
+		t.Fatalf("Failed to create unix server: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = srv.Shutdown()
+	})
+
+	client := newContractClient(t, sockPath)
+	connectReq := dto.ConnectResourceJSONRequestBody{ResourceName: resource}
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := client.ConnectResourceWithResponse(ctx, connectReq)
+		if err != nil {
+			t.Fatalf("Connect failed: %v", err)
+		}
+		if resp.JSON201 != nil && resp.JSON201.Result == dto.Success {
+			return client
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("%s did not connect", resource)
+	return nil
+}
+
+// postgresSchemas returns the root schema nodes of a postgres resource by name.
+func postgresSchemas(t *testing.T, ctx context.Context, client *dto.ClientWithResponses, resource string) map[string]dto.SchemaNode {
+	t.Helper()
+	rootResp, err := client.GetNodesWithResponse(ctx, resource, nil)
+	if err != nil || rootResp.JSON200 == nil {
+		t.Fatalf("getNodes root failed: %v", err)
+	}
+	schemas := map[string]dto.SchemaNode{}
+	for _, node := range rootResp.JSON200.Nodes {
+		schema := mustSchemaNode(t, node)
+		schemas[schema.Name] = schema
+	}
+	return schemas
+}
+
+func getPostgresNodes(t *testing.T, ctx context.Context, client *dto.ClientWithResponses, resource string, ids []string) []dto.Node {
+	t.Helper()
+	if len(ids) == 0 {
+		return nil
+	}
+	ids = append([]string(nil), ids...)
+	resp, err := client.GetNodesWithResponse(ctx, resource, &dto.GetNodesParams{NodeId: &ids})
+	if err != nil || resp.JSON200 == nil {
+		t.Fatalf("getNodes %v failed: %v", ids, err)
+	}
+	return resp.JSON200.Nodes
+}
+
+func hydratePostgresSchema(t *testing.T, ctx context.Context, client *dto.ClientWithResponses, resource string, schema dto.SchemaNode) dto.SchemaNode {
+	t.Helper()
+	if schema.Id == "" {
+		t.Fatalf("schema missing from root nodes")
+	}
+	ids := []string{schema.Id}
+	resp, err := client.GetNodesWithResponse(ctx, resource, &dto.GetNodesParams{NodeId: &ids})
+	if err != nil || resp.JSON200 == nil || len(resp.JSON200.Nodes) != 1 {
+		t.Fatalf("getNodes schema %s failed: %v", schema.Name, err)
+	}
+	return mustSchemaNode(t, resp.JSON200.Nodes[0])
+}
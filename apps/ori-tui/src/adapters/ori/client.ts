@@ -25,7 +25,7 @@ export type ResourceConnectResult = {
   userMessage?: string
 }
 
-export type { Node, NodeEdge } from "contract"
+export type { Node, NodeEdge, Privilege } from "contract"
 
 export type QueryExecOptions = ContractQueryExecOptions
 
@@ -34,10 +34,17 @@ export const NodeType = {
   SCHEMA: "schema",
   TABLE: "table",
   VIEW: "view",
+  MATVIEW: "matview",
+  FOREIGN_TABLE: "foreign_table",
   COLUMN: "column",
   CONSTRAINT: "constraint",
   INDEX: "index",
   TRIGGER: "trigger",
+  FUNCTION: "function",
+  PROCEDURE: "procedure",
+  SEQUENCE: "sequence",
+  TYPE: "type",
+  EXTENSION: "extension",
 } as const
 
 export type QueryExecResult = {
@@ -57,6 +64,7 @@ export type QueryResultView = {
   rowCount: number
   truncated: boolean
   rowsAffected?: number | null
+  commandTag?: string
 }
 
 export type QueryJobStatusView = {
@@ -211,6 +219,7 @@ export class RestOriClient implements OriClient {
       rowCount: payload.rowCount,
       truncated: payload.truncated,
       rowsAffected: payload.rowsAffected ?? undefined,
+      commandTag: payload.commandTag,
     }
   }
 
@@ -32,10 +32,47 @@ export type QueryJobCompletedEvent = {
   id?: string
 }
 
-export type ServerEvent = ConnectionStateEvent | QueryJobCompletedEvent
+export type QueryJobProgressPayload = {
+  jobId: string
+  resourceName: string
+  statement?: number
+  rowsFetched: number
+  bytesMaterialized: number
+  elapsedMs: number
+}
+
+export type QueryJobProgressEvent = {
+  type: "query.job.progress"
+  payload: QueryJobProgressPayload
+  id?: string
+}
+
+export type QueryJobNotice = {
+  statement?: number
+  severity: string
+  code: string
+  message: string
+}
+
+export type QueryJobNoticePayload = {
+  jobId: string
+  resourceName: string
+  notices: QueryJobNotice[]
+  droppedNotices?: number
+}
+
+export type QueryJobNoticeEvent = {
+  type: "query.job.notice"
+  payload: QueryJobNoticePayload
+  id?: string
+}
+
+export type ServerEvent = ConnectionStateEvent | QueryJobCompletedEvent | QueryJobProgressEvent | QueryJobNoticeEvent
 
 export const CONNECTION_STATE_EVENT = "connection.state" as const
 export const QUERY_JOB_COMPLETED_EVENT = "query.job.completed" as const
+export const QUERY_JOB_PROGRESS_EVENT = "query.job.progress" as const
+export const QUERY_JOB_NOTICE_EVENT = "query.job.notice" as const
 
 export function decodeServerEvent(message: SSEMessage): ServerEvent | null {
   if (!message.data) {
@@ -60,5 +97,23 @@ export function decodeServerEvent(message: SSEMessage): ServerEvent | null {
     }
   }
 
+  if (message.event === QUERY_JOB_PROGRESS_EVENT) {
+    const payload = JSON.parse(message.data) as QueryJobProgressPayload
+    return {
+      type: QUERY_JOB_PROGRESS_EVENT,
+      payload,
+      id: message.id,
+    }
+  }
+
+  if (message.event === QUERY_JOB_NOTICE_EVENT) {
+    const payload = JSON.parse(message.data) as QueryJobNoticePayload
+    return {
+      type: QUERY_JOB_NOTICE_EVENT,
+      payload,
+      id: message.id,
+    }
+  }
+
   return null
 }
@@ -0,0 +1,20 @@
+import { describe, expect, test } from "bun:test"
+import { formatCell, isNumericCell } from "./query-cell"
+
+describe("query cell", () => {
+  test("formats native and tagged cells", () => {
+    expect(formatCell(null)).toBe("NULL")
+    expect(formatCell(1)).toBe("1")
+    expect(formatCell("1")).toBe("1")
+    expect(formatCell({ $type: "decimal", value: "9.75" })).toBe("9.75")
+    expect(formatCell({ $type: "json", value: { a: [1] } })).toBe('{"a":[1]}')
+    expect(formatCell({ $type: "bytes", value: "/wA=", length: 2 })).toBe("\\xff00")
+    expect(formatCell({ $type: "array", value: [[1, null], [3, 4]], elementType: "int4" })).toBe("{{1,NULL},{3,4}}")
+  })
+
+  test("treats numbers and decimals as numeric", () => {
+    expect(isNumericCell(1)).toBe(true)
+    expect(isNumericCell({ $type: "decimal", value: "1" })).toBe(true)
+    expect(isNumericCell("1")).toBe(false)
+  })
+})
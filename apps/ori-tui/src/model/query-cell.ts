@@ -0,0 +1,54 @@
+export type TaggedCellType =
+  | "decimal"
+  | "bytes"
+  | "timestamp"
+  | "timestamptz"
+  | "date"
+  | "time"
+  | "interval"
+  | "uuid"
+  | "array"
+  | "json"
+
+export type TaggedCell = {
+  $type: TaggedCellType
+  value: unknown
+  length?: number
+  elementType?: string
+}
+
+export function isTaggedCell(value: unknown): value is TaggedCell {
+  return typeof value === "object" && value !== null && !Array.isArray(value) && "$type" in value && "value" in value
+}
+
+export function isNumericCell(value: unknown): boolean {
+  return typeof value === "number" || (isTaggedCell(value) && value.$type === "decimal")
+}
+
+export function formatCell(value: unknown): string {
+  if (value === null || value === undefined) {
+    return "NULL"
+  }
+  if (!isTaggedCell(value)) {
+    return String(value)
+  }
+
+  switch (value.$type) {
+    case "json":
+      return JSON.stringify(value.value)
+    case "array":
+      return formatArray(value.value)
+    case "bytes":
+      return `\\x${Buffer.from(String(value.value), "base64").toString("hex")}`
+    default:
+      return String(value.value)
+  }
+}
+
+function formatArray(elements: unknown): string {
+  if (!Array.isArray(elements)) {
+    return String(elements)
+  }
+  const parts = elements.map((element) => (Array.isArray(element) ? formatArray(element) : formatCell(element)))
+  return `{${parts.join(",")}}`
+}
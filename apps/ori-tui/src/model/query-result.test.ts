@@ -0,0 +1,12 @@
+import { describe, expect, test } from "bun:test"
+import { formatRowsAffected } from "./query-result"
+
+describe("query result", () => {
+  test("names the rows affected after the command verb", () => {
+    expect(formatRowsAffected(3, "UPDATE 3")).toBe("3 rows updated")
+    expect(formatRowsAffected(1, "INSERT 0 1")).toBe("1 row inserted")
+    expect(formatRowsAffected(2, "DELETE 2")).toBe("2 rows deleted")
+    expect(formatRowsAffected(0)).toBe("0 rows affected")
+    expect(formatRowsAffected(4, "COPY 4")).toBe("4 rows affected")
+  })
+})
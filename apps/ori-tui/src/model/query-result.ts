@@ -0,0 +1,12 @@
+const COMMAND_VERBS: Record<string, string> = {
+  INSERT: "inserted",
+  UPDATE: "updated",
+  DELETE: "deleted",
+  MERGE: "merged",
+}
+
+/** Describes the rows a statement affected, using the verb of its command tag such as "UPDATE 3". */
+export function formatRowsAffected(rowsAffected: number, commandTag?: string): string {
+  const verb = (commandTag && COMMAND_VERBS[commandTag.split(" ")[0] ?? ""]) || "affected"
+  return `${rowsAffected} row${rowsAffected === 1 ? "" : "s"} ${verb}`
+}
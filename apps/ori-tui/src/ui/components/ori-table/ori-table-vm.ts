@@ -1,3 +1,4 @@
+import { formatCell } from "@model/query-cell"
 import { type Accessor, batch, createEffect, createMemo, createSignal, untrack } from "solid-js"
 import {
   type CellRef,
@@ -217,8 +218,8 @@ export function createOriTableVM(options: CreateOriTableVMOptions) {
     headerSegments: () => geometry().headerSegments(),
     rowSegments: (row: TableRow) => geometry().rowSegments(row),
     rowVisualRange: (row: TableRow) => geometry().rowVisualRange(row),
-    headerText: (col: TableCol) => formatTableCell(options.columns()[col]?.name ?? ""),
-    cellText: (row: TableRow, col: TableCol) => formatTableCell(options.rows()[row]?.[col]),
+    headerText: (col: TableCol) => formatCell(options.columns()[col]?.name ?? ""),
+    cellText: (row: TableRow, col: TableCol) => formatCell(options.rows()[row]?.[col]),
     cellValue: (row: TableRow, col: TableCol) => options.rows()[row]?.[col],
     isCellSelected: (cell: CellRef) => isCellSelected(selectedRange(), cell),
     isSeparatorSelected: (row: TableRow | "header", ref: SeparatorRef) =>
@@ -232,16 +233,12 @@ function buildColumnWidths(columns: OriTableColumn[], rows: unknown[][]) {
   const widths = columns.map((column) => column.name.length)
   for (const row of rows) {
     for (let index = 0; index < columns.length; index += 1) {
-      widths[index] = Math.max(widths[index] ?? 0, formatTableCell(row[index]).length)
+      widths[index] = Math.max(widths[index] ?? 0, formatCell(row[index]).length)
     }
   }
   return widths
 }
 
-function formatTableCell(value: unknown): string {
-  return value === null || value === undefined ? "NULL" : String(value)
-}
-
 function buildSelectionText(
   selection: CellSelection | null,
   columns: OriTableColumn[],
@@ -254,7 +251,7 @@ function buildSelectionText(
   if (range.includeHeader) {
     const values: string[] = []
     for (let col = Number(range.firstCol); col <= range.lastCol; col += 1) {
-      values.push(formatTableCell(columns[col]?.name ?? ""))
+      values.push(formatCell(columns[col]?.name ?? ""))
     }
     lines.push(values.join("\t"))
   }
@@ -264,7 +261,7 @@ function buildSelectionText(
       const row = rows[rowIndex] ?? []
       const values: string[] = []
       for (let col = Number(range.firstCol); col <= range.lastCol; col += 1) {
-        values.push(formatTableCell(row[col]))
+        values.push(formatCell(row[col]))
       }
       lines.push(values.join("\t"))
     }
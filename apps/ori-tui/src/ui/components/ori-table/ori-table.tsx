@@ -4,6 +4,7 @@ import {
   type ScrollBoxRenderable,
   TextAttributes,
 } from "@opentui/core"
+import { isNumericCell } from "@model/query-cell"
 import { OriScrollbox } from "@ui/components/ori-scrollbox"
 import { type SelectionOwnerOptions, useSelectionOwner } from "@ui/providers/selection"
 import { type KeyBinding, KeyScope } from "@ui/services/key-scopes"
@@ -430,7 +431,7 @@ export function OriTable(props: OriTableProps) {
                                 backgroundColor={cursor() ? props.colors.cursorBackground : background()}
                                 flexDirection="row"
                                 width={segment.width}
-                                align={typeof value() === "number" ? "right" : "left"}
+                                align={isNumericCell(value()) ? "right" : "left"}
                                 selectable={selectionOwner.canAcquire()}
                                 onMouseDown={(event: MouseEvent) => handleCellMouseDown(cell, event)}
                                 selectionBg={props.colors.selectionBackground}
@@ -7,7 +7,7 @@ export type SqlSchemaInput = {
   loaded: boolean
 }
 
-export type SqlRelationKind = "table" | "view"
+export type SqlRelationKind = "table" | "view" | "matview" | "foreign_table"
 
 export type SqlColumn = {
   id: string
@@ -119,7 +119,7 @@ function isDefaultSchema(node: Node | undefined) {
 }
 
 function createRelation(
-  node: Extract<Node, { type: "table" | "view" }>,
+  node: Extract<Node, { type: SqlRelationKind }>,
   input: SqlSchemaInput,
   parentById: Record<string, string>,
 ): SqlRelation {
@@ -153,8 +153,11 @@ export function buildSqlSchemaIndex(input: SqlSchemaInput): SqlSchemaIndex {
   const schemaLookup: Lookup = new Map()
   const relations = Object.values(input.nodesById)
     .filter(
-      (node): node is Extract<Node, { type: "table" | "view" }> =>
-        node.type === NodeType.TABLE || node.type === NodeType.VIEW,
+      (node): node is Extract<Node, { type: SqlRelationKind }> =>
+        node.type === NodeType.TABLE ||
+        node.type === NodeType.VIEW ||
+        node.type === NodeType.MATVIEW ||
+        node.type === NodeType.FOREIGN_TABLE,
     )
     .map((node) => {
       const schemaNode = findAncestor(parentById, input.nodesById, node.id, NodeType.SCHEMA)
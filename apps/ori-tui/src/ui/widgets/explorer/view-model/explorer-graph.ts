@@ -8,7 +8,10 @@ export type ExplorerGraph = {
   parentById: Record<string, string>
 }
 
-type BrowseSourceNode = Extract<Node, { type: typeof NodeType.TABLE | typeof NodeType.VIEW }>
+type BrowseSourceNode = Extract<
+  Node,
+  { type: typeof NodeType.TABLE | typeof NodeType.VIEW | typeof NodeType.MATVIEW | typeof NodeType.FOREIGN_TABLE }
+>
 type BrowseScopeNode = Extract<Node, { type: typeof NodeType.DATABASE | typeof NodeType.SCHEMA }>
 
 export function createExplorerGraph(snapshot: { nodesById: Record<string, Node>; rootIds: string[] }): ExplorerGraph {
@@ -98,7 +101,12 @@ function attachBrowseCapabilities(
 }
 
 function isBrowseSourceNode(node: Node): node is BrowseSourceNode {
-  return node.type === NodeType.TABLE || node.type === NodeType.VIEW
+  return (
+    node.type === NodeType.TABLE ||
+    node.type === NodeType.VIEW ||
+    node.type === NodeType.MATVIEW ||
+    node.type === NodeType.FOREIGN_TABLE
+  )
 }
 
 function findBrowseScopeNode(
@@ -202,6 +202,74 @@ describe("createSnapshotExplorerNode", () => {
     expect(view.description).toBe("active_users")
   })
 
+  test("describes partitioned tables by key and partitions by bound", () => {
+    const parent = getSnapshotNode(
+      makeNode({
+        id: "events",
+        type: NodeType.TABLE,
+        name: "events",
+        attributes: { partitionStrategy: "RANGE", partitionKey: "happened_on" },
+      }),
+    )
+    expect(parent.description).toBe("partition by range (happened_on)")
+
+    const partition = getSnapshotNode(
+      makeNode({
+        id: "events-2024",
+        type: NodeType.TABLE,
+        name: "events_2024",
+        attributes: { partitionBound: "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')" },
+      }),
+    )
+    expect(partition.description).toBe("FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')")
+  })
+
+  test("badges unpopulated materialized views", () => {
+    const node = getSnapshotNode(
+      makeNode({
+        id: "matview-1",
+        type: NodeType.MATVIEW,
+        name: "pending_book_titles",
+        attributes: { tableType: "matview", populated: false },
+      }),
+    )
+    expect(node.description).toBeUndefined()
+    expect(node.badges).toEqual(["unpopulated"])
+  })
+
+  test("describes foreign tables by server and lists their options", () => {
+    const node = makeNode({
+      id: "ft-1",
+      type: NodeType.FOREIGN_TABLE,
+      name: "remote_authors",
+      attributes: { tableType: "foreign_table", server: "loopback", options: ["table_name=authors"] },
+    })
+    expect(getSnapshotNode(node).description).toBe("server loopback")
+    const option = convertToExplorerNodes(node).find((item) => item.id === "synthetic:ft-1:options:0")
+    expect(option?.name).toBe("table_name=authors")
+  })
+
+  test("lists privileges grouped by grantee", () => {
+    const node = makeNode({
+      id: "table-2",
+      type: NodeType.TABLE,
+      name: "books",
+      attributes: {
+        tableType: "table",
+        owner: "app_owner",
+        privileges: [
+          { grantee: "app_owner", privilege: "SELECT", grantOption: false },
+          { grantee: "app_owner", privilege: "INSERT", grantOption: true },
+          { grantee: "PUBLIC", privilege: "SELECT", grantOption: false },
+        ],
+      },
+    })
+    const rows = convertToExplorerNodes(node)
+      .filter((item) => item.id.startsWith("synthetic:table-2:privileges:"))
+      .map((item) => item.name)
+    expect(rows).toEqual(["app_owner: select, insert with grant option", "PUBLIC: select"])
+  })
+
   test("describes columns and badges primary/!null", () => {
     const node = getSnapshotNode(
       makeNode({
@@ -286,6 +354,132 @@ describe("createSnapshotExplorerNode", () => {
     expect(node.badges).toEqual(["enabled"])
   })
 
+  test("describes generated and identity columns", () => {
+    const generated = getSnapshotNode(
+      makeNode({
+        id: "col-total",
+        type: NodeType.COLUMN,
+        name: "total",
+        attributes: { dataType: "NUMERIC", generated: "STORED", generationExpression: "price * qty" },
+      }),
+    )
+    expect(generated.description).toBe("numeric as (price * qty)")
+    expect(generated.badges).toEqual(["stored"])
+
+    const identity = getSnapshotNode(
+      makeNode({
+        id: "col-id",
+        type: NodeType.COLUMN,
+        name: "id",
+        attributes: { dataType: "bigint", notNull: true, identity: "ALWAYS" },
+      }),
+    )
+    expect(identity.description).toBe("bigint")
+    expect(identity.badges).toEqual(["!null", "identity"])
+  })
+
+  test("describes sequences by their owning column", () => {
+    const node = getSnapshotNode(
+      makeNode({
+        id: "seq-1",
+        type: NodeType.SEQUENCE,
+        name: "users_id_seq",
+        attributes: { ownedByTable: "users", ownedByColumn: "id" },
+      }),
+    )
+    expect(node.description).toBe("owned by users.id")
+  })
+
+  test("describes user-defined types and lists their labels and fields", () => {
+    const enumNode = makeNode({
+      id: "type-mood",
+      type: NodeType.TYPE,
+      name: "mood",
+      attributes: { typeName: "mood", kind: "enum", notNull: false, labels: ["sad", "ok", "happy"] },
+    })
+    expect(getSnapshotNode(enumNode).description).toBe("enum")
+    const labels = convertToExplorerNodes(enumNode).filter((item) => item.id.startsWith("synthetic:type-mood:labels:"))
+    expect(labels.map((item) => item.name)).toEqual(["sad", "ok", "happy"])
+
+    const domain = getSnapshotNode(
+      makeNode({
+        id: "type-price",
+        type: NodeType.TYPE,
+        name: "positive_price",
+        attributes: { typeName: "positive_price", kind: "domain", baseType: "NUMERIC(10,2)", notNull: true },
+      }),
+    )
+    expect(domain.description).toBe("domain over numeric(10,2)")
+    expect(domain.badges).toEqual(["!null"])
+
+    const composite = makeNode({
+      id: "type-point",
+      type: NodeType.TYPE,
+      name: "point",
+      attributes: {
+        typeName: "point",
+        kind: "composite",
+        notNull: false,
+        fields: [{ name: "x", dataType: "INTEGER" }],
+      },
+    })
+    const field = convertToExplorerNodes(composite).find((item) => item.id === "synthetic:type-point:fields:0")
+    expect(field?.name).toBe("x integer")
+  })
+
+  test("describes extensions by version and badges load state", () => {
+    const trgm = getSnapshotNode(
+      makeNode({
+        id: "ext-trgm",
+        type: NodeType.EXTENSION,
+        name: "pg_trgm",
+        attributes: { extensionName: "pg_trgm", version: "1.6", schema: "public" },
+      }),
+    )
+    expect(trgm.description).toBe("1.6")
+    expect(trgm.badges).toEqual([])
+
+    const spatial = getSnapshotNode(
+      makeNode({
+        id: "ext-spatial",
+        type: NodeType.EXTENSION,
+        name: "spatial",
+        attributes: { extensionName: "spatial", version: "v1.4.4", loaded: false },
+      }),
+    )
+    expect(spatial.badges).toEqual(["installed"])
+  })
+
+  test("describes routines by argument types and badges", () => {
+    const fn = getSnapshotNode(
+      makeNode({
+        id: "fn-1",
+        type: NodeType.FUNCTION,
+        name: "add",
+        attributes: {
+          signature: "add(integer, integer)",
+          returnType: "integer",
+          language: "sql",
+          volatility: "IMMUTABLE",
+          securityDefiner: false,
+        },
+      }),
+    )
+    expect(fn.description).toBe("(integer, integer) returns integer")
+    expect(fn.badges).toEqual(["sql", "immutable"])
+
+    const procedure = getSnapshotNode(
+      makeNode({
+        id: "proc-1",
+        type: NodeType.PROCEDURE,
+        name: "touch",
+        attributes: { signature: "touch(integer)", language: "plpgsql", securityDefiner: true },
+      }),
+    )
+    expect(procedure.description).toBe("(integer)")
+    expect(procedure.badges).toEqual(["plpgsql", "definer"])
+  })
+
   test("creates synthetic attribute nodes without backend node payload", () => {
     const index = makeNode({
       id: "idx-1",
@@ -1,4 +1,4 @@
-import { type Node, type NodeEdge, NodeType } from "@adapters/ori/client"
+import { type Node, type NodeEdge, NodeType, type Privilege } from "@adapters/ori/client"
 
 export type ExplorerOrigin =
   | {
@@ -37,6 +37,11 @@ export type BrowsableExplorerNode = ExplorerNode & {
 type ConstraintNode = Extract<Node, { type: typeof NodeType.CONSTRAINT }>
 type IndexNode = Extract<Node, { type: typeof NodeType.INDEX }>
 type TriggerNode = Extract<Node, { type: typeof NodeType.TRIGGER }>
+type ColumnNode = Extract<Node, { type: typeof NodeType.COLUMN }>
+type TableNode = Extract<Node, { type: typeof NodeType.TABLE }>
+type SequenceNode = Extract<Node, { type: typeof NodeType.SEQUENCE }>
+type TypeNode = Extract<Node, { type: typeof NodeType.TYPE }>
+type RoutineNode = Extract<Node, { type: typeof NodeType.FUNCTION | typeof NodeType.PROCEDURE }>
 
 function explorerNodeFromSnapshotNode(node: Node): ExplorerNode {
   const isDefault = "isDefault" in node.attributes ? Boolean(node.attributes.isDefault) : undefined
@@ -147,6 +152,23 @@ function collectExpandableAttributes(node: Node): Array<[string, string[]]> {
     attributes.push(["action_rules", ruleName ? [ruleName] : []])
   }
 
+  if (node.type === NodeType.FOREIGN_TABLE) {
+    attributes.push(["options", node.attributes.options ?? []])
+  }
+
+  if (node.type === NodeType.TYPE) {
+    const fields = (node.attributes.fields ?? []).map((field) => `${field.name} ${field.dataType.toLowerCase()}`)
+    attributes.push(
+      ["labels", node.attributes.labels ?? []],
+      ["constraints", node.attributes.constraints ?? []],
+      ["fields", fields],
+    )
+  }
+
+  if ("privileges" in node.attributes) {
+    attributes.push(["privileges", formatPrivileges(node.attributes.privileges ?? [])])
+  }
+
   return attributes.filter(([, values]) => values.length > 0)
 }
 
@@ -182,6 +204,18 @@ function createAttributeExplorerGroup(node: Node, attributeName: string, childId
   }
 }
 
+// One row per grantee, as in "app_user: select, insert with grant option"
+function formatPrivileges(privileges: Privilege[]): string[] {
+  const byGrantee = new Map<string, string[]>()
+  for (const privilege of privileges) {
+    const name = privilege.privilege.toLowerCase()
+    const granted = byGrantee.get(privilege.grantee) ?? []
+    granted.push(privilege.grantOption ? `${name} with grant option` : name)
+    byGrantee.set(privilege.grantee, granted)
+  }
+  return [...byGrantee].map(([grantee, granted]) => `${grantee}: ${granted.join(", ")}`)
+}
+
 function formatConstraintActionName(attrs: ConstraintNode["attributes"]): string | undefined {
   const parts: string[] = []
   const match = attrs.match ?? ""
@@ -231,6 +265,10 @@ function describeNode(node: Node): string | undefined {
     case NodeType.SCHEMA:
       return "schema"
     case NodeType.TABLE: {
+      const partition = describePartition(node.attributes)
+      if (partition) {
+        return partition
+      }
       const table = node.attributes.table ?? ""
       if (!table) {
         return undefined
@@ -240,7 +278,8 @@ function describeNode(node: Node): string | undefined {
       }
       return table.toLowerCase()
     }
-    case NodeType.VIEW: {
+    case NodeType.VIEW:
+    case NodeType.MATVIEW: {
       const table = node.attributes.table ?? ""
       if (!table) {
         return undefined
@@ -250,14 +289,28 @@ function describeNode(node: Node): string | undefined {
       }
       return table.toLowerCase()
     }
+    case NodeType.FOREIGN_TABLE: {
+      const server = node.attributes.server ?? ""
+      if (!server) return undefined
+      return `server ${server.toLowerCase()}`
+    }
     case NodeType.COLUMN:
-      return node.attributes.dataType?.toLowerCase()
+      return describeColumn(node.attributes)
     case NodeType.CONSTRAINT:
       return describeConstraint(node.attributes)
     case NodeType.INDEX:
       return describeIndex(node.attributes)
     case NodeType.TRIGGER:
       return undefined
+    case NodeType.FUNCTION:
+    case NodeType.PROCEDURE:
+      return describeRoutine(node)
+    case NodeType.SEQUENCE:
+      return describeSequence(node.attributes)
+    case NodeType.TYPE:
+      return describeType(node.attributes)
+    case NodeType.EXTENSION:
+      return node.attributes.version || undefined
   }
 }
 
@@ -270,6 +323,12 @@ function nodeBadges(node: Node): string[] {
     if (node.attributes.notNull) {
       badges.push("!null")
     }
+    if (node.attributes.identity) {
+      badges.push("identity")
+    }
+    if (node.attributes.generated) {
+      badges.push(node.attributes.generated.toLowerCase())
+    }
     return badges
   }
   if (node.type === NodeType.CONSTRAINT) {
@@ -283,9 +342,74 @@ function nodeBadges(node: Node): string[] {
     if (!state) return []
     return [state.toLowerCase()]
   }
+  if (node.type === NodeType.FUNCTION || node.type === NodeType.PROCEDURE) {
+    return routineBadges(node)
+  }
+  if (node.type === NodeType.MATVIEW) {
+    return node.attributes.populated ? [] : ["unpopulated"]
+  }
+  if (node.type === NodeType.TYPE && node.attributes.notNull) {
+    return ["!null"]
+  }
+  // DuckDB installs extensions ahead of loading them; Postgres reports no load state
+  if (node.type === NodeType.EXTENSION && node.attributes.loaded !== undefined) {
+    return [node.attributes.loaded ? "loaded" : "installed"]
+  }
   return []
 }
 
+// Partitioned tables show their key and partitions their bound, which keeps its literals as written
+function describePartition(attrs: TableNode["attributes"]): string | undefined {
+  if (attrs.partitionBound) return attrs.partitionBound
+  if (!attrs.partitionStrategy) return undefined
+  return `partition by ${attrs.partitionStrategy.toLowerCase()} (${attrs.partitionKey ?? ""})`
+}
+
+// Generated columns show their expression in place of a default
+function describeColumn(attrs: ColumnNode["attributes"]): string | undefined {
+  const dataType = attrs.dataType?.toLowerCase()
+  const expression = attrs.generationExpression ?? ""
+  if (!expression) return dataType
+  return `${dataType} as (${expression.toLowerCase()})`
+}
+
+function describeSequence(attrs: SequenceNode["attributes"]): string | undefined {
+  const owner = [attrs.ownedByTable ?? "", attrs.ownedByColumn ?? ""].filter(Boolean).join(".")
+  if (!owner) return undefined
+  return `owned by ${owner.toLowerCase()}`
+}
+
+function describeType(attrs: TypeNode["attributes"]): string {
+  if (attrs.kind === "domain" && attrs.baseType) {
+    return `domain over ${attrs.baseType.toLowerCase()}`
+  }
+  return attrs.kind
+}
+
+// Overloads share a name, so routines are told apart by their argument types
+function describeRoutine(node: RoutineNode): string | undefined {
+  const signature = node.attributes.signature ?? ""
+  const argumentTypes = signature.slice(signature.indexOf("("))
+  const returnType = node.type === NodeType.FUNCTION ? (node.attributes.returnType ?? "") : ""
+  const parts = [argumentTypes, returnType ? `returns ${returnType}` : ""].filter(Boolean)
+  if (parts.length === 0) return undefined
+  return parts.join(" ").toLowerCase()
+}
+
+function routineBadges(node: RoutineNode): string[] {
+  const badges: string[] = []
+  if (node.attributes.language) {
+    badges.push(node.attributes.language.toLowerCase())
+  }
+  if (node.type === NodeType.FUNCTION && node.attributes.volatility) {
+    badges.push(node.attributes.volatility.toLowerCase())
+  }
+  if (node.attributes.securityDefiner) {
+    badges.push("definer")
+  }
+  return badges
+}
+
 function constraintBadges(attrs: ConstraintNode["attributes"]): string[] {
   const constraintType = attrs.constraintType ?? ""
   if (constraintType === "PRIMARY KEY") {
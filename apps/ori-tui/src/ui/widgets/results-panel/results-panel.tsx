@@ -1,3 +1,4 @@
+import { formatRowsAffected } from "@model/query-result"
 import { TextAttributes } from "@opentui/core"
 import { OriTable } from "@ui/components/ori-table/ori-table"
 import { useTheme } from "@ui/providers/theme"
@@ -58,7 +59,13 @@ export function ResultsPanel(props: ResultsPanelProps) {
 
   const resultRows = () => pane.job()?.result?.rows ?? []
   const resultColumns = () => pane.job()?.result?.columns ?? []
-  const rowsAffected = () => pane.job()?.result?.rowsAffected
+  const rowsAffected = () => {
+    const result = pane.job()?.result
+    if (result?.rowsAffected === undefined || result.rowsAffected === null) {
+      return undefined
+    }
+    return formatRowsAffected(result.rowsAffected, result.commandTag)
+  }
   const hasRows = createMemo(() => {
     const current = pane.job()
     return current?.status === "success" && current?.result && resultRows().length > 0
@@ -128,7 +135,7 @@ export function ResultsPanel(props: ResultsPanelProps) {
           <text attributes={TextAttributes.DIM}>
             Query completed successfully in
             {pane.job()?.durationMs ? ` ${pane.job()?.durationMs}ms` : ""}
-            {rowsAffected() !== undefined ? `; ${rowsAffected()} rows affected` : ""}
+            {rowsAffected() !== undefined ? `; ${rowsAffected()}` : ""}
           </text>
         </Show>
       </box>
@@ -1,3 +1,4 @@
+import { formatRowsAffected } from "@model/query-result"
 import type { HighlightGroup } from "@ui/theme"
 import type { QueryJob } from "@usecase/query/usecase"
 import { Show } from "solid-js"
@@ -57,10 +58,14 @@ function formatSuccessNotification(job: QueryJob) {
   if (result && result.rows.length > 0) {
     const rowsText = `${result.rowCount} row${result.rowCount === 1 ? "" : "s"}`
     const truncatedText = result.truncated ? " (truncated)" : ""
-    return `${rowsText}${truncatedText}${durationText}`
+    const affectedText =
+      result.rowsAffected === undefined || result.rowsAffected === null
+        ? ""
+        : `, ${formatRowsAffected(result.rowsAffected, result.commandTag)}`
+    return `${rowsText}${truncatedText}${affectedText}${durationText}`
   }
-  if (result?.rowsAffected !== undefined) {
-    return `${result.rowsAffected} row${result.rowsAffected === 1 ? "" : "s"} affected${durationText}`
+  if (result?.rowsAffected !== undefined && result.rowsAffected !== null) {
+    return `${formatRowsAffected(result.rowsAffected, result.commandTag)}${durationText}`
   }
   const fallbackDurationText = job.durationMs === undefined ? "" : ` (${job.durationMs}ms)`
   return `Query completed successfully${fallbackDurationText}`
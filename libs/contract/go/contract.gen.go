@@ -24,6 +24,18 @@ const (
 	Column ColumnNodeType = "column"
 )
 
+// Defines values for ColumnNodeAttributesGenerated.
+const (
+	GeneratedStored  ColumnNodeAttributesGenerated = "STORED"
+	GeneratedVirtual ColumnNodeAttributesGenerated = "VIRTUAL"
+)
+
+// Defines values for ColumnNodeAttributesIdentity.
+const (
+	IdentityAlways    ColumnNodeAttributesIdentity = "ALWAYS"
+	IdentityByDefault ColumnNodeAttributesIdentity = "BY DEFAULT"
+)
+
 // Defines values for ConstraintNodeType.
 const (
 	Constraint ConstraintNodeType = "constraint"
@@ -34,11 +46,49 @@ const (
 	Database DatabaseNodeType = "database"
 )
 
+// Defines values for ExtensionNodeType.
+const (
+	Extension ExtensionNodeType = "extension"
+)
+
+// Defines values for ForeignTableNodeType.
+const (
+	ForeignTable ForeignTableNodeType = "foreign_table"
+)
+
+// Defines values for FunctionNodeType.
+const (
+	Function FunctionNodeType = "function"
+)
+
+// Defines values for FunctionNodeAttributesVolatility.
+const (
+	FunctionVolatilityImmutable FunctionNodeAttributesVolatility = "IMMUTABLE"
+	FunctionVolatilityStable    FunctionNodeAttributesVolatility = "STABLE"
+	FunctionVolatilityVolatile  FunctionNodeAttributesVolatility = "VOLATILE"
+)
+
 // Defines values for IndexNodeType.
 const (
 	Index IndexNodeType = "index"
 )
 
+// Defines values for MatviewNodeType.
+const (
+	Matview MatviewNodeType = "matview"
+)
+
+// Defines values for ObjectPrivilegesObjectType.
+const (
+	PrivilegeObjectForeignTable ObjectPrivilegesObjectType = "foreign_table"
+	PrivilegeObjectFunction     ObjectPrivilegesObjectType = "function"
+	PrivilegeObjectMatview      ObjectPrivilegesObjectType = "matview"
+	PrivilegeObjectProcedure    ObjectPrivilegesObjectType = "procedure"
+	PrivilegeObjectSchema       ObjectPrivilegesObjectType = "schema"
+	PrivilegeObjectTable        ObjectPrivilegesObjectType = "table"
+	PrivilegeObjectView         ObjectPrivilegesObjectType = "view"
+)
+
 // Defines values for PasswordConfigType.
 const (
 	Keychain  PasswordConfigType = "keychain"
@@ -46,18 +96,73 @@ const (
 	Shell     PasswordConfigType = "shell"
 )
 
+// Defines values for ProcedureNodeType.
+const (
+	Procedure ProcedureNodeType = "procedure"
+)
+
+// Defines values for QueryExecOptionsOnError.
+const (
+	Continue QueryExecOptionsOnError = "continue"
+	Stop     QueryExecOptionsOnError = "stop"
+)
+
 // Defines values for QueryExecResponseStatus.
 const (
 	QueryExecResponseStatusFailed  QueryExecResponseStatus = "failed"
+	QueryExecResponseStatusQueued  QueryExecResponseStatus = "queued"
 	QueryExecResponseStatusRunning QueryExecResponseStatus = "running"
 )
 
+// Defines values for QueryExportFormat.
+const (
+	ExportCsv      QueryExportFormat = "csv"
+	ExportJsonl    QueryExportFormat = "jsonl"
+	ExportMarkdown QueryExportFormat = "markdown"
+	ExportParquet  QueryExportFormat = "parquet"
+	ExportTsv      QueryExportFormat = "tsv"
+)
+
+// Defines values for QueryHistoryStatus.
+const (
+	HistoryCanceled QueryHistoryStatus = "canceled"
+	HistoryFailed   QueryHistoryStatus = "failed"
+	HistorySuccess  QueryHistoryStatus = "success"
+	HistoryTimedOut QueryHistoryStatus = "timed_out"
+)
+
 // Defines values for QueryJobStatusResponseStatus.
 const (
 	QueryJobStatusResponseStatusCanceled QueryJobStatusResponseStatus = "canceled"
 	QueryJobStatusResponseStatusFailed   QueryJobStatusResponseStatus = "failed"
+	QueryJobStatusResponseStatusQueued   QueryJobStatusResponseStatus = "queued"
 	QueryJobStatusResponseStatusRunning  QueryJobStatusResponseStatus = "running"
 	QueryJobStatusResponseStatusSuccess  QueryJobStatusResponseStatus = "success"
+	QueryJobStatusResponseStatusTimedOut QueryJobStatusResponseStatus = "timed_out"
+)
+
+// Defines values for QueryStatementStatusStatus.
+const (
+	StatementCanceled QueryStatementStatusStatus = "canceled"
+	StatementFailed   QueryStatementStatusStatus = "failed"
+	StatementPending  QueryStatementStatusStatus = "pending"
+	StatementRunning  QueryStatementStatusStatus = "running"
+	StatementSkipped  QueryStatementStatusStatus = "skipped"
+	StatementSuccess  QueryStatementStatusStatus = "success"
+)
+
+// Defines values for QueryTxState.
+const (
+	TxFailed        QueryTxState = "failed"
+	TxIdle          QueryTxState = "idle"
+	TxInTransaction QueryTxState = "in_transaction"
+)
+
+// Defines values for ResourceDestructiveGuard.
+const (
+	GuardConfirm ResourceDestructiveGuard = "confirm"
+	GuardOff     ResourceDestructiveGuard = "off"
+	GuardWarn    ResourceDestructiveGuard = "warn"
 )
 
 // Defines values for ResourceConnectResultResult.
@@ -72,16 +177,40 @@ const (
 	Schema SchemaNodeType = "schema"
 )
 
+// Defines values for SequenceNodeType.
+const (
+	Sequence SequenceNodeType = "sequence"
+)
+
 // Defines values for TableNodeType.
 const (
 	Table TableNodeType = "table"
 )
 
+// Defines values for TableNodeAttributesPartitionStrategy.
+const (
+	PartitionHash  TableNodeAttributesPartitionStrategy = "HASH"
+	PartitionList  TableNodeAttributesPartitionStrategy = "LIST"
+	PartitionRange TableNodeAttributesPartitionStrategy = "RANGE"
+)
+
 // Defines values for TriggerNodeType.
 const (
 	Trigger TriggerNodeType = "trigger"
 )
 
+// Defines values for TypeNodeType.
+const (
+	Type TypeNodeType = "type"
+)
+
+// Defines values for TypeNodeAttributesKind.
+const (
+	TypeKindComposite TypeNodeAttributesKind = "composite"
+	TypeKindDomain    TypeNodeAttributesKind = "domain"
+	TypeKindEnum      TypeNodeAttributesKind = "enum"
+)
+
 // Defines values for ViewNodeType.
 const (
 	View ViewNodeType = "view"
@@ -101,19 +230,32 @@ type ColumnNodeType string
 
 // ColumnNodeAttributes defines model for ColumnNodeAttributes.
 type ColumnNodeAttributes struct {
-	CharMaxLength      *int64  `json:"charMaxLength,omitempty"`
-	Column             string  `json:"column"`
-	DataType           string  `json:"dataType"`
-	DefaultValue       *string `json:"defaultValue,omitempty"`
-	NotNull            bool    `json:"notNull"`
-	NumericPrecision   *int64  `json:"numericPrecision,omitempty"`
-	NumericScale       *int64  `json:"numericScale,omitempty"`
-	Ordinal            int     `json:"ordinal"`
-	PrimaryKeyPosition *int    `json:"primaryKeyPosition,omitempty"`
-	Resource           string  `json:"resource"`
-	Table              string  `json:"table"`
+	CharMaxLength *int64  `json:"charMaxLength,omitempty"`
+	Column        string  `json:"column"`
+	DataType      string  `json:"dataType"`
+	DefaultValue  *string `json:"defaultValue,omitempty"`
+
+	// Generated Set on generated columns; virtual columns are computed on read
+	Generated            *ColumnNodeAttributesGenerated `json:"generated,omitempty"`
+	GenerationExpression *string                        `json:"generationExpression,omitempty"`
+
+	// Identity Set on identity columns
+	Identity           *ColumnNodeAttributesIdentity `json:"identity,omitempty"`
+	NotNull            bool                          `json:"notNull"`
+	NumericPrecision   *int64                        `json:"numericPrecision,omitempty"`
+	NumericScale       *int64                        `json:"numericScale,omitempty"`
+	Ordinal            int                           `json:"ordinal"`
+	PrimaryKeyPosition *int                          `json:"primaryKeyPosition,omitempty"`
+	Resource           string                        `json:"resource"`
+	Table              string                        `json:"table"`
 }
 
+// ColumnNodeAttributesGenerated Set on generated columns; virtual columns are computed on read
+type ColumnNodeAttributesGenerated string
+
+// ColumnNodeAttributesIdentity Set on identity columns
+type ColumnNodeAttributesIdentity string
+
 // ConstraintNode defines model for ConstraintNode.
 type ConstraintNode struct {
 	Attributes ConstraintNodeAttributes `json:"attributes"`
@@ -144,6 +286,32 @@ type ConstraintNodeAttributes struct {
 	Table              string    `json:"table"`
 }
 
+// DatabaseError Structured fields of an error the database reported. Fields the engine does not report are omitted.
+type DatabaseError struct {
+	// Code SQLSTATE on PostgreSQL, result code name on SQLite, error type on DuckDB
+	Code *string `json:"code,omitempty"`
+
+	// Column 1-based column of the error within its line
+	Column     *int    `json:"column,omitempty"`
+	ColumnName *string `json:"columnName,omitempty"`
+	Constraint *string `json:"constraint,omitempty"`
+	Detail     *string `json:"detail,omitempty"`
+
+	// Engine Engine that reported the error, such as postgres, sqlite or duckdb
+	Engine string  `json:"engine"`
+	Hint   *string `json:"hint,omitempty"`
+
+	// Line 1-based line of the error within the submitted query text
+	Line    *int   `json:"line,omitempty"`
+	Message string `json:"message"`
+
+	// Position 1-based character offset of the error within the failed statement
+	Position *int    `json:"position,omitempty"`
+	Schema   *string `json:"schema,omitempty"`
+	Severity *string `json:"severity,omitempty"`
+	Table    *string `json:"table,omitempty"`
+}
+
 // DatabaseNode defines model for DatabaseNode.
 type DatabaseNode struct {
 	Attributes DatabaseNodeAttributes `json:"attributes"`
@@ -169,11 +337,107 @@ type DatabaseNodeAttributes struct {
 
 // ErrorPayload defines model for ErrorPayload.
 type ErrorPayload struct {
-	Code    string                  `json:"code"`
-	Details *map[string]interface{} `json:"details,omitempty"`
-	Message string                  `json:"message"`
+	Code string `json:"code"`
+
+	// DatabaseError Structured fields of an error the database reported. Fields the engine does not report are omitted.
+	DatabaseError *DatabaseError          `json:"databaseError,omitempty"`
+	Details       *map[string]interface{} `json:"details,omitempty"`
+	Message       string                  `json:"message"`
+}
+
+// ExtensionNode defines model for ExtensionNode.
+type ExtensionNode struct {
+	Attributes ExtensionNodeAttributes `json:"attributes"`
+	Edges      map[string]NodeEdge     `json:"edges"`
+	Id         string                  `json:"id"`
+	Name       string                  `json:"name"`
+	Type       ExtensionNodeType       `json:"type"`
+}
+
+// ExtensionNodeType defines model for ExtensionNode.Type.
+type ExtensionNodeType string
+
+// ExtensionNodeAttributes defines model for ExtensionNodeAttributes.
+type ExtensionNodeAttributes struct {
+	Description   *string `json:"description,omitempty"`
+	ExtensionName string  `json:"extensionName"`
+
+	// Loaded Whether the extension is loaded (DuckDB); installed extensions load on first use
+	Loaded   *bool  `json:"loaded,omitempty"`
+	Resource string `json:"resource"`
+
+	// Schema Schema holding the extension's objects (Postgres); the extensions edge of the default schema lists every extension; when the search_path names no existing schema, public lists them, or the first schema when there is no public
+	Schema  *string `json:"schema,omitempty"`
+	Version string  `json:"version"`
+}
+
+// ForeignTableNode defines model for ForeignTableNode.
+type ForeignTableNode struct {
+	Attributes ForeignTableNodeAttributes `json:"attributes"`
+	Edges      map[string]NodeEdge        `json:"edges"`
+	Id         string                     `json:"id"`
+	Name       string                     `json:"name"`
+	Type       ForeignTableNodeType       `json:"type"`
+}
+
+// ForeignTableNodeType defines model for ForeignTableNode.Type.
+type ForeignTableNodeType string
+
+// ForeignTableNodeAttributes defines model for ForeignTableNodeAttributes.
+type ForeignTableNodeAttributes struct {
+	// Options Table options, such as "table_name=orders"
+	Options *[]string `json:"options,omitempty"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges *[]Privilege `json:"privileges,omitempty"`
+	Resource   string       `json:"resource"`
+
+	// Server Foreign server the table reads from
+	Server    string `json:"server"`
+	Table     string `json:"table"`
+	TableType string `json:"tableType"`
+}
+
+// FunctionNode defines model for FunctionNode.
+type FunctionNode struct {
+	Attributes FunctionNodeAttributes `json:"attributes"`
+	Edges      map[string]NodeEdge    `json:"edges"`
+	Id         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Type       FunctionNodeType       `json:"type"`
 }
 
+// FunctionNodeType defines model for FunctionNode.Type.
+type FunctionNodeType string
+
+// FunctionNodeAttributes defines model for FunctionNodeAttributes.
+type FunctionNodeAttributes struct {
+	// Arguments Argument list with names, modes and defaults
+	Arguments    *string `json:"arguments,omitempty"`
+	Definition   *string `json:"definition,omitempty"`
+	FunctionName string  `json:"functionName"`
+	Language     string  `json:"language"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges      *[]Privilege `json:"privileges,omitempty"`
+	Resource        string       `json:"resource"`
+	ReturnType      *string      `json:"returnType,omitempty"`
+	SecurityDefiner bool         `json:"securityDefiner"`
+
+	// Signature Function name with its argument types, unique among overloads
+	Signature  string                            `json:"signature"`
+	Volatility *FunctionNodeAttributesVolatility `json:"volatility,omitempty"`
+}
+
+// FunctionNodeAttributesVolatility defines model for FunctionNodeAttributes.Volatility.
+type FunctionNodeAttributesVolatility string
+
 // IndexNode defines model for IndexNode.
 type IndexNode struct {
 	Attributes IndexNodeAttributes `json:"attributes"`
@@ -200,6 +464,36 @@ type IndexNodeAttributes struct {
 	Unique         bool      `json:"unique"`
 }
 
+// MatviewNode defines model for MatviewNode.
+type MatviewNode struct {
+	Attributes MatviewNodeAttributes `json:"attributes"`
+	Edges      map[string]NodeEdge   `json:"edges"`
+	Id         string                `json:"id"`
+	Name       string                `json:"name"`
+	Type       MatviewNodeType       `json:"type"`
+}
+
+// MatviewNodeType defines model for MatviewNode.Type.
+type MatviewNodeType string
+
+// MatviewNodeAttributes defines model for MatviewNodeAttributes.
+type MatviewNodeAttributes struct {
+	// Definition Query the view runs on REFRESH MATERIALIZED VIEW
+	Definition *string `json:"definition,omitempty"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// Populated False until the view is first refreshed when created WITH NO DATA
+	Populated bool `json:"populated"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges *[]Privilege `json:"privileges,omitempty"`
+	Resource   string       `json:"resource"`
+	Table      string       `json:"table"`
+	TableType  string       `json:"tableType"`
+}
+
 // Node defines model for Node.
 type Node struct {
 	union json.RawMessage
@@ -223,6 +517,18 @@ type NodesResponse struct {
 	Nodes []Node `json:"nodes"`
 }
 
+// ObjectPrivileges defines model for ObjectPrivileges.
+type ObjectPrivileges struct {
+	// Name Object name; routines are named by signature
+	Name       string                     `json:"name"`
+	ObjectType ObjectPrivilegesObjectType `json:"objectType"`
+	Privileges []RolePrivilege            `json:"privileges"`
+	Schema     string                     `json:"schema"`
+}
+
+// ObjectPrivilegesObjectType defines model for ObjectPrivileges.ObjectType.
+type ObjectPrivilegesObjectType string
+
 // PasswordConfig defines model for PasswordConfig.
 type PasswordConfig struct {
 	// Key Provider-specific identifier (plain text value, shell command, or keychain account)
@@ -235,19 +541,92 @@ type PasswordConfig struct {
 // PasswordConfigType Password provider type
 type PasswordConfigType string
 
+// Privilege defines model for Privilege.
+type Privilege struct {
+	// GrantOption Whether the grantee may grant the privilege to others
+	GrantOption bool `json:"grantOption"`
+
+	// Grantee Role holding the privilege, or PUBLIC
+	Grantee string `json:"grantee"`
+
+	// Privilege SELECT, INSERT, UPDATE, DELETE, TRUNCATE, REFERENCES, TRIGGER, USAGE, CREATE or EXECUTE
+	Privilege string `json:"privilege"`
+}
+
+// ProcedureNode defines model for ProcedureNode.
+type ProcedureNode struct {
+	Attributes ProcedureNodeAttributes `json:"attributes"`
+	Edges      map[string]NodeEdge     `json:"edges"`
+	Id         string                  `json:"id"`
+	Name       string                  `json:"name"`
+	Type       ProcedureNodeType       `json:"type"`
+}
+
+// ProcedureNodeType defines model for ProcedureNode.Type.
+type ProcedureNodeType string
+
+// ProcedureNodeAttributes defines model for ProcedureNodeAttributes.
+type ProcedureNodeAttributes struct {
+	// Arguments Argument list with names, modes and defaults
+	Arguments  *string `json:"arguments,omitempty"`
+	Definition *string `json:"definition,omitempty"`
+	Language   string  `json:"language"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges      *[]Privilege `json:"privileges,omitempty"`
+	ProcedureName   string       `json:"procedureName"`
+	Resource        string       `json:"resource"`
+	SecurityDefiner bool         `json:"securityDefiner"`
+
+	// Signature Procedure name with its argument types, unique among overloads
+	Signature string `json:"signature"`
+}
+
 // QueryExecOptions defines model for QueryExecOptions.
 type QueryExecOptions struct {
-	// MaxRows Requested result materialization limit, bounded by the server's ORI_MAX_MATERIALIZED_ROWS policy
+	// ConfirmToken Confirms a destructive query; the token comes from the confirmation_required error for the same resource and query
+	ConfirmToken *string `json:"confirmToken,omitempty"`
+
+	// FetchRows Rows fetched per round trip in lazy mode. Defaults to the resource's autoLimitRows
+	FetchRows *int `json:"fetchRows,omitempty"`
+
+	// Lazy Keep the query's cursor open and fetch further rows only when a result page asks for them. Not allowed with script or sessionId. DuckDB reads the whole result up front instead, since an open cursor would hold its only connection.
+	// The cursor closes once exhausted, at maxRows, after two idle minutes, or when it is the least recently paged of more than four open cursors on the resource; rows fetched until then stay readable.
+	// A DuckDB resource has a single connection, so its other queries wait while the cursor is open.
+	Lazy *bool `json:"lazy,omitempty"`
+
+	// MaxRows Requested result materialization limit. Bounded by ORI_MAX_MATERIALIZED_ROWS when results are memory-only; when results spill to disk only the disk quota applies
 	MaxRows *int `json:"maxRows,omitempty"`
+
+	// OnError Whether a script stops at the first failing statement (default) or continues with the next one
+	OnError *QueryExecOptionsOnError `json:"onError,omitempty"`
+
+	// Script Split the query into statements and run them in order on one connection as a single job
+	Script *bool `json:"script,omitempty"`
+
+	// TimeoutMs Milliseconds the job may run before it is canceled with status timed_out. Defaults to the resource's queryTimeout.
+	// On PostgreSQL a job outside a session or script also runs with this statement_timeout.
+	TimeoutMs *int `json:"timeoutMs,omitempty"`
 }
 
+// QueryExecOptionsOnError Whether a script stops at the first failing statement (default) or continues with the next one
+type QueryExecOptionsOnError string
+
 // QueryExecRequest defines model for QueryExecRequest.
 type QueryExecRequest struct {
-	JobId        openapi_types.UUID       `json:"jobId"`
-	Options      *QueryExecOptions        `json:"options,omitempty"`
+	JobId   openapi_types.UUID `json:"jobId"`
+	Options *QueryExecOptions  `json:"options,omitempty"`
+
+	// Params Positional values, or an object of values for :name / @name placeholders. Every placeholder needs a value and every value must be used.
 	Params       *QueryExecRequest_Params `json:"params,omitempty"`
 	Query        string                   `json:"query"`
 	ResourceName string                   `json:"resourceName"`
+
+	// SessionId Run the job on the connection pinned by this query session
+	SessionId *openapi_types.UUID `json:"sessionId,omitempty"`
 }
 
 // QueryExecRequestParams0 defines model for .
@@ -256,35 +635,198 @@ type QueryExecRequestParams0 map[string]interface{}
 // QueryExecRequestParams1 defines model for .
 type QueryExecRequestParams1 = []interface{}
 
-// QueryExecRequest_Params defines model for QueryExecRequest.Params.
+// QueryExecRequest_Params Positional values, or an object of values for :name / @name placeholders. Every placeholder needs a value and every value must be used.
 type QueryExecRequest_Params struct {
 	union json.RawMessage
 }
 
 // QueryExecResponse defines model for QueryExecResponse.
 type QueryExecResponse struct {
-	JobId   string                  `json:"jobId"`
-	Message *string                 `json:"message"`
-	Status  QueryExecResponseStatus `json:"status"`
+	JobId   string  `json:"jobId"`
+	Message *string `json:"message"`
+
+	// QueuePosition 1-based position in the resource's queue when status is queued
+	QueuePosition *int                    `json:"queuePosition,omitempty"`
+	Status        QueryExecResponseStatus `json:"status"`
+
+	// Warnings Destructive operations detected in the query when the resource's destructiveGuard is warn
+	Warnings *[]string `json:"warnings,omitempty"`
 }
 
 // QueryExecResponseStatus defines model for QueryExecResponse.Status.
 type QueryExecResponseStatus string
 
+// QueryExplainRequest defines model for QueryExplainRequest.
+type QueryExplainRequest struct {
+	// Analyze Execute the statement to collect actual rows and timings. Not supported by SQLite.
+	Analyze *bool `json:"analyze,omitempty"`
+
+	// Params Positional values, or an object of values for :name / @name placeholders.
+	Params *QueryExplainRequest_Params `json:"params,omitempty"`
+
+	// Query A single statement
+	Query        string `json:"query"`
+	ResourceName string `json:"resourceName"`
+}
+
+// QueryExplainRequestParams0 defines model for .
+type QueryExplainRequestParams0 map[string]interface{}
+
+// QueryExplainRequestParams1 defines model for .
+type QueryExplainRequestParams1 = []interface{}
+
+// QueryExplainRequest_Params Positional values, or an object of values for :name / @name placeholders.
+type QueryExplainRequest_Params struct {
+	union json.RawMessage
+}
+
+// QueryExportFileRequest defines model for QueryExportFileRequest.
+type QueryExportFileRequest struct {
+	Format QueryExportFormat `json:"format"`
+
+	// Header Write the column names first (CSV, TSV and Markdown). Defaults to true.
+	Header *bool `json:"header,omitempty"`
+
+	// Null Text written for NULL cells in CSV, TSV and Markdown
+	Null      *string `json:"null,omitempty"`
+	Overwrite *bool   `json:"overwrite,omitempty"`
+
+	// Path Absolute path on the server's filesystem
+	Path string `json:"path"`
+
+	// Statement Zero-based statement index of a script job. Defaults to the last statement.
+	Statement *int `json:"statement,omitempty"`
+}
+
+// QueryExportFileResponse defines model for QueryExportFileResponse.
+type QueryExportFileResponse struct {
+	Bytes    int64  `json:"bytes"`
+	Path     string `json:"path"`
+	RowCount int    `json:"rowCount"`
+}
+
+// QueryExportFormat defines model for QueryExportFormat.
+type QueryExportFormat string
+
+// QueryHistoryEntry defines model for QueryHistoryEntry.
+type QueryHistoryEntry struct {
+	CreatedAt  time.Time `json:"createdAt"`
+	DurationMs int64     `json:"durationMs"`
+	Error      *string   `json:"error,omitempty"`
+	FinishedAt time.Time `json:"finishedAt"`
+	JobId      string    `json:"jobId"`
+
+	// Params Parameter values as submitted
+	Params *QueryHistoryEntry_Params `json:"params,omitempty"`
+
+	// Query Query text as submitted, before named parameters were bound
+	Query        string `json:"query"`
+	ResourceName string `json:"resourceName"`
+
+	// RowCount Rows returned. Scripts report their last successful statement.
+	RowCount     *int               `json:"rowCount,omitempty"`
+	RowsAffected *int64             `json:"rowsAffected,omitempty"`
+	Script       bool               `json:"script"`
+	SessionId    *string            `json:"sessionId,omitempty"`
+	StartedAt    *time.Time         `json:"startedAt,omitempty"`
+	Status       QueryHistoryStatus `json:"status"`
+}
+
+// QueryHistoryEntryParams0 defines model for .
+type QueryHistoryEntryParams0 map[string]interface{}
+
+// QueryHistoryEntryParams1 defines model for .
+type QueryHistoryEntryParams1 = []interface{}
+
+// QueryHistoryEntry_Params Parameter values as submitted
+type QueryHistoryEntry_Params struct {
+	union json.RawMessage
+}
+
+// QueryHistoryResponse defines model for QueryHistoryResponse.
+type QueryHistoryResponse struct {
+	Entries []QueryHistoryEntry `json:"entries"`
+
+	// Total Number of matching entries across all pages
+	Total int `json:"total"`
+}
+
+// QueryHistoryStatus defines model for QueryHistoryStatus.
+type QueryHistoryStatus string
+
 // QueryJobStatusResponse defines model for QueryJobStatusResponse.
 type QueryJobStatusResponse struct {
-	DurationMs   *int64                       `json:"durationMs,omitempty"`
-	Error        *string                      `json:"error,omitempty"`
-	FinishedAt   *time.Time                   `json:"finishedAt,omitempty"`
-	JobId        string                       `json:"jobId"`
-	ResourceName string                       `json:"resourceName"`
-	Status       QueryJobStatusResponseStatus `json:"status"`
-	Stored       bool                         `json:"stored"`
+	// DatabaseError Structured fields of an error the database reported. Fields the engine does not report are omitted.
+	DatabaseError *DatabaseError `json:"databaseError,omitempty"`
+
+	// DroppedNotices Notices received after the first 100, counted but not kept
+	DroppedNotices *int       `json:"droppedNotices,omitempty"`
+	DurationMs     *int64     `json:"durationMs,omitempty"`
+	Error          *string    `json:"error,omitempty"`
+	FinishedAt     *time.Time `json:"finishedAt,omitempty"`
+	JobId          string     `json:"jobId"`
+
+	// Notices Notices the database sent while the job ran, in order; only the first 100 are kept
+	Notices *[]QueryNotice `json:"notices,omitempty"`
+
+	// QueuePosition 1-based position in the resource's queue when status is queued
+	QueuePosition *int   `json:"queuePosition,omitempty"`
+	ResourceName  string `json:"resourceName"`
+
+	// SessionId Query session the job ran in
+	SessionId *string `json:"sessionId,omitempty"`
+
+	// Statements Per-statement progress of a script job
+	Statements *[]QueryStatementStatus      `json:"statements,omitempty"`
+	Status     QueryJobStatusResponseStatus `json:"status"`
+	Stored     bool                         `json:"stored"`
+
+	// TxState Transaction state of a query session connection. Sessions left idle in a transaction are rolled back.
+	TxState *QueryTxState `json:"txState,omitempty"`
 }
 
 // QueryJobStatusResponseStatus defines model for QueryJobStatusResponse.Status.
 type QueryJobStatusResponseStatus string
 
+// QueryNotice A notice or warning the database sent while a job ran, such as RAISE NOTICE output
+type QueryNotice struct {
+	// Code SQLSTATE code of the notice
+	Code    string `json:"code"`
+	Message string `json:"message"`
+
+	// Severity Severity reported by the database, such as NOTICE, WARNING or INFO
+	Severity string `json:"severity"`
+
+	// Statement Index of the script statement that raised the notice
+	Statement *int `json:"statement,omitempty"`
+}
+
+// QueryPlanNode A plan operator. Values the engine does not report are omitted.
+type QueryPlanNode struct {
+	ActualRows   *float64        `json:"actualRows,omitempty"`
+	ActualTimeMs *float64        `json:"actualTimeMs,omitempty"`
+	Children     []QueryPlanNode `json:"children"`
+
+	// Detail Engine-specific summary such as filters, join conditions or the index used
+	Detail        *string  `json:"detail,omitempty"`
+	EstimatedRows *float64 `json:"estimatedRows,omitempty"`
+	Loops         *int64   `json:"loops,omitempty"`
+	NodeType      string   `json:"nodeType"`
+	Relation      *string  `json:"relation,omitempty"`
+	StartupCost   *float64 `json:"startupCost,omitempty"`
+	TotalCost     *float64 `json:"totalCost,omitempty"`
+}
+
+// QueryPlanResponse defines model for QueryPlanResponse.
+type QueryPlanResponse struct {
+	Analyzed        bool     `json:"analyzed"`
+	ExecutionTimeMs *float64 `json:"executionTimeMs,omitempty"`
+
+	// Nodes Top-level plan nodes; most engines return exactly one
+	Nodes          []QueryPlanNode `json:"nodes"`
+	PlanningTimeMs *float64        `json:"planningTimeMs,omitempty"`
+}
+
 // QueryResultColumn defines model for QueryResultColumn.
 type QueryResultColumn struct {
 	Name string `json:"name"`
@@ -293,27 +835,96 @@ type QueryResultColumn struct {
 
 // QueryResultResponse defines model for QueryResultResponse.
 type QueryResultResponse struct {
-	Columns      []QueryResultColumn `json:"columns"`
-	RowCount     int                 `json:"rowCount"`
-	Rows         [][]interface{}     `json:"rows"`
-	RowsAffected *int                `json:"rowsAffected"`
-	Truncated    bool                `json:"truncated"`
+	Columns []QueryResultColumn `json:"columns"`
+
+	// CommandTag Completion tag of a data-modifying statement, such as INSERT 0 5 or UPDATE 3; returned rows are the rows it affected
+	CommandTag *string `json:"commandTag,omitempty"`
+
+	// HasMore Set while a lazy result's cursor is open; rowCount counts only rows fetched so far and later pages fetch more
+	HasMore *bool `json:"hasMore,omitempty"`
+
+	// Notices Notices the database sent while the job ran, limited to the requested statement of a script
+	Notices *[]QueryNotice `json:"notices,omitempty"`
+
+	// Partial Set while the job is still running; rows hold the first rows fetched so far and rowCount counts every row fetched so far
+	Partial *bool `json:"partial,omitempty"`
+
+	// RowCount Number of rows in the view; counts only matching rows when filters are applied
+	RowCount int `json:"rowCount"`
+
+	// Rows Rows of typed cells. A cell is null, a string, a boolean, a number (integers only within the exact float64 range), or a QueryTaggedCell.
+	Rows         [][]interface{} `json:"rows"`
+	RowsAffected *int            `json:"rowsAffected"`
+	Truncated    bool            `json:"truncated"`
+}
+
+// QuerySession defines model for QuerySession.
+type QuerySession struct {
+	ActiveJobId  *string   `json:"activeJobId"`
+	CreatedAt    time.Time `json:"createdAt"`
+	LastUsedAt   time.Time `json:"lastUsedAt"`
+	ResourceName string    `json:"resourceName"`
+	SessionId    string    `json:"sessionId"`
+
+	// TxState Transaction state of a query session connection. Sessions left idle in a transaction are rolled back.
+	TxState QueryTxState `json:"txState"`
+}
+
+// QueryStatementStatus defines model for QueryStatementStatus.
+type QueryStatementStatus struct {
+	// CommandTag Completion tag of a data-modifying statement, such as INSERT 0 5 or UPDATE 3
+	CommandTag *string `json:"commandTag,omitempty"`
+
+	// DatabaseError Structured fields of an error the database reported. Fields the engine does not report are omitted.
+	DatabaseError *DatabaseError `json:"databaseError,omitempty"`
+	DurationMs    int64          `json:"durationMs"`
+	Error         *string        `json:"error,omitempty"`
+	Index         int            `json:"index"`
+
+	// Line 1-based line of the script on which the statement starts
+	Line         int                        `json:"line"`
+	RowCount     *int                       `json:"rowCount"`
+	RowsAffected *int                       `json:"rowsAffected"`
+	Status       QueryStatementStatusStatus `json:"status"`
 }
 
+// QueryStatementStatusStatus defines model for QueryStatementStatus.Status.
+type QueryStatementStatusStatus string
+
+// QueryTxState Transaction state of a query session connection. Sessions left idle in a transaction are rolled back.
+type QueryTxState string
+
 // Resource defines model for Resource.
 type Resource struct {
 	// AutoLimitRows Default SELECT auto-limit page size; null disables auto-limit
-	AutoLimitRows *int            `json:"autoLimitRows"`
-	Database      string          `json:"database"`
-	Host          *string         `json:"host"`
-	Name          string          `json:"name"`
-	Password      *PasswordConfig `json:"password,omitempty"`
-	Port          *int            `json:"port"`
-	Tls           *TlsConfig      `json:"tls,omitempty"`
-	Type          string          `json:"type"`
-	Username      *string         `json:"username"`
+	AutoLimitRows *int   `json:"autoLimitRows"`
+	Database      string `json:"database"`
+
+	// DestructiveGuard What happens to UPDATE or DELETE without WHERE, DROP, TRUNCATE and ALTER ... DROP COLUMN.
+	// warn (default) runs them and lists the risks in the exec response; confirm refuses them until the request carries a confirmation token.
+	DestructiveGuard *ResourceDestructiveGuard `json:"destructiveGuard,omitempty"`
+	Host             *string                   `json:"host"`
+
+	// MaxConcurrentQueries Jobs run at once on the resource; further jobs wait in a FIFO queue. Defaults to 1 for DuckDB and 4 otherwise; session jobs are not queued
+	MaxConcurrentQueries *int            `json:"maxConcurrentQueries"`
+	Name                 string          `json:"name"`
+	Password             *PasswordConfig `json:"password,omitempty"`
+	Port                 *int            `json:"port"`
+
+	// QueryTimeout Milliseconds a query may run before it is canceled; on PostgreSQL also the connection's statement_timeout
+	QueryTimeout *int `json:"queryTimeout"`
+
+	// ReadOnly Open connections read-only and refuse statements classified as writes with error code read_only_resource
+	ReadOnly *bool      `json:"readOnly,omitempty"`
+	Tls      *TlsConfig `json:"tls,omitempty"`
+	Type     string     `json:"type"`
+	Username *string    `json:"username"`
 }
 
+// ResourceDestructiveGuard What happens to UPDATE or DELETE without WHERE, DROP, TRUNCATE and ALTER ... DROP COLUMN.
+// warn (default) runs them and lists the risks in the exec response; confirm refuses them until the request carries a confirmation token.
+type ResourceDestructiveGuard string
+
 // ResourceConnectRequest defines model for ResourceConnectRequest.
 type ResourceConnectRequest struct {
 	ResourceName string `json:"resourceName"`
@@ -333,6 +944,21 @@ type ResourcesResponse struct {
 	Resources []Resource `json:"resources"`
 }
 
+// RolePrivilege defines model for RolePrivilege.
+type RolePrivilege struct {
+	GrantOption bool   `json:"grantOption"`
+	Privilege   string `json:"privilege"`
+}
+
+// RolePrivilegesResponse defines model for RolePrivilegesResponse.
+type RolePrivilegesResponse struct {
+	Objects []ObjectPrivileges `json:"objects"`
+	Role    string             `json:"role"`
+
+	// Superuser Superusers pass every privilege check, so objects lists every object
+	Superuser bool `json:"superuser"`
+}
+
 // SchemaNode defines model for SchemaNode.
 type SchemaNode struct {
 	Attributes SchemaNodeAttributes `json:"attributes"`
@@ -349,7 +975,43 @@ type SchemaNodeType string
 type SchemaNodeAttributes struct {
 	Engine    string `json:"engine"`
 	IsDefault bool   `json:"isDefault"`
-	Resource  string `json:"resource"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges *[]Privilege `json:"privileges,omitempty"`
+	Resource   string       `json:"resource"`
+}
+
+// SequenceNode defines model for SequenceNode.
+type SequenceNode struct {
+	Attributes SequenceNodeAttributes `json:"attributes"`
+	Edges      map[string]NodeEdge    `json:"edges"`
+	Id         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Type       SequenceNodeType       `json:"type"`
+}
+
+// SequenceNodeType defines model for SequenceNode.Type.
+type SequenceNodeType string
+
+// SequenceNodeAttributes defines model for SequenceNodeAttributes.
+type SequenceNodeAttributes struct {
+	// CurrentValue Last value the sequence handed out; absent before first use
+	CurrentValue  *int64  `json:"currentValue,omitempty"`
+	Cycle         bool    `json:"cycle"`
+	DataType      string  `json:"dataType"`
+	Increment     int64   `json:"increment"`
+	MaxValue      int64   `json:"maxValue"`
+	MinValue      int64   `json:"minValue"`
+	OwnedByColumn *string `json:"ownedByColumn,omitempty"`
+
+	// OwnedByTable Table of the column the sequence feeds, such as a serial or identity column
+	OwnedByTable *string `json:"ownedByTable,omitempty"`
+	Resource     string  `json:"resource"`
+	SequenceName string  `json:"sequenceName"`
+	StartValue   int64   `json:"startValue"`
 }
 
 // TableNode defines model for TableNode.
@@ -367,11 +1029,29 @@ type TableNodeType string
 // TableNodeAttributes defines model for TableNodeAttributes.
 type TableNodeAttributes struct {
 	Definition *string `json:"definition,omitempty"`
-	Resource   string  `json:"resource"`
-	Table      string  `json:"table"`
-	TableType  string  `json:"tableType"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// PartitionBound Set on partitions, such as "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')"
+	PartitionBound *string `json:"partitionBound,omitempty"`
+
+	// PartitionKey Partition key columns or expressions, such as "created_at"
+	PartitionKey *string `json:"partitionKey,omitempty"`
+
+	// PartitionStrategy Set on partitioned tables
+	PartitionStrategy *TableNodeAttributesPartitionStrategy `json:"partitionStrategy,omitempty"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges *[]Privilege `json:"privileges,omitempty"`
+	Resource   string       `json:"resource"`
+	Table      string       `json:"table"`
+	TableType  string       `json:"tableType"`
 }
 
+// TableNodeAttributesPartitionStrategy Set on partitioned tables
+type TableNodeAttributesPartitionStrategy string
+
 // TlsConfig defines model for TlsConfig.
 type TlsConfig struct {
 	// CaCertPath CA certificate path
@@ -413,6 +1093,51 @@ type TriggerNodeAttributes struct {
 	TriggerName  string    `json:"triggerName"`
 }
 
+// TypeField defines model for TypeField.
+type TypeField struct {
+	DataType string `json:"dataType"`
+	Name     string `json:"name"`
+}
+
+// TypeNode defines model for TypeNode.
+type TypeNode struct {
+	Attributes TypeNodeAttributes  `json:"attributes"`
+	Edges      map[string]NodeEdge `json:"edges"`
+	Id         string              `json:"id"`
+	Name       string              `json:"name"`
+	Type       TypeNodeType        `json:"type"`
+}
+
+// TypeNodeType defines model for TypeNode.Type.
+type TypeNodeType string
+
+// TypeNodeAttributes defines model for TypeNodeAttributes.
+type TypeNodeAttributes struct {
+	// BaseType Type a domain is based on
+	BaseType *string `json:"baseType,omitempty"`
+
+	// Constraints Domain CHECK constraints, such as "CHECK (VALUE > 0)"
+	Constraints *[]string `json:"constraints,omitempty"`
+
+	// DefaultValue Domain default
+	DefaultValue *string `json:"defaultValue,omitempty"`
+
+	// Fields Composite type attributes
+	Fields *[]TypeField           `json:"fields,omitempty"`
+	Kind   TypeNodeAttributesKind `json:"kind"`
+
+	// Labels Enum labels in sort order
+	Labels *[]string `json:"labels,omitempty"`
+
+	// NotNull Whether a domain rejects nulls
+	NotNull  bool   `json:"notNull"`
+	Resource string `json:"resource"`
+	TypeName string `json:"typeName"`
+}
+
+// TypeNodeAttributesKind defines model for TypeNodeAttributes.Kind.
+type TypeNodeAttributesKind string
+
 // ViewNode defines model for ViewNode.
 type ViewNode struct {
 	Attributes ViewNodeAttributes  `json:"attributes"`
@@ -428,29 +1153,93 @@ type ViewNodeType string
 // ViewNodeAttributes defines model for ViewNodeAttributes.
 type ViewNodeAttributes struct {
 	Definition *string `json:"definition,omitempty"`
-	Resource   string  `json:"resource"`
-	Table      string  `json:"table"`
-	TableType  string  `json:"tableType"`
+
+	// Owner Role owning the object (Postgres)
+	Owner *string `json:"owner,omitempty"`
+
+	// Privileges Grants on the object, including the owner's defaults when none were made (Postgres)
+	Privileges *[]Privilege `json:"privileges,omitempty"`
+	Resource   string       `json:"resource"`
+	Table      string       `json:"table"`
+	TableType  string       `json:"tableType"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse = ErrorPayload
 
-// GetQueryResultParams defines parameters for GetQueryResult.
-type GetQueryResultParams struct {
+// GetQueryHistoryParams defines parameters for GetQueryHistory.
+type GetQueryHistoryParams struct {
+	// Q Whitespace-separated terms that must all appear in the query text or error message, ignoring case
+	Q        *string `form:"q,omitempty" json:"q,omitempty"`
+	Resource *string `form:"resource,omitempty" json:"resource,omitempty"`
+
+	// Status Keep jobs with any of these statuses
+	Status *[]QueryHistoryStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Since Keep jobs that finished at or after this time
+	Since *time.Time `form:"since,omitempty" json:"since,omitempty"`
+
+	// Until Keep jobs that finished before this time
+	Until *time.Time `form:"until,omitempty" json:"until,omitempty"`
+
+	// Limit Page size. Defaults to 50, at most 500.
 	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
 }
 
-// GetNodesParams defines parameters for GetNodes.
-type GetNodesParams struct {
-	// NodeId Optional repeated parameter limiting the nodes returned
-	NodeId *[]string `form:"nodeId,omitempty" json:"nodeId,omitempty"`
-}
+// ExportQueryResultParams defines parameters for ExportQueryResult.
+type ExportQueryResultParams struct {
+	Format QueryExportFormat `form:"format" json:"format"`
 
-// ExecQueryJSONRequestBody defines body for ExecQuery for application/json ContentType.
+	// Header Write the column names first (CSV, TSV and Markdown). Defaults to true.
+	Header *bool `form:"header,omitempty" json:"header,omitempty"`
+
+	// Null Text written for NULL cells in CSV, TSV and Markdown
+	Null *string `form:"null,omitempty" json:"null,omitempty"`
+
+	// Statement Zero-based statement index of a script job. Defaults to the last statement.
+	Statement *int `form:"statement,omitempty" json:"statement,omitempty"`
+}
+
+// GetQueryResultParams defines parameters for GetQueryResult.
+type GetQueryResultParams struct {
+	Limit  *int `form:"limit,omitempty" json:"limit,omitempty"`
+	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Statement Zero-based statement index of a script job. Defaults to the last statement.
+	Statement *int `form:"statement,omitempty" json:"statement,omitempty"`
+
+	// Sort Sort by a column as column[:asc|desc]. Repeat to break ties. Nulls sort last ascending and first descending.
+	Sort *[]string `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Filter Keep rows matching column:op[:value], where op is eq, contains (case-insensitive), isnull, notnull, gt, gte, lt or lte (numeric). Repeated filters must all match.
+	Filter *[]string `form:"filter,omitempty" json:"filter,omitempty"`
+}
+
+// GetNodesParams defines parameters for GetNodes.
+type GetNodesParams struct {
+	// NodeId Optional repeated parameter limiting the nodes returned
+	NodeId *[]string `form:"nodeId,omitempty" json:"nodeId,omitempty"`
+}
+
+// GetPrivilegesParams defines parameters for GetPrivileges.
+type GetPrivilegesParams struct {
+	// Role Role to check; defaults to the role the resource connects as
+	Role *string `form:"role,omitempty" json:"role,omitempty"`
+
+	// Schema Only list the schema and the objects in it; defaults to every schema
+	Schema *string `form:"schema,omitempty" json:"schema,omitempty"`
+}
+
+// ExecQueryJSONRequestBody defines body for ExecQuery for application/json ContentType.
 type ExecQueryJSONRequestBody = QueryExecRequest
 
+// ExplainQueryJSONRequestBody defines body for ExplainQuery for application/json ContentType.
+type ExplainQueryJSONRequestBody = QueryExplainRequest
+
+// ExportQueryResultToFileJSONRequestBody defines body for ExportQueryResultToFile for application/json ContentType.
+type ExportQueryResultToFileJSONRequestBody = QueryExportFileRequest
+
 // ConnectResourceJSONRequestBody defines body for ConnectResource for application/json ContentType.
 type ConnectResourceJSONRequestBody = ResourceConnectRequest
 
@@ -566,6 +1355,62 @@ func (t *Node) MergeViewNode(v ViewNode) error {
 	return err
 }
 
+// AsMatviewNode returns the union data inside the Node as a MatviewNode
+func (t Node) AsMatviewNode() (MatviewNode, error) {
+	var body MatviewNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromMatviewNode overwrites any union data inside the Node as the provided MatviewNode
+func (t *Node) FromMatviewNode(v MatviewNode) error {
+	v.Type = "matview"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeMatviewNode performs a merge with any union data inside the Node, using the provided MatviewNode
+func (t *Node) MergeMatviewNode(v MatviewNode) error {
+	v.Type = "matview"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsForeignTableNode returns the union data inside the Node as a ForeignTableNode
+func (t Node) AsForeignTableNode() (ForeignTableNode, error) {
+	var body ForeignTableNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromForeignTableNode overwrites any union data inside the Node as the provided ForeignTableNode
+func (t *Node) FromForeignTableNode(v ForeignTableNode) error {
+	v.Type = "foreign_table"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeForeignTableNode performs a merge with any union data inside the Node, using the provided ForeignTableNode
+func (t *Node) MergeForeignTableNode(v ForeignTableNode) error {
+	v.Type = "foreign_table"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 // AsColumnNode returns the union data inside the Node as a ColumnNode
 func (t Node) AsColumnNode() (ColumnNode, error) {
 	var body ColumnNode
@@ -678,6 +1523,146 @@ func (t *Node) MergeTriggerNode(v TriggerNode) error {
 	return err
 }
 
+// AsFunctionNode returns the union data inside the Node as a FunctionNode
+func (t Node) AsFunctionNode() (FunctionNode, error) {
+	var body FunctionNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromFunctionNode overwrites any union data inside the Node as the provided FunctionNode
+func (t *Node) FromFunctionNode(v FunctionNode) error {
+	v.Type = "function"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeFunctionNode performs a merge with any union data inside the Node, using the provided FunctionNode
+func (t *Node) MergeFunctionNode(v FunctionNode) error {
+	v.Type = "function"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsProcedureNode returns the union data inside the Node as a ProcedureNode
+func (t Node) AsProcedureNode() (ProcedureNode, error) {
+	var body ProcedureNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromProcedureNode overwrites any union data inside the Node as the provided ProcedureNode
+func (t *Node) FromProcedureNode(v ProcedureNode) error {
+	v.Type = "procedure"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeProcedureNode performs a merge with any union data inside the Node, using the provided ProcedureNode
+func (t *Node) MergeProcedureNode(v ProcedureNode) error {
+	v.Type = "procedure"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsSequenceNode returns the union data inside the Node as a SequenceNode
+func (t Node) AsSequenceNode() (SequenceNode, error) {
+	var body SequenceNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromSequenceNode overwrites any union data inside the Node as the provided SequenceNode
+func (t *Node) FromSequenceNode(v SequenceNode) error {
+	v.Type = "sequence"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeSequenceNode performs a merge with any union data inside the Node, using the provided SequenceNode
+func (t *Node) MergeSequenceNode(v SequenceNode) error {
+	v.Type = "sequence"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsTypeNode returns the union data inside the Node as a TypeNode
+func (t Node) AsTypeNode() (TypeNode, error) {
+	var body TypeNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromTypeNode overwrites any union data inside the Node as the provided TypeNode
+func (t *Node) FromTypeNode(v TypeNode) error {
+	v.Type = "type"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeTypeNode performs a merge with any union data inside the Node, using the provided TypeNode
+func (t *Node) MergeTypeNode(v TypeNode) error {
+	v.Type = "type"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsExtensionNode returns the union data inside the Node as a ExtensionNode
+func (t Node) AsExtensionNode() (ExtensionNode, error) {
+	var body ExtensionNode
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromExtensionNode overwrites any union data inside the Node as the provided ExtensionNode
+func (t *Node) FromExtensionNode(v ExtensionNode) error {
+	v.Type = "extension"
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeExtensionNode performs a merge with any union data inside the Node, using the provided ExtensionNode
+func (t *Node) MergeExtensionNode(v ExtensionNode) error {
+	v.Type = "extension"
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
 func (t Node) Discriminator() (string, error) {
 	var discriminator struct {
 		Discriminator string `json:"type"`
@@ -698,14 +1683,28 @@ func (t Node) ValueByDiscriminator() (interface{}, error) {
 		return t.AsConstraintNode()
 	case "database":
 		return t.AsDatabaseNode()
+	case "extension":
+		return t.AsExtensionNode()
+	case "foreign_table":
+		return t.AsForeignTableNode()
+	case "function":
+		return t.AsFunctionNode()
 	case "index":
 		return t.AsIndexNode()
+	case "matview":
+		return t.AsMatviewNode()
+	case "procedure":
+		return t.AsProcedureNode()
 	case "schema":
 		return t.AsSchemaNode()
+	case "sequence":
+		return t.AsSequenceNode()
 	case "table":
 		return t.AsTableNode()
 	case "trigger":
 		return t.AsTriggerNode()
+	case "type":
+		return t.AsTypeNode()
 	case "view":
 		return t.AsViewNode()
 	default:
@@ -785,6 +1784,130 @@ func (t *QueryExecRequest_Params) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// AsQueryExplainRequestParams0 returns the union data inside the QueryExplainRequest_Params as a QueryExplainRequestParams0
+func (t QueryExplainRequest_Params) AsQueryExplainRequestParams0() (QueryExplainRequestParams0, error) {
+	var body QueryExplainRequestParams0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromQueryExplainRequestParams0 overwrites any union data inside the QueryExplainRequest_Params as the provided QueryExplainRequestParams0
+func (t *QueryExplainRequest_Params) FromQueryExplainRequestParams0(v QueryExplainRequestParams0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeQueryExplainRequestParams0 performs a merge with any union data inside the QueryExplainRequest_Params, using the provided QueryExplainRequestParams0
+func (t *QueryExplainRequest_Params) MergeQueryExplainRequestParams0(v QueryExplainRequestParams0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsQueryExplainRequestParams1 returns the union data inside the QueryExplainRequest_Params as a QueryExplainRequestParams1
+func (t QueryExplainRequest_Params) AsQueryExplainRequestParams1() (QueryExplainRequestParams1, error) {
+	var body QueryExplainRequestParams1
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromQueryExplainRequestParams1 overwrites any union data inside the QueryExplainRequest_Params as the provided QueryExplainRequestParams1
+func (t *QueryExplainRequest_Params) FromQueryExplainRequestParams1(v QueryExplainRequestParams1) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeQueryExplainRequestParams1 performs a merge with any union data inside the QueryExplainRequest_Params, using the provided QueryExplainRequestParams1
+func (t *QueryExplainRequest_Params) MergeQueryExplainRequestParams1(v QueryExplainRequestParams1) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t QueryExplainRequest_Params) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *QueryExplainRequest_Params) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
+// AsQueryHistoryEntryParams0 returns the union data inside the QueryHistoryEntry_Params as a QueryHistoryEntryParams0
+func (t QueryHistoryEntry_Params) AsQueryHistoryEntryParams0() (QueryHistoryEntryParams0, error) {
+	var body QueryHistoryEntryParams0
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromQueryHistoryEntryParams0 overwrites any union data inside the QueryHistoryEntry_Params as the provided QueryHistoryEntryParams0
+func (t *QueryHistoryEntry_Params) FromQueryHistoryEntryParams0(v QueryHistoryEntryParams0) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeQueryHistoryEntryParams0 performs a merge with any union data inside the QueryHistoryEntry_Params, using the provided QueryHistoryEntryParams0
+func (t *QueryHistoryEntry_Params) MergeQueryHistoryEntryParams0(v QueryHistoryEntryParams0) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+// AsQueryHistoryEntryParams1 returns the union data inside the QueryHistoryEntry_Params as a QueryHistoryEntryParams1
+func (t QueryHistoryEntry_Params) AsQueryHistoryEntryParams1() (QueryHistoryEntryParams1, error) {
+	var body QueryHistoryEntryParams1
+	err := json.Unmarshal(t.union, &body)
+	return body, err
+}
+
+// FromQueryHistoryEntryParams1 overwrites any union data inside the QueryHistoryEntry_Params as the provided QueryHistoryEntryParams1
+func (t *QueryHistoryEntry_Params) FromQueryHistoryEntryParams1(v QueryHistoryEntryParams1) error {
+	b, err := json.Marshal(v)
+	t.union = b
+	return err
+}
+
+// MergeQueryHistoryEntryParams1 performs a merge with any union data inside the QueryHistoryEntry_Params, using the provided QueryHistoryEntryParams1
+func (t *QueryHistoryEntry_Params) MergeQueryHistoryEntryParams1(v QueryHistoryEntryParams1) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := runtime.JSONMerge(t.union, b)
+	t.union = merged
+	return err
+}
+
+func (t QueryHistoryEntry_Params) MarshalJSON() ([]byte, error) {
+	b, err := t.union.MarshalJSON()
+	return b, err
+}
+
+func (t *QueryHistoryEntry_Params) UnmarshalJSON(b []byte) error {
+	err := t.union.UnmarshalJSON(b)
+	return err
+}
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -864,17 +1987,33 @@ type ClientInterface interface {
 	// GetHealth request
 	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetQueryHistory request
+	GetQueryHistory(ctx context.Context, params *GetQueryHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ExecQueryWithBody request with any body
 	ExecQueryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	ExecQuery(ctx context.Context, body ExecQueryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ExplainQueryWithBody request with any body
+	ExplainQueryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ExplainQuery(ctx context.Context, body ExplainQueryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetQueryStatus request
 	GetQueryStatus(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// CancelQuery request
 	CancelQuery(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ExportQueryResult request
+	ExportQueryResult(ctx context.Context, jobId string, params *ExportQueryResultParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportQueryResultToFileWithBody request with any body
+	ExportQueryResultToFileWithBody(ctx context.Context, jobId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ExportQueryResultToFile(ctx context.Context, jobId string, body ExportQueryResultToFileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetQueryResult request
 	GetQueryResult(ctx context.Context, jobId string, params *GetQueryResultParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -888,6 +2027,18 @@ type ClientInterface interface {
 
 	// GetNodes request
 	GetNodes(ctx context.Context, resourceName string, params *GetNodesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPrivileges request
+	GetPrivileges(ctx context.Context, resourceName string, params *GetPrivilegesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// OpenQuerySession request
+	OpenQuerySession(ctx context.Context, resourceName string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CloseQuerySession request
+	CloseQuerySession(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetQuerySession request
+	GetQuerySession(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) StreamEvents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -914,6 +2065,18 @@ func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetQueryHistory(ctx context.Context, params *GetQueryHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQueryHistoryRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ExecQueryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewExecQueryRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -938,8 +2101,8 @@ func (c *Client) ExecQuery(ctx context.Context, body ExecQueryJSONRequestBody, r
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetQueryStatus(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetQueryStatusRequest(c.Server, jobId)
+func (c *Client) ExplainQueryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExplainQueryRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -950,8 +2113,8 @@ func (c *Client) GetQueryStatus(ctx context.Context, jobId string, reqEditors ..
 	return c.Client.Do(req)
 }
 
-func (c *Client) CancelQuery(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCancelQueryRequest(c.Server, jobId)
+func (c *Client) ExplainQuery(ctx context.Context, body ExplainQueryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExplainQueryRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -962,8 +2125,8 @@ func (c *Client) CancelQuery(ctx context.Context, jobId string, reqEditors ...Re
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetQueryResult(ctx context.Context, jobId string, params *GetQueryResultParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetQueryResultRequest(c.Server, jobId, params)
+func (c *Client) GetQueryStatus(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQueryStatusRequest(c.Server, jobId)
 	if err != nil {
 		return nil, err
 	}
@@ -974,8 +2137,8 @@ func (c *Client) GetQueryResult(ctx context.Context, jobId string, params *GetQu
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListResources(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListResourcesRequest(c.Server)
+func (c *Client) CancelQuery(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelQueryRequest(c.Server, jobId)
 	if err != nil {
 		return nil, err
 	}
@@ -986,8 +2149,8 @@ func (c *Client) ListResources(ctx context.Context, reqEditors ...RequestEditorF
 	return c.Client.Do(req)
 }
 
-func (c *Client) ConnectResourceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewConnectResourceRequestWithBody(c.Server, contentType, body)
+func (c *Client) ExportQueryResult(ctx context.Context, jobId string, params *ExportQueryResultParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportQueryResultRequest(c.Server, jobId, params)
 	if err != nil {
 		return nil, err
 	}
@@ -998,8 +2161,8 @@ func (c *Client) ConnectResourceWithBody(ctx context.Context, contentType string
 	return c.Client.Do(req)
 }
 
-func (c *Client) ConnectResource(ctx context.Context, body ConnectResourceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewConnectResourceRequest(c.Server, body)
+func (c *Client) ExportQueryResultToFileWithBody(ctx context.Context, jobId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportQueryResultToFileRequestWithBody(c.Server, jobId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1010,8 +2173,8 @@ func (c *Client) ConnectResource(ctx context.Context, body ConnectResourceJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetNodes(ctx context.Context, resourceName string, params *GetNodesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetNodesRequest(c.Server, resourceName, params)
+func (c *Client) ExportQueryResultToFile(ctx context.Context, jobId string, body ExportQueryResultToFileJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportQueryResultToFileRequest(c.Server, jobId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -1022,117 +2185,124 @@ func (c *Client) GetNodes(ctx context.Context, resourceName string, params *GetN
 	return c.Client.Do(req)
 }
 
-// NewStreamEventsRequest generates requests for StreamEvents
-func NewStreamEventsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetQueryResult(ctx context.Context, jobId string, params *GetQueryResultParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQueryResultRequest(c.Server, jobId, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/events")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ListResources(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListResourcesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewGetHealthRequest generates requests for GetHealth
-func NewGetHealthRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) ConnectResourceWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConnectResourceRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/health")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ConnectResource(ctx context.Context, body ConnectResourceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewConnectResourceRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	return c.Client.Do(req)
 }
 
-// NewExecQueryRequest calls the generic ExecQuery builder with application/json body
-func NewExecQueryRequest(server string, body ExecQueryJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) GetNodes(ctx context.Context, resourceName string, params *GetNodesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNodesRequest(c.Server, resourceName, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewExecQueryRequestWithBody(server, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewExecQueryRequestWithBody generates requests for ExecQuery with any type of body
-func NewExecQueryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) GetPrivileges(ctx context.Context, resourceName string, params *GetPrivilegesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPrivilegesRequest(c.Server, resourceName, params)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/queries")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) OpenQuerySession(ctx context.Context, resourceName string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOpenQuerySessionRequest(c.Server, resourceName)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) CloseQuerySession(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCloseQuerySessionRequest(c.Server, resourceName, sessionId)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewGetQueryStatusRequest generates requests for GetQueryStatus
-func NewGetQueryStatusRequest(server string, jobId string) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+func (c *Client) GetQuerySession(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetQuerySessionRequest(c.Server, resourceName, sessionId)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewStreamEventsRequest generates requests for StreamEvents
+func NewStreamEventsRequest(server string) (*http.Request, error) {
+	var err error
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/queries/%s", pathParam0)
+	operationPath := fmt.Sprintf("/events")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1150,23 +2320,16 @@ func NewGetQueryStatusRequest(server string, jobId string) (*http.Request, error
 	return req, nil
 }
 
-// NewCancelQueryRequest generates requests for CancelQuery
-func NewCancelQueryRequest(server string, jobId string) (*http.Request, error) {
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/queries/%s/cancel", pathParam0)
+	operationPath := fmt.Sprintf("/health")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1176,7 +2339,7 @@ func NewCancelQueryRequest(server string, jobId string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1184,23 +2347,16 @@ func NewCancelQueryRequest(server string, jobId string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewGetQueryResultRequest generates requests for GetQueryResult
-func NewGetQueryResultRequest(server string, jobId string, params *GetQueryResultParams) (*http.Request, error) {
+// NewGetQueryHistoryRequest generates requests for GetQueryHistory
+func NewGetQueryHistoryRequest(server string, params *GetQueryHistoryParams) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/queries/%s/result", pathParam0)
+	operationPath := fmt.Sprintf("/history")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1213,6 +2369,86 @@ func NewGetQueryResultRequest(server string, jobId string, params *GetQueryResul
 	if params != nil {
 		queryValues := queryURL.Query()
 
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Resource != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "resource", runtime.ParamLocationQuery, *params.Resource); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Since != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since", runtime.ParamLocationQuery, *params.Since); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Until != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "until", runtime.ParamLocationQuery, *params.Until); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		if params.Limit != nil {
 
 			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
@@ -1256,11 +2492,426 @@ func NewGetQueryResultRequest(server string, jobId string, params *GetQueryResul
 	return req, nil
 }
 
-// NewListResourcesRequest generates requests for ListResources
-func NewListResourcesRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+// NewExecQueryRequest calls the generic ExecQuery builder with application/json body
+func NewExecQueryRequest(server string, body ExecQueryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExecQueryRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewExecQueryRequestWithBody generates requests for ExecQuery with any type of body
+func NewExecQueryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewExplainQueryRequest calls the generic ExplainQuery builder with application/json body
+func NewExplainQueryRequest(server string, body ExplainQueryJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExplainQueryRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewExplainQueryRequestWithBody generates requests for ExplainQuery with any type of body
+func NewExplainQueryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries/explain")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQueryStatusRequest generates requests for GetQueryStatus
+func NewGetQueryStatusRequest(server string, jobId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCancelQueryRequest generates requests for CancelQuery
+func NewCancelQueryRequest(server string, jobId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportQueryResultRequest generates requests for ExportQueryResult
+func NewExportQueryResultRequest(server string, jobId string, params *ExportQueryResultParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, params.Format); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Header != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "header", runtime.ParamLocationQuery, *params.Header); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Null != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "null", runtime.ParamLocationQuery, *params.Null); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Statement != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "statement", runtime.ParamLocationQuery, *params.Statement); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewExportQueryResultToFileRequest calls the generic ExportQueryResultToFile builder with application/json body
+func NewExportQueryResultToFileRequest(server string, jobId string, body ExportQueryResultToFileJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExportQueryResultToFileRequestWithBody(server, jobId, "application/json", bodyReader)
+}
+
+// NewExportQueryResultToFileRequestWithBody generates requests for ExportQueryResultToFile with any type of body
+func NewExportQueryResultToFileRequestWithBody(server string, jobId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries/%s/export", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetQueryResultRequest generates requests for GetQueryResult
+func NewGetQueryResultRequest(server string, jobId string, params *GetQueryResultParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "jobId", runtime.ParamLocationPath, jobId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/queries/%s/result", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Statement != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "statement", runtime.ParamLocationQuery, *params.Statement); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Filter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "filter", runtime.ParamLocationQuery, *params.Filter); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListResourcesRequest generates requests for ListResources
+func NewListResourcesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
@@ -1275,7 +2926,209 @@ func NewListResourcesRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewConnectResourceRequest calls the generic ConnectResource builder with application/json body
+func NewConnectResourceRequest(server string, body ConnectResourceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewConnectResourceRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewConnectResourceRequestWithBody generates requests for ConnectResource with any type of body
+func NewConnectResourceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resources/connect")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetNodesRequest generates requests for GetNodes
+func NewGetNodesRequest(server string, resourceName string, params *GetNodesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceName", runtime.ParamLocationPath, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resources/%s/nodes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.NodeId != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "nodeId", runtime.ParamLocationQuery, *params.NodeId); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPrivilegesRequest generates requests for GetPrivileges
+func NewGetPrivilegesRequest(server string, resourceName string, params *GetPrivilegesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceName", runtime.ParamLocationPath, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resources/%s/privileges", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Role != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "role", runtime.ParamLocationQuery, *params.Role); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Schema != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "schema", runtime.ParamLocationQuery, *params.Schema); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOpenQuerySessionRequest generates requests for OpenQuerySession
+func NewOpenQuerySessionRequest(server string, resourceName string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceName", runtime.ParamLocationPath, resourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/resources/%s/sessions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1283,27 +3136,30 @@ func NewListResourcesRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewConnectResourceRequest calls the generic ConnectResource builder with application/json body
-func NewConnectResourceRequest(server string, body ConnectResourceJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+// NewCloseQuerySessionRequest generates requests for CloseQuerySession
+func NewCloseQuerySessionRequest(server string, resourceName string, sessionId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "resourceName", runtime.ParamLocationPath, resourceName)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewConnectResourceRequestWithBody(server, "application/json", bodyReader)
-}
 
-// NewConnectResourceRequestWithBody generates requests for ConnectResource with any type of body
-func NewConnectResourceRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "sessionId", runtime.ParamLocationPath, sessionId)
+	if err != nil {
+		return nil, err
+	}
 
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/resources/connect")
+	operationPath := fmt.Sprintf("/resources/%s/sessions/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1313,18 +3169,16 @@ func NewConnectResourceRequestWithBody(server string, contentType string, body i
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewGetNodesRequest generates requests for GetNodes
-func NewGetNodesRequest(server string, resourceName string, params *GetNodesParams) (*http.Request, error) {
+// NewGetQuerySessionRequest generates requests for GetQuerySession
+func NewGetQuerySessionRequest(server string, resourceName string, sessionId string) (*http.Request, error) {
 	var err error
 
 	var pathParam0 string
@@ -1334,12 +3188,19 @@ func NewGetNodesRequest(server string, resourceName string, params *GetNodesPara
 		return nil, err
 	}
 
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "sessionId", runtime.ParamLocationPath, sessionId)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/resources/%s/nodes", pathParam0)
+	operationPath := fmt.Sprintf("/resources/%s/sessions/%s", pathParam0, pathParam1)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1349,28 +3210,6 @@ func NewGetNodesRequest(server string, resourceName string, params *GetNodesPara
 		return nil, err
 	}
 
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.NodeId != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "nodeId", runtime.ParamLocationQuery, *params.NodeId); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
-		queryURL.RawQuery = queryValues.Encode()
-	}
-
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1428,17 +3267,33 @@ type ClientWithResponsesInterface interface {
 	// GetHealthWithResponse request
 	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
 
+	// GetQueryHistoryWithResponse request
+	GetQueryHistoryWithResponse(ctx context.Context, params *GetQueryHistoryParams, reqEditors ...RequestEditorFn) (*GetQueryHistoryResponse, error)
+
 	// ExecQueryWithBodyWithResponse request with any body
 	ExecQueryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExecQueryResponse, error)
 
 	ExecQueryWithResponse(ctx context.Context, body ExecQueryJSONRequestBody, reqEditors ...RequestEditorFn) (*ExecQueryResponse, error)
 
+	// ExplainQueryWithBodyWithResponse request with any body
+	ExplainQueryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExplainQueryResponse, error)
+
+	ExplainQueryWithResponse(ctx context.Context, body ExplainQueryJSONRequestBody, reqEditors ...RequestEditorFn) (*ExplainQueryResponse, error)
+
 	// GetQueryStatusWithResponse request
 	GetQueryStatusWithResponse(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*GetQueryStatusResponse, error)
 
 	// CancelQueryWithResponse request
 	CancelQueryWithResponse(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*CancelQueryResponse, error)
 
+	// ExportQueryResultWithResponse request
+	ExportQueryResultWithResponse(ctx context.Context, jobId string, params *ExportQueryResultParams, reqEditors ...RequestEditorFn) (*ExportQueryResultResponse, error)
+
+	// ExportQueryResultToFileWithBodyWithResponse request with any body
+	ExportQueryResultToFileWithBodyWithResponse(ctx context.Context, jobId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExportQueryResultToFileResponse, error)
+
+	ExportQueryResultToFileWithResponse(ctx context.Context, jobId string, body ExportQueryResultToFileJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportQueryResultToFileResponse, error)
+
 	// GetQueryResultWithResponse request
 	GetQueryResultWithResponse(ctx context.Context, jobId string, params *GetQueryResultParams, reqEditors ...RequestEditorFn) (*GetQueryResultResponse, error)
 
@@ -1452,6 +3307,18 @@ type ClientWithResponsesInterface interface {
 
 	// GetNodesWithResponse request
 	GetNodesWithResponse(ctx context.Context, resourceName string, params *GetNodesParams, reqEditors ...RequestEditorFn) (*GetNodesResponse, error)
+
+	// GetPrivilegesWithResponse request
+	GetPrivilegesWithResponse(ctx context.Context, resourceName string, params *GetPrivilegesParams, reqEditors ...RequestEditorFn) (*GetPrivilegesResponse, error)
+
+	// OpenQuerySessionWithResponse request
+	OpenQuerySessionWithResponse(ctx context.Context, resourceName string, reqEditors ...RequestEditorFn) (*OpenQuerySessionResponse, error)
+
+	// CloseQuerySessionWithResponse request
+	CloseQuerySessionWithResponse(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*CloseQuerySessionResponse, error)
+
+	// GetQuerySessionWithResponse request
+	GetQuerySessionWithResponse(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*GetQuerySessionResponse, error)
 }
 
 type StreamEventsResponse struct {
@@ -1461,7 +3328,202 @@ type StreamEventsResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r StreamEventsResponse) Status() string {
+func (r StreamEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQueryHistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QueryHistoryResponse
+	JSON503      *ErrorPayload
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQueryHistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQueryHistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExecQueryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *QueryExecResponse
+	JSON403      *ErrorPayload
+	JSON404      *ErrorPayload
+	JSON428      *ErrorPayload
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExecQueryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExecQueryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExplainQueryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QueryPlanResponse
+	JSON400      *ErrorPayload
+	JSON403      *ErrorPayload
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExplainQueryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExplainQueryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetQueryStatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *QueryJobStatusResponse
+	JSON404      *ErrorPayload
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetQueryStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetQueryStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CancelQueryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON202      *struct {
+		Status string `json:"status"`
+	}
+	JSON404     *ErrorPayload
+	JSONDefault *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CancelQueryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CancelQueryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportQueryResultResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *ErrorPayload
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportQueryResultResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportQueryResultResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportQueryResultToFileResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *QueryExportFileResponse
+	JSON404      *ErrorPayload
+	JSON409      *ErrorPayload
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportQueryResultToFileResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1469,21 +3531,23 @@ func (r StreamEventsResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r StreamEventsResponse) StatusCode() int {
+func (r ExportQueryResultToFileResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetHealthResponse struct {
+type GetQueryResultResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *QueryResultResponse
+	JSON404      *ErrorPayload
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r GetHealthResponse) Status() string {
+func (r GetQueryResultResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1491,23 +3555,22 @@ func (r GetHealthResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetHealthResponse) StatusCode() int {
+func (r GetQueryResultResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ExecQueryResponse struct {
+type ListResourcesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON202      *QueryExecResponse
-	JSON404      *ErrorPayload
+	JSON200      *ResourcesResponse
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r ExecQueryResponse) Status() string {
+func (r ListResourcesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1515,23 +3578,24 @@ func (r ExecQueryResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ExecQueryResponse) StatusCode() int {
+func (r ListResourcesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetQueryStatusResponse struct {
+type ConnectResourceResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *QueryJobStatusResponse
+	JSON201      *ResourceConnectResult
 	JSON404      *ErrorPayload
+	JSON409      *ErrorPayload
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r GetQueryStatusResponse) Status() string {
+func (r ConnectResourceResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1539,25 +3603,23 @@ func (r GetQueryStatusResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetQueryStatusResponse) StatusCode() int {
+func (r ConnectResourceResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type CancelQueryResponse struct {
+type GetNodesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON202      *struct {
-		Status string `json:"status"`
-	}
-	JSON404     *ErrorPayload
-	JSONDefault *ErrorResponse
+	JSON200      *NodesResponse
+	JSON404      *ErrorPayload
+	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r CancelQueryResponse) Status() string {
+func (r GetNodesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1565,23 +3627,25 @@ func (r CancelQueryResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r CancelQueryResponse) StatusCode() int {
+func (r GetNodesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetQueryResultResponse struct {
+type GetPrivilegesResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *QueryResultResponse
+	JSON200      *RolePrivilegesResponse
+	JSON400      *ErrorPayload
 	JSON404      *ErrorPayload
+	JSON409      *ErrorPayload
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r GetQueryResultResponse) Status() string {
+func (r GetPrivilegesResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1589,22 +3653,24 @@ func (r GetQueryResultResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetQueryResultResponse) StatusCode() int {
+func (r GetPrivilegesResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ListResourcesResponse struct {
+type OpenQuerySessionResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *ResourcesResponse
+	JSON201      *QuerySession
+	JSON400      *ErrorPayload
+	JSON409      *ErrorPayload
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r ListResourcesResponse) Status() string {
+func (r OpenQuerySessionResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1612,24 +3678,23 @@ func (r ListResourcesResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListResourcesResponse) StatusCode() int {
+func (r OpenQuerySessionResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ConnectResourceResponse struct {
+type CloseQuerySessionResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON201      *ResourceConnectResult
 	JSON404      *ErrorPayload
 	JSON409      *ErrorPayload
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r ConnectResourceResponse) Status() string {
+func (r CloseQuerySessionResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1637,23 +3702,23 @@ func (r ConnectResourceResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ConnectResourceResponse) StatusCode() int {
+func (r CloseQuerySessionResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetNodesResponse struct {
+type GetQuerySessionResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *NodesResponse
+	JSON200      *QuerySession
 	JSON404      *ErrorPayload
 	JSONDefault  *ErrorResponse
 }
 
 // Status returns HTTPResponse.Status
-func (r GetNodesResponse) Status() string {
+func (r GetQuerySessionResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1661,7 +3726,7 @@ func (r GetNodesResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetNodesResponse) StatusCode() int {
+func (r GetQuerySessionResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -1686,6 +3751,15 @@ func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEdit
 	return ParseGetHealthResponse(rsp)
 }
 
+// GetQueryHistoryWithResponse request returning *GetQueryHistoryResponse
+func (c *ClientWithResponses) GetQueryHistoryWithResponse(ctx context.Context, params *GetQueryHistoryParams, reqEditors ...RequestEditorFn) (*GetQueryHistoryResponse, error) {
+	rsp, err := c.GetQueryHistory(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQueryHistoryResponse(rsp)
+}
+
 // ExecQueryWithBodyWithResponse request with arbitrary body returning *ExecQueryResponse
 func (c *ClientWithResponses) ExecQueryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExecQueryResponse, error) {
 	rsp, err := c.ExecQueryWithBody(ctx, contentType, body, reqEditors...)
@@ -1703,6 +3777,23 @@ func (c *ClientWithResponses) ExecQueryWithResponse(ctx context.Context, body Ex
 	return ParseExecQueryResponse(rsp)
 }
 
+// ExplainQueryWithBodyWithResponse request with arbitrary body returning *ExplainQueryResponse
+func (c *ClientWithResponses) ExplainQueryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExplainQueryResponse, error) {
+	rsp, err := c.ExplainQueryWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExplainQueryResponse(rsp)
+}
+
+func (c *ClientWithResponses) ExplainQueryWithResponse(ctx context.Context, body ExplainQueryJSONRequestBody, reqEditors ...RequestEditorFn) (*ExplainQueryResponse, error) {
+	rsp, err := c.ExplainQuery(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExplainQueryResponse(rsp)
+}
+
 // GetQueryStatusWithResponse request returning *GetQueryStatusResponse
 func (c *ClientWithResponses) GetQueryStatusWithResponse(ctx context.Context, jobId string, reqEditors ...RequestEditorFn) (*GetQueryStatusResponse, error) {
 	rsp, err := c.GetQueryStatus(ctx, jobId, reqEditors...)
@@ -1721,6 +3812,32 @@ func (c *ClientWithResponses) CancelQueryWithResponse(ctx context.Context, jobId
 	return ParseCancelQueryResponse(rsp)
 }
 
+// ExportQueryResultWithResponse request returning *ExportQueryResultResponse
+func (c *ClientWithResponses) ExportQueryResultWithResponse(ctx context.Context, jobId string, params *ExportQueryResultParams, reqEditors ...RequestEditorFn) (*ExportQueryResultResponse, error) {
+	rsp, err := c.ExportQueryResult(ctx, jobId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportQueryResultResponse(rsp)
+}
+
+// ExportQueryResultToFileWithBodyWithResponse request with arbitrary body returning *ExportQueryResultToFileResponse
+func (c *ClientWithResponses) ExportQueryResultToFileWithBodyWithResponse(ctx context.Context, jobId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExportQueryResultToFileResponse, error) {
+	rsp, err := c.ExportQueryResultToFileWithBody(ctx, jobId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportQueryResultToFileResponse(rsp)
+}
+
+func (c *ClientWithResponses) ExportQueryResultToFileWithResponse(ctx context.Context, jobId string, body ExportQueryResultToFileJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportQueryResultToFileResponse, error) {
+	rsp, err := c.ExportQueryResultToFile(ctx, jobId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportQueryResultToFileResponse(rsp)
+}
+
 // GetQueryResultWithResponse request returning *GetQueryResultResponse
 func (c *ClientWithResponses) GetQueryResultWithResponse(ctx context.Context, jobId string, params *GetQueryResultParams, reqEditors ...RequestEditorFn) (*GetQueryResultResponse, error) {
 	rsp, err := c.GetQueryResult(ctx, jobId, params, reqEditors...)
@@ -1748,37 +3865,240 @@ func (c *ClientWithResponses) ConnectResourceWithBodyWithResponse(ctx context.Co
 	return ParseConnectResourceResponse(rsp)
 }
 
-func (c *ClientWithResponses) ConnectResourceWithResponse(ctx context.Context, body ConnectResourceJSONRequestBody, reqEditors ...RequestEditorFn) (*ConnectResourceResponse, error) {
-	rsp, err := c.ConnectResource(ctx, body, reqEditors...)
-	if err != nil {
-		return nil, err
+func (c *ClientWithResponses) ConnectResourceWithResponse(ctx context.Context, body ConnectResourceJSONRequestBody, reqEditors ...RequestEditorFn) (*ConnectResourceResponse, error) {
+	rsp, err := c.ConnectResource(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseConnectResourceResponse(rsp)
+}
+
+// GetNodesWithResponse request returning *GetNodesResponse
+func (c *ClientWithResponses) GetNodesWithResponse(ctx context.Context, resourceName string, params *GetNodesParams, reqEditors ...RequestEditorFn) (*GetNodesResponse, error) {
+	rsp, err := c.GetNodes(ctx, resourceName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNodesResponse(rsp)
+}
+
+// GetPrivilegesWithResponse request returning *GetPrivilegesResponse
+func (c *ClientWithResponses) GetPrivilegesWithResponse(ctx context.Context, resourceName string, params *GetPrivilegesParams, reqEditors ...RequestEditorFn) (*GetPrivilegesResponse, error) {
+	rsp, err := c.GetPrivileges(ctx, resourceName, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPrivilegesResponse(rsp)
+}
+
+// OpenQuerySessionWithResponse request returning *OpenQuerySessionResponse
+func (c *ClientWithResponses) OpenQuerySessionWithResponse(ctx context.Context, resourceName string, reqEditors ...RequestEditorFn) (*OpenQuerySessionResponse, error) {
+	rsp, err := c.OpenQuerySession(ctx, resourceName, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseOpenQuerySessionResponse(rsp)
+}
+
+// CloseQuerySessionWithResponse request returning *CloseQuerySessionResponse
+func (c *ClientWithResponses) CloseQuerySessionWithResponse(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*CloseQuerySessionResponse, error) {
+	rsp, err := c.CloseQuerySession(ctx, resourceName, sessionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCloseQuerySessionResponse(rsp)
+}
+
+// GetQuerySessionWithResponse request returning *GetQuerySessionResponse
+func (c *ClientWithResponses) GetQuerySessionWithResponse(ctx context.Context, resourceName string, sessionId string, reqEditors ...RequestEditorFn) (*GetQuerySessionResponse, error) {
+	rsp, err := c.GetQuerySession(ctx, resourceName, sessionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetQuerySessionResponse(rsp)
+}
+
+// ParseStreamEventsResponse parses an HTTP response from a StreamEventsWithResponse call
+func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQueryHistoryResponse parses an HTTP response from a GetQueryHistoryWithResponse call
+func ParseGetQueryHistoryResponse(rsp *http.Response) (*GetQueryHistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQueryHistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QueryHistoryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExecQueryResponse parses an HTTP response from a ExecQueryWithResponse call
+func ParseExecQueryResponse(rsp *http.Response) (*ExecQueryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExecQueryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
+		var dest QueryExecResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON202 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 428:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON428 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
 	}
-	return ParseConnectResourceResponse(rsp)
-}
 
-// GetNodesWithResponse request returning *GetNodesResponse
-func (c *ClientWithResponses) GetNodesWithResponse(ctx context.Context, resourceName string, params *GetNodesParams, reqEditors ...RequestEditorFn) (*GetNodesResponse, error) {
-	rsp, err := c.GetNodes(ctx, resourceName, params, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseGetNodesResponse(rsp)
+	return response, nil
 }
 
-// ParseStreamEventsResponse parses an HTTP response from a StreamEventsWithResponse call
-func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error) {
+// ParseExplainQueryResponse parses an HTTP response from a ExplainQueryWithResponse call
+func ParseExplainQueryResponse(rsp *http.Response) (*ExplainQueryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &StreamEventsResponse{
+	response := &ExplainQueryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QueryPlanResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1791,20 +4111,34 @@ func ParseStreamEventsResponse(rsp *http.Response) (*StreamEventsResponse, error
 	return response, nil
 }
 
-// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
-func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+// ParseGetQueryStatusResponse parses an HTTP response from a GetQueryStatusWithResponse call
+func ParseGetQueryStatusResponse(rsp *http.Response) (*GetQueryStatusResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetHealthResponse{
+	response := &GetQueryStatusResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QueryJobStatusResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1817,22 +4151,24 @@ func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
 	return response, nil
 }
 
-// ParseExecQueryResponse parses an HTTP response from a ExecQueryWithResponse call
-func ParseExecQueryResponse(rsp *http.Response) (*ExecQueryResponse, error) {
+// ParseCancelQueryResponse parses an HTTP response from a CancelQueryWithResponse call
+func ParseCancelQueryResponse(rsp *http.Response) (*CancelQueryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ExecQueryResponse{
+	response := &CancelQueryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
-		var dest QueryExecResponse
+		var dest struct {
+			Status string `json:"status"`
+		}
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1857,27 +4193,20 @@ func ParseExecQueryResponse(rsp *http.Response) (*ExecQueryResponse, error) {
 	return response, nil
 }
 
-// ParseGetQueryStatusResponse parses an HTTP response from a GetQueryStatusWithResponse call
-func ParseGetQueryStatusResponse(rsp *http.Response) (*GetQueryStatusResponse, error) {
+// ParseExportQueryResultResponse parses an HTTP response from a ExportQueryResultWithResponse call
+func ParseExportQueryResultResponse(rsp *http.Response) (*ExportQueryResultResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetQueryStatusResponse{
+	response := &ExportQueryResultResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest QueryJobStatusResponse
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest ErrorPayload
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1897,28 +4226,26 @@ func ParseGetQueryStatusResponse(rsp *http.Response) (*GetQueryStatusResponse, e
 	return response, nil
 }
 
-// ParseCancelQueryResponse parses an HTTP response from a CancelQueryWithResponse call
-func ParseCancelQueryResponse(rsp *http.Response) (*CancelQueryResponse, error) {
+// ParseExportQueryResultToFileResponse parses an HTTP response from a ExportQueryResultToFileWithResponse call
+func ParseExportQueryResultToFileResponse(rsp *http.Response) (*ExportQueryResultToFileResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CancelQueryResponse{
+	response := &ExportQueryResultToFileResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 202:
-		var dest struct {
-			Status string `json:"status"`
-		}
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest QueryExportFileResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON202 = &dest
+		response.JSON201 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest ErrorPayload
@@ -1927,6 +4254,13 @@ func ParseCancelQueryResponse(rsp *http.Response) (*CancelQueryResponse, error)
 		}
 		response.JSON404 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest ErrorResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2098,3 +4432,184 @@ func ParseGetNodesResponse(rsp *http.Response) (*GetNodesResponse, error) {
 
 	return response, nil
 }
+
+// ParseGetPrivilegesResponse parses an HTTP response from a GetPrivilegesWithResponse call
+func ParseGetPrivilegesResponse(rsp *http.Response) (*GetPrivilegesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPrivilegesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RolePrivilegesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseOpenQuerySessionResponse parses an HTTP response from a OpenQuerySessionWithResponse call
+func ParseOpenQuerySessionResponse(rsp *http.Response) (*OpenQuerySessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OpenQuerySessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest QuerySession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCloseQuerySessionResponse parses an HTTP response from a CloseQuerySessionWithResponse call
+func ParseCloseQuerySessionResponse(rsp *http.Response) (*CloseQuerySessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CloseQuerySessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetQuerySessionResponse parses an HTTP response from a GetQuerySessionWithResponse call
+func ParseGetQuerySessionResponse(rsp *http.Response) (*GetQuerySessionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetQuerySessionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest QuerySession
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
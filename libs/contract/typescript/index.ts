@@ -1,4 +1,4 @@
 // This file is auto-generated by @hey-api/openapi-ts
 
-export { cancelQuery, connectResource, execQuery, getHealth, getNodes, getQueryResult, getQueryStatus, listResources, type Options, streamEvents } from './sdk.gen';
-export type { CancelQueryData, CancelQueryError, CancelQueryErrors, CancelQueryResponse, CancelQueryResponses, ClientOptions, ColumnNode, ColumnNodeAttributes, ConnectResourceData, ConnectResourceError, ConnectResourceErrors, ConnectResourceResponse, ConnectResourceResponses, ConstraintNode, ConstraintNodeAttributes, DatabaseNode, DatabaseNodeAttributes, ErrorPayload, ExecQueryData, ExecQueryError, ExecQueryErrors, ExecQueryResponse, ExecQueryResponses, GetHealthData, GetHealthError, GetHealthErrors, GetHealthResponse, GetHealthResponses, GetNodesData, GetNodesError, GetNodesErrors, GetNodesResponse, GetNodesResponses, GetQueryResultData, GetQueryResultError, GetQueryResultErrors, GetQueryResultResponse, GetQueryResultResponses, GetQueryStatusData, GetQueryStatusError, GetQueryStatusErrors, GetQueryStatusResponse, GetQueryStatusResponses, IndexNode, IndexNodeAttributes, ListResourcesData, ListResourcesError, ListResourcesErrors, ListResourcesResponse, ListResourcesResponses, Node, NodeBase, NodeEdge, NodesResponse, PasswordConfig, QueryExecOptions, QueryExecRequest, QueryExecResponse, QueryJobStatusResponse, QueryResultColumn, QueryResultResponse, Resource, ResourceConnectRequest, ResourceConnectResult, ResourcesResponse, SchemaNode, SchemaNodeAttributes, StreamEventsData, StreamEventsError, StreamEventsErrors, StreamEventsResponse, StreamEventsResponses, TableNode, TableNodeAttributes, TlsConfig, TriggerNode, TriggerNodeAttributes, ViewNode, ViewNodeAttributes } from './types.gen';
+export { cancelQuery, closeQuerySession, connectResource, execQuery, explainQuery, exportQueryResult, exportQueryResultToFile, getHealth, getNodes, getPrivileges, getQueryHistory, getQueryResult, getQuerySession, getQueryStatus, listResources, openQuerySession, type Options, streamEvents } from './sdk.gen';
+export type { CancelQueryData, CancelQueryError, CancelQueryErrors, CancelQueryResponse, CancelQueryResponses, ClientOptions, CloseQuerySessionData, CloseQuerySessionError, CloseQuerySessionErrors, CloseQuerySessionResponse, CloseQuerySessionResponses, ColumnNode, ColumnNodeAttributes, ConnectResourceData, ConnectResourceError, ConnectResourceErrors, ConnectResourceResponse, ConnectResourceResponses, ConstraintNode, ConstraintNodeAttributes, DatabaseNode, DatabaseNodeAttributes, ErrorPayload, ExecQueryData, ExecQueryError, ExecQueryErrors, ExecQueryResponse, ExecQueryResponses, ExplainQueryData, ExplainQueryError, ExplainQueryErrors, ExplainQueryResponse, ExplainQueryResponses, ExportQueryResultData, ExportQueryResultError, ExportQueryResultErrors, ExportQueryResultResponse, ExportQueryResultResponses, ExportQueryResultToFileData, ExportQueryResultToFileError, ExportQueryResultToFileErrors, ExportQueryResultToFileResponse, ExportQueryResultToFileResponses, GetHealthData, GetHealthError, GetHealthErrors, GetHealthResponse, GetHealthResponses, GetNodesData, GetNodesError, GetNodesErrors, GetNodesResponse, GetNodesResponses, GetPrivilegesData, GetPrivilegesError, GetPrivilegesErrors, GetPrivilegesResponse, GetPrivilegesResponses, GetQueryHistoryData, GetQueryHistoryError, GetQueryHistoryErrors, GetQueryHistoryResponse, GetQueryHistoryResponses, GetQueryResultData, GetQueryResultError, GetQueryResultErrors, GetQueryResultResponse, GetQueryResultResponses, GetQuerySessionData, GetQuerySessionError, GetQuerySessionErrors, GetQuerySessionResponse, GetQuerySessionResponses, GetQueryStatusData, GetQueryStatusError, GetQueryStatusErrors, GetQueryStatusResponse, GetQueryStatusResponses, IndexNode, IndexNodeAttributes, ListResourcesData, ListResourcesError, ListResourcesErrors, ListResourcesResponse, ListResourcesResponses, Node, NodeBase, NodeEdge, NodesResponse, ObjectPrivileges, OpenQuerySessionData, OpenQuerySessionError, OpenQuerySessionErrors, OpenQuerySessionResponse, OpenQuerySessionResponses, PasswordConfig, Privilege, QueryExecOptions, QueryExecRequest, QueryExecResponse, QueryExplainRequest, QueryExportFileRequest, QueryExportFileResponse, QueryExportFormat, QueryHistoryEntry, QueryHistoryResponse, QueryHistoryStatus, QueryJobStatusResponse, QueryPlanNode, QueryPlanResponse, QueryResultColumn, QueryResultResponse, QuerySession, QueryStatementStatus, QueryTaggedCell, QueryTxState, Resource, ResourceConnectRequest, ResourceConnectResult, ResourcesResponse, RolePrivilege, RolePrivilegesResponse, SchemaNode, SchemaNodeAttributes, StreamEventsData, StreamEventsError, StreamEventsErrors, StreamEventsResponse, StreamEventsResponses, TableNode, TableNodeAttributes, TlsConfig, TriggerNode, TriggerNodeAttributes, ViewNode, ViewNodeAttributes } from './types.gen';
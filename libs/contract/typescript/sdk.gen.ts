@@ -2,7 +2,7 @@
 
 import type { Client, Options as Options2, TDataShape } from './client';
 import { client } from './client.gen';
-import type { CancelQueryData, CancelQueryErrors, CancelQueryResponses, ConnectResourceData, ConnectResourceErrors, ConnectResourceResponses, ExecQueryData, ExecQueryErrors, ExecQueryResponses, GetHealthData, GetHealthErrors, GetHealthResponses, GetNodesData, GetNodesErrors, GetNodesResponses, GetQueryResultData, GetQueryResultErrors, GetQueryResultResponses, GetQueryStatusData, GetQueryStatusErrors, GetQueryStatusResponses, ListResourcesData, ListResourcesErrors, ListResourcesResponses, StreamEventsData, StreamEventsErrors, StreamEventsResponses } from './types.gen';
+import type { CancelQueryData, CancelQueryErrors, CancelQueryResponses, CloseQuerySessionData, CloseQuerySessionErrors, CloseQuerySessionResponses, ConnectResourceData, ConnectResourceErrors, ConnectResourceResponses, ExecQueryData, ExecQueryErrors, ExecQueryResponses, ExplainQueryData, ExplainQueryErrors, ExplainQueryResponses, ExportQueryResultData, ExportQueryResultErrors, ExportQueryResultResponses, ExportQueryResultToFileData, ExportQueryResultToFileErrors, ExportQueryResultToFileResponses, GetHealthData, GetHealthErrors, GetHealthResponses, GetNodesData, GetNodesErrors, GetNodesResponses, GetPrivilegesData, GetPrivilegesErrors, GetPrivilegesResponses, GetQueryHistoryData, GetQueryHistoryErrors, GetQueryHistoryResponses, GetQueryResultData, GetQueryResultErrors, GetQueryResultResponses, GetQuerySessionData, GetQuerySessionErrors, GetQuerySessionResponses, GetQueryStatusData, GetQueryStatusErrors, GetQueryStatusResponses, ListResourcesData, ListResourcesErrors, ListResourcesResponses, OpenQuerySessionData, OpenQuerySessionErrors, OpenQuerySessionResponses, StreamEventsData, StreamEventsErrors, StreamEventsResponses } from './types.gen';
 
 export type Options<TData extends TDataShape = TDataShape, ThrowOnError extends boolean = boolean> = Options2<TData, ThrowOnError> & {
     /**
@@ -45,6 +45,28 @@ export const connectResource = <ThrowOnError extends boolean = false>(options: O
  */
 export const getNodes = <ThrowOnError extends boolean = false>(options: Options<GetNodesData, ThrowOnError>) => (options.client ?? client).get<GetNodesResponses, GetNodesErrors, ThrowOnError>({ url: '/resources/{resourceName}/nodes', ...options });
 
+/**
+ * Summarize what a role can do in a resource
+ *
+ * Lists the schemas, tables, views and routines the role holds privileges on. Postgres only.
+ */
+export const getPrivileges = <ThrowOnError extends boolean = false>(options: Options<GetPrivilegesData, ThrowOnError>) => (options.client ?? client).get<GetPrivilegesResponses, GetPrivilegesErrors, ThrowOnError>({ url: '/resources/{resourceName}/privileges', ...options });
+
+/**
+ * Open a query session pinned to a dedicated connection
+ */
+export const openQuerySession = <ThrowOnError extends boolean = false>(options: Options<OpenQuerySessionData, ThrowOnError>) => (options.client ?? client).post<OpenQuerySessionResponses, OpenQuerySessionErrors, ThrowOnError>({ url: '/resources/{resourceName}/sessions', ...options });
+
+/**
+ * Close a query session, rolling back any open transaction
+ */
+export const closeQuerySession = <ThrowOnError extends boolean = false>(options: Options<CloseQuerySessionData, ThrowOnError>) => (options.client ?? client).delete<CloseQuerySessionResponses, CloseQuerySessionErrors, ThrowOnError>({ url: '/resources/{resourceName}/sessions/{sessionId}', ...options });
+
+/**
+ * Retrieve the state of a query session
+ */
+export const getQuerySession = <ThrowOnError extends boolean = false>(options: Options<GetQuerySessionData, ThrowOnError>) => (options.client ?? client).get<GetQuerySessionResponses, GetQuerySessionErrors, ThrowOnError>({ url: '/resources/{resourceName}/sessions/{sessionId}', ...options });
+
 /**
  * Execute a SQL query asynchronously
  */
@@ -57,8 +79,24 @@ export const execQuery = <ThrowOnError extends boolean = false>(options: Options
     }
 });
 
+/**
+ * Explain a SQL statement
+ *
+ * Runs the engine's plan command and returns a normalized plan tree. With analyze the statement is executed inside a transaction that is rolled back.
+ */
+export const explainQuery = <ThrowOnError extends boolean = false>(options: Options<ExplainQueryData, ThrowOnError>) => (options.client ?? client).post<ExplainQueryResponses, ExplainQueryErrors, ThrowOnError>({
+    url: '/queries/explain',
+    ...options,
+    headers: {
+        'Content-Type': 'application/json',
+        ...options.headers
+    }
+});
+
 /**
  * Cancel a running query job
+ *
+ * A queued job is removed from its resource's queue and finishes as canceled without starting.
  */
 export const cancelQuery = <ThrowOnError extends boolean = false>(options: Options<CancelQueryData, ThrowOnError>) => (options.client ?? client).post<CancelQueryResponses, CancelQueryErrors, ThrowOnError>({ url: '/queries/{jobId}/cancel', ...options });
 
@@ -68,10 +106,38 @@ export const cancelQuery = <ThrowOnError extends boolean = false>(options: Optio
 export const getQueryStatus = <ThrowOnError extends boolean = false>(options: Options<GetQueryStatusData, ThrowOnError>) => (options.client ?? client).get<GetQueryStatusResponses, GetQueryStatusErrors, ThrowOnError>({ url: '/queries/{jobId}', ...options });
 
 /**
- * Retrieve a previously stored query result view
+ * Retrieve a previously stored query result view, or a partial first page of a running job
  */
 export const getQueryResult = <ThrowOnError extends boolean = false>(options: Options<GetQueryResultData, ThrowOnError>) => (options.client ?? client).get<GetQueryResultResponses, GetQueryResultErrors, ThrowOnError>({ url: '/queries/{jobId}/result', ...options });
 
+/**
+ * Stream a stored query result as a file
+ *
+ * NULL cells are written as the null text, which defaults to an empty string (NULL for Markdown). Parquet types are inferred from the cell values.
+ */
+export const exportQueryResult = <ThrowOnError extends boolean = false>(options: Options<ExportQueryResultData, ThrowOnError>) => (options.client ?? client).get<ExportQueryResultResponses, ExportQueryResultErrors, ThrowOnError>({ url: '/queries/{jobId}/export', ...options });
+
+/**
+ * Write a stored query result to a file on the server
+ *
+ * For results too large to stream through the socket. The path must be absolute; existing files are kept unless overwrite is set.
+ */
+export const exportQueryResultToFile = <ThrowOnError extends boolean = false>(options: Options<ExportQueryResultToFileData, ThrowOnError>) => (options.client ?? client).post<ExportQueryResultToFileResponses, ExportQueryResultToFileErrors, ThrowOnError>({
+    url: '/queries/{jobId}/export',
+    ...options,
+    headers: {
+        'Content-Type': 'application/json',
+        ...options.headers
+    }
+});
+
+/**
+ * Search the history of finished query jobs
+ *
+ * History survives restarts and outlives stored results. Entries are returned newest first.
+ */
+export const getQueryHistory = <ThrowOnError extends boolean = false>(options?: Options<GetQueryHistoryData, ThrowOnError>) => (options?.client ?? client).get<GetQueryHistoryResponses, GetQueryHistoryErrors, ThrowOnError>({ url: '/history', ...options });
+
 /**
  * Subscribe to server-sent events for resource and query notifications
  */
@@ -45,6 +45,24 @@ export type Resource = {
      * Default SELECT auto-limit page size; null disables auto-limit
      */
     autoLimitRows?: number | null;
+    /**
+     * Milliseconds a query may run before it is canceled; on PostgreSQL also the connection's statement_timeout
+     */
+    queryTimeout?: number | null;
+    /**
+     * Jobs run at once on the resource; further jobs wait in a FIFO queue. Defaults to 1 for DuckDB and 4 otherwise; session jobs are not queued
+     */
+    maxConcurrentQueries?: number | null;
+    /**
+     * Open connections read-only and refuse statements classified as writes with error code read_only_resource
+     */
+    readOnly?: boolean;
+    /**
+     * What happens to UPDATE or DELETE without WHERE, DROP, TRUNCATE and ALTER ... DROP COLUMN.
+     * warn (default) runs them and lists the risks in the exec response; confirm refuses them until the request carries a confirmation token.
+     *
+     */
+    destructiveGuard?: 'off' | 'warn' | 'confirm';
     password?: PasswordConfig;
     tls?: TlsConfig;
 };
@@ -77,10 +95,33 @@ export type DatabaseNodeAttributes = {
     encoding?: string;
 };
 
+export type Privilege = {
+    /**
+     * Role holding the privilege, or PUBLIC
+     */
+    grantee: string;
+    /**
+     * SELECT, INSERT, UPDATE, DELETE, TRUNCATE, REFERENCES, TRIGGER, USAGE, CREATE or EXECUTE
+     */
+    privilege: string;
+    /**
+     * Whether the grantee may grant the privilege to others
+     */
+    grantOption: boolean;
+};
+
 export type SchemaNodeAttributes = {
     resource: string;
     engine: string;
     isDefault: boolean;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
 };
 
 export type TableNodeAttributes = {
@@ -88,6 +129,26 @@ export type TableNodeAttributes = {
     table: string;
     tableType: string;
     definition?: string;
+    /**
+     * Set on partitioned tables
+     */
+    partitionStrategy?: 'RANGE' | 'LIST' | 'HASH';
+    /**
+     * Partition key columns or expressions, such as "created_at"
+     */
+    partitionKey?: string;
+    /**
+     * Set on partitions, such as "FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')"
+     */
+    partitionBound?: string;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
 };
 
 export type ViewNodeAttributes = {
@@ -95,6 +156,58 @@ export type ViewNodeAttributes = {
     table: string;
     tableType: string;
     definition?: string;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
+};
+
+export type MatviewNodeAttributes = {
+    resource: string;
+    table: string;
+    tableType: string;
+    /**
+     * Query the view runs on REFRESH MATERIALIZED VIEW
+     */
+    definition?: string;
+    /**
+     * False until the view is first refreshed when created WITH NO DATA
+     */
+    populated: boolean;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
+};
+
+export type ForeignTableNodeAttributes = {
+    resource: string;
+    table: string;
+    tableType: string;
+    /**
+     * Foreign server the table reads from
+     */
+    server: string;
+    /**
+     * Table options, such as "table_name=orders"
+     */
+    options?: Array<string>;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
 };
 
 export type ColumnNodeAttributes = {
@@ -109,6 +222,15 @@ export type ColumnNodeAttributes = {
     charMaxLength?: number;
     numericPrecision?: number;
     numericScale?: number;
+    /**
+     * Set on identity columns
+     */
+    identity?: 'ALWAYS' | 'BY DEFAULT';
+    /**
+     * Set on generated columns; virtual columns are computed on read
+     */
+    generated?: 'STORED' | 'VIRTUAL';
+    generationExpression?: string;
 };
 
 export type ConstraintNodeAttributes = {
@@ -154,6 +276,126 @@ export type TriggerNodeAttributes = {
     definition?: string;
 };
 
+export type SequenceNodeAttributes = {
+    resource: string;
+    sequenceName: string;
+    dataType: string;
+    startValue: number;
+    increment: number;
+    minValue: number;
+    maxValue: number;
+    cycle: boolean;
+    /**
+     * Last value the sequence handed out; absent before first use
+     */
+    currentValue?: number;
+    /**
+     * Table of the column the sequence feeds, such as a serial or identity column
+     */
+    ownedByTable?: string;
+    ownedByColumn?: string;
+};
+
+export type TypeField = {
+    name: string;
+    dataType: string;
+};
+
+export type TypeNodeAttributes = {
+    resource: string;
+    typeName: string;
+    kind: 'enum' | 'domain' | 'composite';
+    /**
+     * Enum labels in sort order
+     */
+    labels?: Array<string>;
+    /**
+     * Type a domain is based on
+     */
+    baseType?: string;
+    /**
+     * Whether a domain rejects nulls
+     */
+    notNull: boolean;
+    /**
+     * Domain default
+     */
+    defaultValue?: string;
+    /**
+     * Domain CHECK constraints, such as "CHECK (VALUE > 0)"
+     */
+    constraints?: Array<string>;
+    /**
+     * Composite type attributes
+     */
+    fields?: Array<TypeField>;
+};
+
+export type ExtensionNodeAttributes = {
+    resource: string;
+    extensionName: string;
+    version: string;
+    /**
+     * Schema holding the extension's objects (Postgres); the extensions edge of the default schema lists every extension; when the search_path names no existing schema, public lists them, or the first schema when there is no public
+     */
+    schema?: string;
+    description?: string;
+    /**
+     * Whether the extension is loaded (DuckDB); installed extensions load on first use
+     */
+    loaded?: boolean;
+};
+
+export type FunctionNodeAttributes = {
+    resource: string;
+    functionName: string;
+    /**
+     * Function name with its argument types, unique among overloads
+     */
+    signature: string;
+    /**
+     * Argument list with names, modes and defaults
+     */
+    arguments?: string;
+    returnType?: string;
+    language: string;
+    volatility?: 'IMMUTABLE' | 'STABLE' | 'VOLATILE';
+    securityDefiner: boolean;
+    definition?: string;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
+};
+
+export type ProcedureNodeAttributes = {
+    resource: string;
+    procedureName: string;
+    /**
+     * Procedure name with its argument types, unique among overloads
+     */
+    signature: string;
+    /**
+     * Argument list with names, modes and defaults
+     */
+    arguments?: string;
+    language: string;
+    securityDefiner: boolean;
+    definition?: string;
+    /**
+     * Role owning the object (Postgres)
+     */
+    owner?: string;
+    /**
+     * Grants on the object, including the owner's defaults when none were made (Postgres)
+     */
+    privileges?: Array<Privilege>;
+};
+
 export type NodeBase = {
     id: string;
     name: string;
@@ -182,6 +424,16 @@ export type ViewNode = NodeBase & {
     attributes: ViewNodeAttributes;
 };
 
+export type MatviewNode = NodeBase & {
+    type: 'matview';
+    attributes: MatviewNodeAttributes;
+};
+
+export type ForeignTableNode = NodeBase & {
+    type: 'foreign_table';
+    attributes: ForeignTableNodeAttributes;
+};
+
 export type ColumnNode = NodeBase & {
     type: 'column';
     attributes: ColumnNodeAttributes;
@@ -202,6 +454,31 @@ export type TriggerNode = NodeBase & {
     attributes: TriggerNodeAttributes;
 };
 
+export type SequenceNode = NodeBase & {
+    type: 'sequence';
+    attributes: SequenceNodeAttributes;
+};
+
+export type TypeNode = NodeBase & {
+    type: 'type';
+    attributes: TypeNodeAttributes;
+};
+
+export type ExtensionNode = NodeBase & {
+    type: 'extension';
+    attributes: ExtensionNodeAttributes;
+};
+
+export type FunctionNode = NodeBase & {
+    type: 'function';
+    attributes: FunctionNodeAttributes;
+};
+
+export type ProcedureNode = NodeBase & {
+    type: 'procedure';
+    attributes: ProcedureNodeAttributes;
+};
+
 export type Node = ({
     type: 'database';
 } & DatabaseNode) | ({
@@ -211,6 +488,10 @@ export type Node = ({
 } & TableNode) | ({
     type: 'view';
 } & ViewNode) | ({
+    type: 'matview';
+} & MatviewNode) | ({
+    type: 'foreign_table';
+} & ForeignTableNode) | ({
     type: 'column';
 } & ColumnNode) | ({
     type: 'constraint';
@@ -218,43 +499,289 @@ export type Node = ({
     type: 'index';
 } & IndexNode) | ({
     type: 'trigger';
-} & TriggerNode);
+} & TriggerNode) | ({
+    type: 'function';
+} & FunctionNode) | ({
+    type: 'procedure';
+} & ProcedureNode) | ({
+    type: 'sequence';
+} & SequenceNode) | ({
+    type: 'type';
+} & TypeNode) | ({
+    type: 'extension';
+} & ExtensionNode);
 
 export type NodesResponse = {
     nodes: Array<Node>;
 };
 
+export type RolePrivilegesResponse = {
+    role: string;
+    /**
+     * Superusers pass every privilege check, so objects lists every object
+     */
+    superuser: boolean;
+    objects: Array<ObjectPrivileges>;
+};
+
+export type ObjectPrivileges = {
+    objectType: 'schema' | 'table' | 'view' | 'matview' | 'foreign_table' | 'function' | 'procedure';
+    schema: string;
+    /**
+     * Object name; routines are named by signature
+     */
+    name: string;
+    privileges: Array<RolePrivilege>;
+};
+
+export type RolePrivilege = {
+    privilege: string;
+    grantOption: boolean;
+};
+
 export type QueryExecOptions = {
     /**
-     * Requested result materialization limit, bounded by the server's ORI_MAX_MATERIALIZED_ROWS policy
+     * Requested result materialization limit. Bounded by ORI_MAX_MATERIALIZED_ROWS when results are memory-only; when results spill to disk only the disk quota applies
      */
     maxRows?: number;
+    /**
+     * Split the query into statements and run them in order on one connection as a single job
+     */
+    script?: boolean;
+    /**
+     * Whether a script stops at the first failing statement (default) or continues with the next one
+     */
+    onError?: 'stop' | 'continue';
+    /**
+     * Keep the query's cursor open and fetch further rows only when a result page asks for them. Not allowed with script or sessionId. DuckDB reads the whole result up front instead, since an open cursor would hold its only connection.
+     * The cursor closes once exhausted, at maxRows, after two idle minutes, or when it is the least recently paged of more than four open cursors on the resource; rows fetched until then stay readable.
+     * A DuckDB resource has a single connection, so its other queries wait while the cursor is open.
+     *
+     */
+    lazy?: boolean;
+    /**
+     * Rows fetched per round trip in lazy mode. Defaults to the resource's autoLimitRows
+     */
+    fetchRows?: number;
+    /**
+     * Milliseconds the job may run before it is canceled with status timed_out. Defaults to the resource's queryTimeout.
+     * On PostgreSQL a job outside a session or script also runs with this statement_timeout.
+     *
+     */
+    timeoutMs?: number;
+    /**
+     * Confirms a destructive query; the token comes from the confirmation_required error for the same resource and query
+     */
+    confirmToken?: string;
 };
 
 export type QueryExecRequest = {
     resourceName: string;
     jobId: string;
     query: string;
+    /**
+     * Positional values, or an object of values for :name / @name placeholders. Every placeholder needs a value and every value must be used.
+     */
     params?: {
         [key: string]: unknown;
     } | Array<unknown>;
+    /**
+     * Run the job on the connection pinned by this query session
+     */
+    sessionId?: string;
     options?: QueryExecOptions;
 };
 
+export type QueryExportFormat = 'csv' | 'tsv' | 'jsonl' | 'markdown' | 'parquet';
+
+export type QueryExportFileRequest = {
+    format: QueryExportFormat;
+    /**
+     * Absolute path on the server's filesystem
+     */
+    path: string;
+    overwrite?: boolean;
+    /**
+     * Write the column names first (CSV, TSV and Markdown). Defaults to true.
+     */
+    header?: boolean;
+    /**
+     * Text written for NULL cells in CSV, TSV and Markdown
+     */
+    null?: string;
+    /**
+     * Zero-based statement index of a script job. Defaults to the last statement.
+     */
+    statement?: number;
+};
+
+export type QueryExportFileResponse = {
+    path: string;
+    bytes: number;
+    rowCount: number;
+};
+
+export type QueryExplainRequest = {
+    resourceName: string;
+    /**
+     * A single statement
+     */
+    query: string;
+    /**
+     * Positional values, or an object of values for :name / @name placeholders.
+     */
+    params?: {
+        [key: string]: unknown;
+    } | Array<unknown>;
+    /**
+     * Execute the statement to collect actual rows and timings. Not supported by SQLite.
+     */
+    analyze?: boolean;
+};
+
+export type QueryPlanResponse = {
+    analyzed: boolean;
+    planningTimeMs?: number;
+    executionTimeMs?: number;
+    /**
+     * Top-level plan nodes; most engines return exactly one
+     */
+    nodes: Array<QueryPlanNode>;
+};
+
+/**
+ * A plan operator. Values the engine does not report are omitted.
+ */
+export type QueryPlanNode = {
+    nodeType: string;
+    relation?: string;
+    /**
+     * Engine-specific summary such as filters, join conditions or the index used
+     */
+    detail?: string;
+    estimatedRows?: number;
+    actualRows?: number;
+    startupCost?: number;
+    totalCost?: number;
+    actualTimeMs?: number;
+    loops?: number;
+    children: Array<QueryPlanNode>;
+};
+
 export type QueryExecResponse = {
     jobId: string;
-    status: 'running' | 'failed';
+    status: 'queued' | 'running' | 'failed';
+    /**
+     * 1-based position in the resource's queue when status is queued
+     */
+    queuePosition?: number;
+    /**
+     * Destructive operations detected in the query when the resource's destructiveGuard is warn
+     */
+    warnings?: Array<string>;
     message?: string | null;
 };
 
 export type QueryJobStatusResponse = {
     jobId: string;
     resourceName: string;
-    status: 'running' | 'success' | 'failed' | 'canceled';
+    status: 'queued' | 'running' | 'success' | 'failed' | 'canceled' | 'timed_out';
+    /**
+     * 1-based position in the resource's queue when status is queued
+     */
+    queuePosition?: number;
     finishedAt?: string;
     durationMs?: number;
     error?: string;
+    databaseError?: DatabaseError;
     stored: boolean;
+    /**
+     * Per-statement progress of a script job
+     */
+    statements?: Array<QueryStatementStatus>;
+    /**
+     * Query session the job ran in
+     */
+    sessionId?: string;
+    txState?: QueryTxState;
+    /**
+     * Notices the database sent while the job ran, in order; only the first 100 are kept
+     */
+    notices?: Array<QueryNotice>;
+    /**
+     * Notices received after the first 100, counted but not kept
+     */
+    droppedNotices?: number;
+};
+
+export type QueryHistoryStatus = 'success' | 'failed' | 'canceled' | 'timed_out';
+
+export type QueryHistoryEntry = {
+    jobId: string;
+    resourceName: string;
+    /**
+     * Query text as submitted, before named parameters were bound
+     */
+    query: string;
+    /**
+     * Parameter values as submitted
+     */
+    params?: {
+        [key: string]: unknown;
+    } | Array<unknown>;
+    status: QueryHistoryStatus;
+    error?: string;
+    /**
+     * Rows returned. Scripts report their last successful statement.
+     */
+    rowCount?: number;
+    rowsAffected?: number;
+    durationMs: number;
+    sessionId?: string;
+    script: boolean;
+    createdAt: string;
+    startedAt?: string;
+    finishedAt: string;
+};
+
+export type QueryHistoryResponse = {
+    entries: Array<QueryHistoryEntry>;
+    /**
+     * Number of matching entries across all pages
+     */
+    total: number;
+};
+
+export type QuerySession = {
+    sessionId: string;
+    resourceName: string;
+    txState: QueryTxState;
+    createdAt: string;
+    lastUsedAt: string;
+    activeJobId?: string | null;
+};
+
+/**
+ * Transaction state of a query session connection. Sessions left idle in a transaction are rolled back.
+ */
+export type QueryTxState = 'idle' | 'in_transaction' | 'failed';
+
+export type QueryStatementStatus = {
+    index: number;
+    /**
+     * 1-based line of the script on which the statement starts
+     */
+    line: number;
+    status: 'pending' | 'running' | 'success' | 'failed' | 'canceled' | 'skipped';
+    durationMs: number;
+    rowCount?: number | null;
+    rowsAffected?: number | null;
+    /**
+     * Completion tag of a data-modifying statement, such as INSERT 0 5 or UPDATE 3
+     */
+    commandTag?: string;
+    error?: string;
+    databaseError?: DatabaseError;
 };
 
 export type QueryResultColumn = {
@@ -264,10 +791,73 @@ export type QueryResultColumn = {
 
 export type QueryResultResponse = {
     columns: Array<QueryResultColumn>;
+    /**
+     * Rows of typed cells. A cell is null, a string, a boolean, a number (integers only within the exact float64 range), or a QueryTaggedCell.
+     */
     rows: Array<Array<unknown>>;
+    /**
+     * Number of rows in the view; counts only matching rows when filters are applied
+     */
     rowCount: number;
     truncated: boolean;
     rowsAffected?: number | null;
+    /**
+     * Completion tag of a data-modifying statement, such as INSERT 0 5 or UPDATE 3; returned rows are the rows it affected
+     */
+    commandTag?: string;
+    /**
+     * Set while the job is still running; rows hold the first rows fetched so far and rowCount counts every row fetched so far
+     */
+    partial?: boolean;
+    /**
+     * Set while a lazy result's cursor is open; rowCount counts only rows fetched so far and later pages fetch more
+     */
+    hasMore?: boolean;
+    /**
+     * Notices the database sent while the job ran, limited to the requested statement of a script
+     */
+    notices?: Array<QueryNotice>;
+};
+
+/**
+ * A notice or warning the database sent while a job ran, such as RAISE NOTICE output
+ */
+export type QueryNotice = {
+    /**
+     * Severity reported by the database, such as NOTICE, WARNING or INFO
+     */
+    severity: string;
+    /**
+     * SQLSTATE code of the notice
+     */
+    code: string;
+    message: string;
+    /**
+     * Index of the script statement that raised the notice
+     */
+    statement?: number;
+};
+
+/**
+ * A cell value JSON cannot represent natively, tagged with its type
+ */
+export type QueryTaggedCell = {
+    $type: 'decimal' | 'bytes' | 'timestamp' | 'timestamptz' | 'date' | 'time' | 'interval' | 'uuid' | 'array' | 'json';
+    /**
+     * decimal, interval, time and uuid hold text; bytes holds base64; timestamptz holds RFC 3339 with offset;
+     * timestamp holds RFC 3339 without offset; date holds YYYY-MM-DD; array holds a (possibly nested) list of cells;
+     * json holds the parsed document.
+     *
+     */
+    value: unknown;
+    /**
+     * Byte length of a bytes cell
+     */
+    length?: number;
+    /**
+     * Element type name of an array cell, when known
+     */
+    elementType?: string;
 };
 
 export type ErrorPayload = {
@@ -276,6 +866,41 @@ export type ErrorPayload = {
     details?: {
         [key: string]: unknown;
     };
+    databaseError?: DatabaseError;
+};
+
+/**
+ * Structured fields of an error the database reported. Fields the engine does not report are omitted.
+ */
+export type DatabaseError = {
+    /**
+     * Engine that reported the error, such as postgres, sqlite or duckdb
+     */
+    engine: string;
+    /**
+     * SQLSTATE on PostgreSQL, result code name on SQLite, error type on DuckDB
+     */
+    code?: string;
+    severity?: string;
+    message: string;
+    detail?: string;
+    hint?: string;
+    /**
+     * 1-based character offset of the error within the failed statement
+     */
+    position?: number;
+    /**
+     * 1-based line of the error within the submitted query text
+     */
+    line?: number;
+    /**
+     * 1-based column of the error within its line
+     */
+    column?: number;
+    schema?: string;
+    table?: string;
+    columnName?: string;
+    constraint?: string;
 };
 
 export type GetHealthData = {
@@ -400,6 +1025,157 @@ export type GetNodesResponses = {
 
 export type GetNodesResponse = GetNodesResponses[keyof GetNodesResponses];
 
+export type GetPrivilegesData = {
+    body?: never;
+    path: {
+        resourceName: string;
+    };
+    query?: {
+        /**
+         * Role to check; defaults to the role the resource connects as
+         */
+        role?: string;
+        /**
+         * Only list the schema and the objects in it; defaults to every schema
+         */
+        schema?: string;
+    };
+    url: '/resources/{resourceName}/privileges';
+};
+
+export type GetPrivilegesErrors = {
+    /**
+     * The resource's engine does not report privileges (code privileges_unsupported)
+     */
+    400: ErrorPayload;
+    /**
+     * Role not found (code role_not_found)
+     */
+    404: ErrorPayload;
+    /**
+     * Resource is not connected
+     */
+    409: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type GetPrivilegesError = GetPrivilegesErrors[keyof GetPrivilegesErrors];
+
+export type GetPrivilegesResponses = {
+    /**
+     * Privileges held by the role
+     */
+    200: RolePrivilegesResponse;
+};
+
+export type GetPrivilegesResponse = GetPrivilegesResponses[keyof GetPrivilegesResponses];
+
+export type OpenQuerySessionData = {
+    body?: never;
+    path: {
+        resourceName: string;
+    };
+    query?: never;
+    url: '/resources/{resourceName}/sessions';
+};
+
+export type OpenQuerySessionErrors = {
+    /**
+     * Resource cannot back a session, such as DuckDB's single connection (code sessions_unsupported)
+     */
+    400: ErrorPayload;
+    /**
+     * Resource is not connected
+     */
+    409: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type OpenQuerySessionError = OpenQuerySessionErrors[keyof OpenQuerySessionErrors];
+
+export type OpenQuerySessionResponses = {
+    /**
+     * Session opened
+     */
+    201: QuerySession;
+};
+
+export type OpenQuerySessionResponse = OpenQuerySessionResponses[keyof OpenQuerySessionResponses];
+
+export type CloseQuerySessionData = {
+    body?: never;
+    path: {
+        resourceName: string;
+        sessionId: string;
+    };
+    query?: never;
+    url: '/resources/{resourceName}/sessions/{sessionId}';
+};
+
+export type CloseQuerySessionErrors = {
+    /**
+     * Session not found
+     */
+    404: ErrorPayload;
+    /**
+     * Session is running a job
+     */
+    409: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type CloseQuerySessionError = CloseQuerySessionErrors[keyof CloseQuerySessionErrors];
+
+export type CloseQuerySessionResponses = {
+    /**
+     * Session closed
+     */
+    204: void;
+};
+
+export type CloseQuerySessionResponse = CloseQuerySessionResponses[keyof CloseQuerySessionResponses];
+
+export type GetQuerySessionData = {
+    body?: never;
+    path: {
+        resourceName: string;
+        sessionId: string;
+    };
+    query?: never;
+    url: '/resources/{resourceName}/sessions/{sessionId}';
+};
+
+export type GetQuerySessionErrors = {
+    /**
+     * Session not found
+     */
+    404: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type GetQuerySessionError = GetQuerySessionErrors[keyof GetQuerySessionErrors];
+
+export type GetQuerySessionResponses = {
+    /**
+     * Query session state
+     */
+    200: QuerySession;
+};
+
+export type GetQuerySessionResponse = GetQuerySessionResponses[keyof GetQuerySessionResponses];
+
 export type ExecQueryData = {
     body: QueryExecRequest;
     path?: never;
@@ -408,10 +1184,20 @@ export type ExecQueryData = {
 };
 
 export type ExecQueryErrors = {
+    /**
+     * Resource is read-only and the query writes (code read_only_resource)
+     */
+    403: ErrorPayload;
     /**
      * Resource not found or no active resource
      */
     404: ErrorPayload;
+    /**
+     * The query may destroy data and the resource requires confirmation (code confirmation_required).
+     * details.risks lists what was detected; resend the request with options.confirmToken set to details.confirmToken.
+     *
+     */
+    428: ErrorPayload;
     /**
      * Generic error payload
      */
@@ -429,6 +1215,39 @@ export type ExecQueryResponses = {
 
 export type ExecQueryResponse = ExecQueryResponses[keyof ExecQueryResponses];
 
+export type ExplainQueryData = {
+    body: QueryExplainRequest;
+    path?: never;
+    query?: never;
+    url: '/queries/explain';
+};
+
+export type ExplainQueryErrors = {
+    /**
+     * Invalid request, unsupported option or the engine rejected the statement
+     */
+    400: ErrorPayload;
+    /**
+     * Resource is read-only and analyze would run a write (code read_only_resource)
+     */
+    403: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type ExplainQueryError = ExplainQueryErrors[keyof ExplainQueryErrors];
+
+export type ExplainQueryResponses = {
+    /**
+     * Query plan
+     */
+    200: QueryPlanResponse;
+};
+
+export type ExplainQueryResponse = ExplainQueryResponses[keyof ExplainQueryResponses];
+
 export type CancelQueryData = {
     body?: never;
     path: {
@@ -501,6 +1320,18 @@ export type GetQueryResultData = {
     query?: {
         limit?: number;
         offset?: number;
+        /**
+         * Zero-based statement index of a script job. Defaults to the last statement.
+         */
+        statement?: number;
+        /**
+         * Sort by a column as column[:asc|desc]. Repeat to break ties. Nulls sort last ascending and first descending.
+         */
+        sort?: Array<string>;
+        /**
+         * Keep rows matching column:op[:value], where op is eq, contains (case-insensitive), isnull, notnull, gt, gte, lt or lte (numeric). Repeated filters must all match.
+         */
+        filter?: Array<string>;
     };
     url: '/queries/{jobId}/result';
 };
@@ -527,6 +1358,138 @@ export type GetQueryResultResponses = {
 
 export type GetQueryResultResponse = GetQueryResultResponses[keyof GetQueryResultResponses];
 
+export type ExportQueryResultData = {
+    body?: never;
+    path: {
+        jobId: string;
+    };
+    query: {
+        format: QueryExportFormat;
+        /**
+         * Write the column names first (CSV, TSV and Markdown). Defaults to true.
+         */
+        header?: boolean;
+        /**
+         * Text written for NULL cells in CSV, TSV and Markdown
+         */
+        null?: string;
+        /**
+         * Zero-based statement index of a script job. Defaults to the last statement.
+         */
+        statement?: number;
+    };
+    url: '/queries/{jobId}/export';
+};
+
+export type ExportQueryResultErrors = {
+    /**
+     * Job or statement not found
+     */
+    404: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type ExportQueryResultError = ExportQueryResultErrors[keyof ExportQueryResultErrors];
+
+export type ExportQueryResultResponses = {
+    /**
+     * Exported result
+     */
+    200: string | Blob | File;
+};
+
+export type ExportQueryResultResponse = ExportQueryResultResponses[keyof ExportQueryResultResponses];
+
+export type ExportQueryResultToFileData = {
+    body: QueryExportFileRequest;
+    path: {
+        jobId: string;
+    };
+    query?: never;
+    url: '/queries/{jobId}/export';
+};
+
+export type ExportQueryResultToFileErrors = {
+    /**
+     * Job or statement not found
+     */
+    404: ErrorPayload;
+    /**
+     * The file exists and overwrite is not set
+     */
+    409: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type ExportQueryResultToFileError = ExportQueryResultToFileErrors[keyof ExportQueryResultToFileErrors];
+
+export type ExportQueryResultToFileResponses = {
+    /**
+     * Result written
+     */
+    201: QueryExportFileResponse;
+};
+
+export type ExportQueryResultToFileResponse = ExportQueryResultToFileResponses[keyof ExportQueryResultToFileResponses];
+
+export type GetQueryHistoryData = {
+    body?: never;
+    path?: never;
+    query?: {
+        /**
+         * Whitespace-separated terms that must all appear in the query text or error message, ignoring case
+         */
+        q?: string;
+        resource?: string;
+        /**
+         * Keep jobs with any of these statuses
+         */
+        status?: Array<QueryHistoryStatus>;
+        /**
+         * Keep jobs that finished at or after this time
+         */
+        since?: string;
+        /**
+         * Keep jobs that finished before this time
+         */
+        until?: string;
+        /**
+         * Page size. Defaults to 50, at most 500.
+         */
+        limit?: number;
+        offset?: number;
+    };
+    url: '/history';
+};
+
+export type GetQueryHistoryErrors = {
+    /**
+     * History is disabled because its database could not be opened
+     */
+    503: ErrorPayload;
+    /**
+     * Generic error payload
+     */
+    default: ErrorPayload;
+};
+
+export type GetQueryHistoryError = GetQueryHistoryErrors[keyof GetQueryHistoryErrors];
+
+export type GetQueryHistoryResponses = {
+    /**
+     * Matching history entries
+     */
+    200: QueryHistoryResponse;
+};
+
+export type GetQueryHistoryResponse = GetQueryHistoryResponses[keyof GetQueryHistoryResponses];
+
 export type StreamEventsData = {
     body?: never;
     path?: never;
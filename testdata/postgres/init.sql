@@ -152,6 +152,55 @@ INSERT INTO books (id, author_id, title, isbn) VALUES (1, 1, 'Analytical Sketche
 INSERT INTO events (id, happened_on, payload) VALUES (1, '2024-06-01', 'public partition row') ON CONFLICT DO NOTHING;
 INSERT INTO events (id, happened_on, payload) VALUES (2, '2025-02-01', 'archive partition row') ON CONFLICT DO NOTHING;
 
+-- User-defined types: enum, domain and composite
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'book_format') THEN
+        CREATE TYPE book_format AS ENUM ('hardcover', 'paperback', 'ebook');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'positive_price') THEN
+        CREATE DOMAIN positive_price AS NUMERIC(10, 2) NOT NULL DEFAULT 0 CHECK (VALUE >= 0);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'shelf_position') THEN
+        CREATE TYPE shelf_position AS (aisle INTEGER, shelf TEXT);
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS book_listings (
+    book_id INTEGER PRIMARY KEY REFERENCES books(id),
+    format book_format NOT NULL,
+    price positive_price,
+    position shelf_position
+);
+
+-- Materialized views (populated and WITH NO DATA)
+CREATE MATERIALIZED VIEW IF NOT EXISTS author_book_counts AS
+    SELECT a.id AS author_id, count(b.id) AS book_count
+    FROM authors a
+    LEFT JOIN books b ON b.author_id = a.id
+    GROUP BY a.id;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS pending_book_titles AS
+    SELECT id, title FROM books
+    WITH NO DATA;
+
+-- Foreign table reading back from this database through postgres_fdw
+CREATE EXTENSION IF NOT EXISTS postgres_fdw;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_foreign_server WHERE srvname = 'loopback') THEN
+        CREATE SERVER loopback FOREIGN DATA WRAPPER postgres_fdw
+            OPTIONS (host 'localhost', dbname 'testdb');
+    END IF;
+END $$;
+
+CREATE FOREIGN TABLE IF NOT EXISTS remote_authors (
+    id INTEGER,
+    name TEXT
+) SERVER loopback OPTIONS (schema_name 'public', table_name 'authors');
+
 -- Create a role for macOS keychain-backed testing
 DO $$
 BEGIN
@@ -176,6 +225,18 @@ ALTER DEFAULT PRIVILEGES IN SCHEMA public
 ALTER DEFAULT PRIVILEGES IN SCHEMA archive
     GRANT USAGE, SELECT ON SEQUENCES TO kc_pg_user;
 
+-- Create a role whose search_path names no existing schema, so current_schema() is NULL
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'nopath_pg_user') THEN
+        CREATE ROLE nopath_pg_user LOGIN PASSWORD 'testpassword123';
+    END IF;
+END $$;
+
+ALTER ROLE nopath_pg_user SET search_path = missing_schema;
+GRANT USAGE ON SCHEMA public TO nopath_pg_user;
+GRANT USAGE ON SCHEMA archive TO nopath_pg_user;
+
 -- Reset sequences to account for explicit id inserts
 SELECT setval('authors_id_seq', (SELECT MAX(id) FROM authors));
 SELECT setval('books_id_seq', (SELECT MAX(id) FROM books));